@@ -40,12 +40,15 @@ func dataSourceFolder() *schema.Resource {
 	}
 }
 
+// dataSourceFolderFields are the fields of a folder the data source sets.
+var dataSourceFolderFields = []string{"id", "name"}
+
 func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Info(ctx, "Querying Looker Folder")
 	var folder = lookergo.Folder{}
 	if folderId, exists := d.GetOk("id"); exists { // Query using ID
-		newfolder, _, err := c.Folders.Get(ctx, folderId.(string))
+		newfolder, _, err := c.Folders.Get(ctx, lookergo.ID(folderId.(string)), &lookergo.GetOptions{Fields: dataSourceFolderFields})
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -57,7 +60,7 @@ func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 
 	} else if folderNameKey, exists := d.GetOk("name"); exists { // Query using Name
-		folders, _, err := c.Folders.ListByName(ctx, folderNameKey.(string), &lookergo.ListOptions{})
+		folders, err := listAll(ctx, &lookergo.ListOptions{Fields: dataSourceFolderFields}, c.Folders.ListByName, folderNameKey.(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -75,7 +78,7 @@ func dataSourceFolderRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.Errorf("Neither name, nor id provided.")
 	}
 
-	d.SetId(folder.Id)
+	d.SetId(folder.Id.String())
 	d.Set("name", folder.Name)
 	return diags
 }
@@ -53,12 +53,15 @@ func dataSourceGroup() *schema.Resource {
 	}
 }
 
+// dataSourceGroupFields are the fields of a group the data source sets.
+var dataSourceGroupFields = []string{"id", "name", "user_count", "parent_group_ids", "role_ids"}
+
 func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Info(ctx, "Querying Looker Group")
 	var group = lookergo.Group{}
 	if groupIDKey, exists := d.GetOk("id"); exists { // Query using ID
-		groups, _, err := c.Groups.ListById(ctx, []int{idAsInt(groupIDKey)}, nil)
+		groups, err := listAll(ctx, &lookergo.ListOptions{Fields: dataSourceGroupFields}, c.Groups.ListById, []lookergo.ID{lookergo.ID(groupIDKey.(string))})
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -68,7 +71,7 @@ func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interfac
 			return diag.Errorf("No results found for: %v", groupIDKey)
 		}
 	} else if groupNameKey, exists := d.GetOk("name"); exists { // Query using Name
-		groups, _, err := c.Groups.ListByName(ctx, groupNameKey.(string), nil)
+		groups, err := listAll(ctx, &lookergo.ListOptions{Fields: dataSourceGroupFields}, c.Groups.ListByName, groupNameKey.(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -81,11 +84,11 @@ func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.Errorf("Neither name, nor id provided.")
 	}
 
-	d.SetId(idAsString(group.Id))
+	d.SetId(group.Id.String())
 	d.Set("name", group.Name)
 	d.Set("user_count", group.UserCount)
-	d.Set("parent_groups", group.ParentGroupIds.ToSliceOfStrings())
-	d.Set("roles", group.RoleIds.ToSliceOfStrings())
+	d.Set("parent_groups", group.ParentGroupIds.Strings())
+	d.Set("roles", group.RoleIds.Strings())
 
 	return diags
 }
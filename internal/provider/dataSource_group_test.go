@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGroup(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(srv, `
+resource "looker_group" "parent" {
+  name = "Marketing"
+}
+
+resource "looker_group" "child" {
+  name = "Campaigns"
+}
+
+resource "looker_group_member" "test" {
+  target_group_id = looker_group.parent.id
+  group {
+    id = looker_group.child.id
+  }
+}
+
+data "looker_group" "by_name" {
+  name       = "Campaigns"
+  depends_on = [looker_group_member.test]
+}
+
+data "looker_group" "by_id" {
+  id         = looker_group.child.id
+  depends_on = [looker_group_member.test]
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.looker_group.by_name", "id", "looker_group.child", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.looker_group.by_name", "parent_groups.*", "looker_group.parent", "id"),
+					resource.TestCheckResourceAttr("data.looker_group.by_id", "name", "Campaigns"),
+					resource.TestCheckTypeSetElemAttrPair("data.looker_group.by_id", "parent_groups.*", "looker_group.parent", "id"),
+				),
+			},
+		},
+	})
+}
@@ -43,12 +43,15 @@ func dataSourcePermissionSet() *schema.Resource {
 	}
 }
 
+// dataSourcePermissionSetFields are the fields of a permission set the data source sets.
+var dataSourcePermissionSetFields = []string{"id", "name", "permissions"}
+
 func dataSourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Info(ctx, "Querying Looker Permission Set")
 	var permissionSet = lookergo.PermissionSet{}
 	if psId, exists := d.GetOk("id"); exists { // Query using ID
-		ps, _, err := c.PermissionSets.Get(ctx, psId.(string))
+		ps, _, err := c.PermissionSets.Get(ctx, lookergo.ID(psId.(string)), &lookergo.GetOptions{Fields: dataSourcePermissionSetFields})
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -58,7 +61,7 @@ func dataSourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m
 			permissionSet.Permissions = ps.Permissions
 		}
 	} else if psNameKey, exists := d.GetOk("name"); exists { // Query using Name
-		psSet, _, err := c.PermissionSets.GetByName(ctx, psNameKey.(string), &lookergo.ListOptions{})
+		psSet, err := listAll(ctx, &lookergo.ListOptions{Fields: dataSourcePermissionSetFields}, c.PermissionSets.GetByName, psNameKey.(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -78,7 +81,7 @@ func dataSourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m
 	} else {
 		return diag.Errorf("Neither name, nor id provided.")
 	}
-	d.SetId(permissionSet.Id)
+	d.SetId(permissionSet.Id.String())
 	d.Set("name", permissionSet.Name)
 	d.Set("permissions", permissionSet.Permissions)
 	return diags
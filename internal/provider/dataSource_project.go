@@ -58,21 +58,21 @@ func dataSourceProject() *schema.Resource {
 	}
 }
 
+// dataSourceProjectFields are the fields of a project the data source sets.
+var dataSourceProjectFields = []string{
+	"id", "name", "uses_git", "git_remote_url", "git_username", "validation_required", "allow_warnings", "is_example",
+	"git_service_name",
+}
+
 func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	if err := ensureDevClient(ctx, m); err != nil {
-		return diagErrAppend(diags, err)
-	}
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
 
 	projectId := d.Get("name").(string)
 	var project *lookergo.Project
-	project, _, err = dc.Projects.Get(ctx, projectId)
+	project, _, err = dc.Projects.Get(ctx, projectId, &lookergo.GetOptions{Fields: dataSourceProjectFields})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/net/context"
@@ -37,12 +38,15 @@ func dataSourceUser() *schema.Resource {
 	}
 }
 
+// dataSourceUserFields are the fields of a user the data source sets.
+var dataSourceUserFields = []string{"id", "first_name", "last_name", "role_ids", "credentials_email(email)", "credentials_saml(email)"}
+
 func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 
 	userId := d.Get("id").(string)
 
-	user, _, err := c.Users.Get(ctx, userId)
+	user, _, err := c.Users.Get(ctx, lookergo.ID(userId), &lookergo.GetOptions{Fields: dataSourceUserFields})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -55,7 +59,7 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	if user.RoleIds != nil {
-		err = d.Set("roles", user.RoleIds.ToSliceOfStrings())
+		err = d.Set("roles", user.RoleIds.Strings())
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -66,14 +70,14 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 			return diag.FromErr(err)
 		}
 	} else if user.CredentialsSaml != nil {
-		if err := d.Set("email", user.CredentialsEmail.Email); err != nil {
+		if err := d.Set("email", user.CredentialsSaml.Email); err != nil {
 			return diag.FromErr(err)
 		}
 	} else if err := d.Set("email", ""); err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(user.Id)
+	d.SetId(user.Id.String())
 
 	return diags
 }
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceUser(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(srv, `
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = "Doe"
+  email      = "jane@example.com"
+  roles      = ["`+lookertest.AdminRoleID+`"]
+}
+
+data "looker_user" "test" {
+  id = looker_user.test.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_user.test", "first_name", "Jane"),
+					resource.TestCheckResourceAttr("data.looker_user.test", "last_name", "Doe"),
+					resource.TestCheckResourceAttr("data.looker_user.test", "email", "jane@example.com"),
+					resource.TestCheckTypeSetElemAttr("data.looker_user.test", "roles.*", lookertest.AdminRoleID),
+				),
+			},
+		},
+	})
+}
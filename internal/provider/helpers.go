@@ -3,44 +3,42 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"runtime"
-	"strconv"
+	"sort"
+	"strings"
 
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func idAsString(val interface{}) string {
-	var ret string
-	switch v := val.(type) {
-	case int:
-		ret = strconv.Itoa(v)
-	case string:
-		if _, err := strconv.Atoi(v); err != nil {
-			panic(err)
-		}
-		ret = v
+// goneFromRemote marks the resource as deleted when err says it no longer exists in Looker,
+// so that Terraform plans to re-create it. It returns true when the caller should stop reading.
+func goneFromRemote(ctx context.Context, d *schema.ResourceData, err error) bool {
+	if !lookergo.IsNotFound(err) {
+		return false
 	}
-	return ret
-}
 
-func idAsInt(val interface{}) int {
-	var ret int
-	switch v := val.(type) {
-	case int:
-		ret = v
-	case string:
-		id, err := strconv.Atoi(v)
-		if err != nil {
-			panic(err)
-		}
-		ret = id
-	}
+	tflog.Warn(ctx, "Resource not found, removing it from state", map[string]interface{}{"id": d.Id()})
+	d.SetId("")
+	return true
+}
 
-	return ret
+// listAll returns every item of a paginated list of lookergo called with arg, e.g.
+//
+//	users, err := listAll(ctx, nil, c.Users.ListByEmail, email)
+//
+// Do not use it for the lists Looker does not page, such as the members of a group: they ignore limit and offset,
+// so listAll would fetch them again and again. Call their List method once instead.
+func listAll[T, A any](ctx context.Context, opt *lookergo.ListOptions, list func(context.Context, A, *lookergo.ListOptions) ([]T, *lookergo.Response, error), arg A) ([]T, error) {
+	items, _, err := lookergo.ListAll(ctx, opt, func(ctx context.Context, opt *lookergo.ListOptions) ([]T, *lookergo.Response, error) {
+		return list(ctx, arg, opt)
+	})
+	return items, err
 }
 
 func currFuncName() string {
@@ -61,6 +59,76 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+// changedString returns the update of a string attribute: nil when it did not change, null when it was
+// removed from the configuration, and its value otherwise.
+func changedString(d *schema.ResourceData, key string) *lookergo.Nullable[string] {
+	if !d.HasChange(key) {
+		return nil
+	}
+	return lookergo.NullableString(d.Get(key).(string))
+}
+
+// changedValue returns the update of an attribute, nil when it did not change. Attributes removed from the
+// configuration are sent as their zero value, e.g. false.
+func changedValue[T any](d *schema.ResourceData, key string) *lookergo.Nullable[T] {
+	if !d.HasChange(key) {
+		return nil
+	}
+	return lookergo.Value(d.Get(key).(T))
+}
+
+// changedInt64 is changedValue for int attributes sent as int64.
+func changedInt64(d *schema.ResourceData, key string) *lookergo.Nullable[int64] {
+	if !d.HasChange(key) {
+		return nil
+	}
+	return lookergo.Value(int64(d.Get(key).(int)))
+}
+
+// changedID is changedValue for string attributes holding an id.
+func changedID(d *schema.ResourceData, key string) *lookergo.Nullable[lookergo.ID] {
+	if !d.HasChange(key) {
+		return nil
+	}
+	return lookergo.Value(lookergo.ID(d.Get(key).(string)))
+}
+
+// changedStringSet returns the update of a set of strings, nil when it did not change. An empty set is sent
+// as an empty list.
+func changedStringSet(d *schema.ResourceData, key string) *lookergo.Nullable[[]string] {
+	if !d.HasChange(key) {
+		return nil
+	}
+	return lookergo.Value(schemaSetToStringSlice(d.Get(key).(*schema.Set)))
+}
+
+// requireVersions returns a CustomizeDiffFunc rejecting at plan time the attributes set in the configuration which
+// the Looker instance is too old for. since maps top level attributes to the first release supporting them.
+func requireVersions(since map[string]string) schema.CustomizeDiffFunc {
+	attrs := make([]string, 0, len(since))
+	for attr := range since {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		config, ok := m.(*Config)
+		raw := d.GetRawConfig()
+		if !ok || raw.IsNull() || !raw.IsKnown() {
+			return nil
+		}
+		for _, attr := range attrs {
+			if raw.GetAttr(attr).IsNull() {
+				continue
+			}
+			if err := config.RequireVersion(since[attr], attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 func logTrace(ctx context.Context, msg string, additional ...any) {
 	add := make(map[string]interface{})
 	pc, _, _, ok := runtime.Caller(1)
@@ -82,6 +150,18 @@ func logTrace(ctx context.Context, msg string, additional ...any) {
 	tflog.Trace(ctx, msg, add)
 }
 
+// traceLoggingEnabled reports whether the logs of the provider are at TRACE level, as set by
+// TF_LOG_PROVIDER_LOOKER, TF_LOG_PROVIDER or TF_LOG, from the most specific.
+func traceLoggingEnabled() bool {
+	for _, env := range []string{"TF_LOG_PROVIDER_LOOKER", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := strings.ToUpper(os.Getenv(env)); level != "" {
+			// TF_LOG=JSON logs everything, as JSON.
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+	return false
+}
+
 func logErrDiag(ctx context.Context, diags diag.Diagnostics, msg string, varName string, varValue interface{}) diag.Diagnostics {
 	pc, _, _, ok := runtime.Caller(1)
 	details := runtime.FuncForPC(pc)
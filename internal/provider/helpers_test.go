@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestTraceLoggingEnabled(t *testing.T) {
+	tests := []struct {
+		log, provider, looker string
+		want                  bool
+	}{
+		{"", "", "", false},
+		{"trace", "", "", true},
+		{"JSON", "", "", true},
+		{"TRACE", "DEBUG", "", false},
+		{"DEBUG", "", "TRACE", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("TF_LOG", tt.log)
+		t.Setenv("TF_LOG_PROVIDER", tt.provider)
+		t.Setenv("TF_LOG_PROVIDER_LOOKER", tt.looker)
+		if got := traceLoggingEnabled(); got != tt.want {
+			t.Errorf("traceLoggingEnabled() with %+v = %v, expected %v", tt, got, tt.want)
+		}
+	}
+}
@@ -2,19 +2,26 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"reflect"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -39,9 +46,8 @@ func New(version string) func() *schema.Provider {
 		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
 				"base_url": {
-					Description: "For base_url, provide the URL including /api/ ! " +
-						"Normally, a REST API should not have api in it's path, " +
-						"therefore we don't add the /api/ inside the provider. ",
+					Description: "URL of the API, e.g. `https://org.cloud.looker.com:19999/api/`. " +
+						"The /api/ path is added when missing.",
 					Type:        schema.TypeString,
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("LOOKER_BASE_URL", nil),
@@ -57,6 +63,159 @@ func New(version string) func() *schema.Provider {
 					Sensitive:   true,
 					DefaultFunc: schema.EnvDefaultFunc("LOOKER_API_CLIENT_SECRET", nil),
 				},
+				"access_token": {
+					Description: "Existing API access token, used instead of `client_id` and `client_secret`. " +
+						"It is not renewed: the run fails once it expires.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_ACCESS_TOKEN", nil),
+				},
+				"credential_process": {
+					Description: "Command printing the credentials to use as JSON, e.g. to read them from a secrets " +
+						"manager: either `{\"client_id\": \"...\", \"client_secret\": \"...\"}`, or " +
+						"`{\"access_token\": \"...\", \"expires_in\": 3600}` where `expires_in` (seconds) or " +
+						"`expires_at` (RFC 3339) is optional. The command is run again whenever a new token is needed. " +
+						"It is not run through a shell: arguments are separated by spaces, and may be quoted.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_CREDENTIAL_PROCESS", nil),
+				},
+				"config_file": {
+					Description: "Path of a `looker.ini` file, as used by the Looker SDKs. Its `base_url`, `client_id`, " +
+						"`client_secret`, `verify_ssl` and `timeout` are used for the settings not given in the provider " +
+						"configuration or the environment. Its `base_url` may omit the /api/ path.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_CONFIG_FILE", nil),
+				},
+				"config_section": {
+					Description: "Section of `config_file` to read. Defaults to `" + lookergo.DefaultIniSection + "`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     lookergo.DefaultIniSection,
+				},
+				"ca_cert_file": {
+					Description: "PEM file of certificate authorities to trust on top of the ones of the system, " +
+						"e.g. the private CA of a self-hosted instance.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_CA_CERT_FILE", nil),
+				},
+				"client_cert_file": {
+					Description: "PEM file of the certificate presented to the instance, for mutual TLS. Requires `client_key_file`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_CLIENT_CERT_FILE", nil),
+				},
+				"client_key_file": {
+					Description: "PEM file of the key of `client_cert_file`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_CLIENT_KEY_FILE", nil),
+				},
+				"proxy_url": {
+					Description: "URL of the proxy to reach the instance through, e.g. `http://proxy.example.com:3128`. " +
+						"Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_PROXY_URL", nil),
+				},
+				"insecure_skip_verify": {
+					Description: "Accept any certificate presented by the instance. Only meant for labs.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_INSECURE_SKIP_VERIFY", false),
+				},
+				"request_timeout": {
+					Description: "Time limit in seconds of a request attempt, including the login. " +
+						"Defaults to the `timeout` of `config_file`, or no limit.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_REQUEST_TIMEOUT", nil),
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"api_version": {
+					Description: "Version of the Looker API to use, e.g. `4.0`. It must be one of the versions " +
+						"served by the instance. Defaults to `" + lookergo.DefaultAPIVersion + "`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_API_VERSION", lookergo.DefaultAPIVersion),
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+\.\d+$`), "must be of the form 4.0"),
+				},
+				"read_only": {
+					Description: "Refuse every API request that could change the instance, e.g. to run `terraform plan` " +
+						"safely with production credentials. Reads, data sources and imports keep working, changes fail.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_READ_ONLY", false),
+				},
+				"audit_log_path": {
+					Description: "File to which a JSON line is appended for every API request which may have changed the " +
+						"instance (POST, PATCH, PUT and DELETE), with its time, method, path, status, user, workspace " +
+						"and redacted body. Requests which got no response are recorded with status 0 and their error. " +
+						"The file is created if needed.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("LOOKER_AUDIT_LOG_PATH", nil),
+				},
+				"max_retries": {
+					Description: "Number of times a request is retried after a transient failure " +
+						"(dropped connection, 429, 502, 503 or 504). Only idempotent requests are retried. " +
+						"Set to 0 to disable retries.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_MAX_RETRIES", lookergo.DefaultRetryPolicy.MaxAttempts-1),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"retry_max_wait": {
+					Description: "Maximum number of seconds to wait between two attempts. " +
+						"Requests for which the API asks to wait longer (Retry-After) are not retried.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_RETRY_MAX_WAIT", int(lookergo.DefaultRetryPolicy.MaxWait/time.Second)),
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"requests_per_second": {
+					Description: "Maximum sustained number of API requests per second, also used as burst size. " +
+						"Set to 0 to disable the limit.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_REQUESTS_PER_SECOND", lookergo.DefaultRequestsPerSecond),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"max_concurrent_requests": {
+					Description:  "Maximum number of API requests in flight at the same time. Set to 0 to disable the limit.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_MAX_CONCURRENT_REQUESTS", lookergo.DefaultMaxConcurrentRequests),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"http_log_max_body_size": {
+					Description: "Maximum number of bytes of a request or response body written to the TRACE logs " +
+						"(TF_LOG=TRACE), after secrets are redacted. Longer bodies are truncated. Set to 0 to omit bodies.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_HTTP_LOG_MAX_BODY_SIZE", lookergo.DefaultLogMaxBodySize),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"tracing": {
+					Description: "Export OpenTelemetry traces of the run: a span per resource operation, with a span per " +
+						"API call (route, status, retries, rate limiter wait) and token fetch below it. Either `stdout`, " +
+						"which Terraform writes to its log, or `otlp`, to the OTLP/HTTP collector at `tracing_endpoint`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("LOOKER_TRACING", nil),
+					ValidateFunc: validation.StringInSlice([]string{tracingStdout, tracingOTLP}, false),
+				},
+				"tracing_endpoint": {
+					Description: "URL of the OTLP/HTTP collector receiving the traces, to which `/v1/traces` is added. " +
+						"Defaults to `OTEL_EXPORTER_OTLP_ENDPOINT`, or `http://localhost:4318`.",
+					Type:     schema.TypeString,
+					Optional: true,
+					DefaultFunc: schema.MultiEnvDefaultFunc(
+						[]string{"LOOKER_TRACING_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"}, "http://localhost:4318"),
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"looker_user":           dataSourceUser(),
@@ -83,6 +242,13 @@ func New(version string) func() *schema.Provider {
 			},
 		}
 
+		for name, r := range p.ResourcesMap {
+			traceResource(name, r)
+		}
+		for name, r := range p.DataSourcesMap {
+			traceResource("data."+name, r)
+		}
+
 		p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			return providerConfigure(ctx, d, p, version)
 		}
@@ -99,11 +265,22 @@ const (
 )
 
 type Config struct {
-	Api                       *lookergo.Client
-	ApiUserID                 string
-	DevClient                 *lookergo.Client
-	Workspace                 Workspace
-	RequestCompletionCallback lookergo.RequestCompletionCallback
+	Api        *lookergo.Client
+	ApiUserID  lookergo.ID
+	DevSession *lookergo.WorkspaceSession
+	Workspace  Workspace
+	// Starts the spans of the resources, nil if tracing is disabled.
+	Tracer trace.Tracer
+	// Release of the Looker instance, zero if it could not be determined.
+	LookerVersion lookergo.Version
+	// API versions served by the instance, e.g. ["3.1", "4.0"].
+	ApiVersions []string
+}
+
+// RequireVersion returns a *lookergo.VersionError if the Looker instance is older than release. feature names
+// what needs the release in the error, e.g. an attribute.
+func (c *Config) RequireVersion(release, feature string) error {
+	return lookergo.RequireVersion(c.LookerVersion, release, feature)
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Provider, version string) (interface{}, diag.Diagnostics) {
@@ -113,82 +290,258 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 	userAgent := p.UserAgent("terraform-provider-looker", version)
 	var diags diag.Diagnostics
 
-	client := lookergo.NewClient(nil)
-	devClient := lookergo.NewClient(nil)
-
-	old_url := d.Get("base_url").(string)
-	var newURL string
-	if len(old_url) > 5 {
-		switch old_url[len(old_url)-4:] {
-		case "api/":
-			newURL = old_url
-		case ".com":
-			newURL = old_url + "/api/"
-		case "com/":
-			newURL = old_url + "api/"
-		case "/api":
-			newURL = old_url + "/"
+	baseURL := d.Get("base_url").(string)
+	clientID, clientSecret := d.Get("client_id").(string), d.Get("client_secret").(string)
+	accessToken := d.Get("access_token").(string)
+	credentialProcess := d.Get("credential_process").(string)
+	insecure := d.Get("insecure_skip_verify").(bool)
+	var timeout time.Duration
+
+	// The settings missing from the configuration and the environment come from the looker.ini file of the SDKs.
+	if path := d.Get("config_file").(string); path != "" {
+		ini, err := lookergo.LoadIniConfig(path, d.Get("config_section").(string))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read the Looker configuration file",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("config_file"),
+			})
+			return nil, diags
+		}
+		if baseURL == "" {
+			baseURL = ini.BaseURL
+		}
+		// Credentials are taken as a whole, and an access token or a credential process replaces them.
+		if accessToken == "" && credentialProcess == "" && clientID == "" && clientSecret == "" {
+			clientID, clientSecret = ini.ClientID, ini.ClientSecret
+		}
+		timeout = ini.Timeout
+		if !ini.VerifySSL {
+			insecure = true
 		}
-	} else {
-		newURL = old_url
 	}
-
-	if err := client.SetBaseURL(newURL); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set looker API endpoint",
-			Detail:   "Err: " + err.Error(),
-		})
-		return nil, diags
+	if v, ok := d.GetOk("request_timeout"); ok {
+		timeout = time.Duration(v.(int)) * time.Second
 	}
-	devClient.SetBaseURL(newURL)
 
-	clientId := d.Get("client_id").(string)
-	clientSecret := d.Get("client_secret").(string)
-	if err := client.SetOauthCredentials(ctx, clientId, clientSecret); err != nil {
+	// A single transport for the clients and their token fetches, so that they all reach the instance the same way.
+	transport, err := lookergo.NewTransport(lookergo.TransportOptions{
+		CACertFile:         d.Get("ca_cert_file").(string),
+		ClientCertFile:     d.Get("client_cert_file").(string),
+		ClientKeyFile:      d.Get("client_key_file").(string),
+		ProxyURL:           d.Get("proxy_url").(string),
+		InsecureSkipVerify: insecure,
+	})
+	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to set looker API client_id/client_secret",
-			Detail:   "Err: " + err.Error(),
+			Summary:  "Invalid connection settings",
+			Detail:   err.Error(),
 		})
 		return nil, diags
 	}
-	if err := client.SetUserAgent(userAgent); err != nil {
+	if insecure {
 		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set looker API user-agent",
-			Detail:   "Err: " + err.(*lookergo.ErrorResponse).Message,
+			Severity: diag.Warning,
+			Summary:  "The certificate of the Looker instance is not verified",
+			Detail:   "insecure_skip_verify, or verify_ssl in config_file, turns off the verification. Only use it in labs.",
 		})
-		return nil, diags
 	}
-	devClient.SetUserAgent(userAgent)
+
+	var conflict diag.Diagnostic
+	switch {
+	case credentialProcess != "" && (accessToken != "" || clientID != "" || clientSecret != ""):
+		conflict.Detail = "credential_process cannot be combined with access_token, client_id and client_secret."
+		conflict.AttributePath = cty.GetAttrPath("credential_process")
+	case accessToken != "" && (clientID != "" || clientSecret != ""):
+		conflict.Detail = "access_token cannot be combined with client_id and client_secret."
+		conflict.AttributePath = cty.GetAttrPath("access_token")
+	}
+	if conflict.Detail != "" {
+		conflict.Severity, conflict.Summary = diag.Error, "Conflicting Looker credentials"
+		return nil, append(diags, conflict)
+	}
+
+	credentials := lookergo.WithOAuthCredentials(clientID, clientSecret)
+	switch {
+	case accessToken != "":
+		credentials = lookergo.WithStaticToken(accessToken)
+	case credentialProcess != "":
+		command, err := splitCommand(credentialProcess)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid credential_process",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("credential_process"),
+			})
+			return nil, diags
+		}
+		credentials = lookergo.WithCredentialProcess(command...)
+	}
+
+	retryPolicy := lookergo.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = d.Get("max_retries").(int) + 1
+	retryPolicy.MaxWait = time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+	if retryPolicy.MinWait > retryPolicy.MaxWait {
+		retryPolicy.MinWait = retryPolicy.MaxWait
+	}
+
+	// Both clients talk to the same instance, so they share their budget.
+	rps := d.Get("requests_per_second").(int)
+	limiter := lookergo.NewLimiter(float64(rps), rps, d.Get("max_concurrent_requests").(int))
+
+	var audit *lookergo.AuditLog
+	if path := d.Get("audit_log_path").(string); path != "" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to open the audit log",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("audit_log_path"),
+			})
+			return nil, diags
+		}
+		openSessions.Lock()
+		openSessions.auditLogs = append(openSessions.auditLogs, f)
+		openSessions.Unlock()
+		audit = lookergo.NewAuditLog(f)
+	}
+
+	var tp *sdktrace.TracerProvider
+	if exporter := d.Get("tracing").(string); exporter != "" {
+		var err error
+		tp, err = newTracerProvider(ctx, exporter, d.Get("tracing_endpoint").(string), version)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid tracing settings",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("tracing_endpoint"),
+			})
+			return nil, diags
+		}
+		openSessions.Lock()
+		openSessions.tracerProviders = append(openSessions.tracerProviders, tp)
+		openSessions.Unlock()
+
+		// The login and the requests below are traced under the configuration.
+		var span trace.Span
+		ctx, span = tp.Tracer(tracerName).Start(ctx, "configure")
+		defer span.End()
+	}
 
 	var config Config
 
-	config.RequestCompletionCallback = func(req *http.Request, resp *http.Response) {
+	// The requests are logged with their own context, as most are made by the resources after the configuration.
+	requestCompleted := func(req *http.Request, resp *http.Response) {
+		ctx := req.Context()
 		if code := resp.StatusCode; code >= 200 && code <= 299 {
 			tflog.Debug(ctx, "HTTP Request", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "resp_status": resp.Status})
 		} else {
-			tflog.Debug(ctx, "HTTP Error", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "resp_status": resp.Status, "resp_length": resp.ContentLength, "resp_headers": resp.Header})
+			tflog.Debug(ctx, "HTTP Error", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "resp_status": resp.Status, "resp_length": resp.ContentLength})
+		}
+		if audit != nil {
+			if err := audit.Record(req, resp); err != nil {
+				tflog.Error(ctx, "Unable to write the audit log", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "error": err.Error()})
+			}
 		}
 	}
 
-	client.OnRequestCompleted(config.RequestCompletionCallback)
-	devClient.OnRequestCompleted(config.RequestCompletionCallback)
+	apiVersion := d.Get("api_version").(string)
+	traceLogging := traceLoggingEnabled()
+	opts := []lookergo.ClientOpt{
+		lookergo.WithBaseURL(apiBaseURL(baseURL)),
+		lookergo.WithAPIVersion(apiVersion),
+		lookergo.WithTransport(transport),
+		lookergo.WithTimeout(timeout),
+		lookergo.WithReadOnly(d.Get("read_only").(bool)),
+		lookergo.WithUserAgent(userAgent),
+		lookergo.WithRetryPolicy(retryPolicy),
+		lookergo.WithLimiter(limiter),
+		lookergo.WithLogger(lookergo.LoggerFunc(func(ctx context.Context, msg string, fields map[string]interface{}) {
+			tflog.Debug(ctx, msg, fields)
+		})),
+		lookergo.WithRequestCompletionCallback(requestCompleted),
+		lookergo.WithRequestErrorCallback(func(req *http.Request, err error) {
+			ctx := req.Context()
+			tflog.Debug(ctx, "HTTP Error", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "error": err.Error()})
+			// The request may have reached the instance, and changed it, before failing.
+			if audit != nil {
+				if err := audit.RecordError(req, err); err != nil {
+					tflog.Error(ctx, "Unable to write the audit log", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "error": err.Error()})
+				}
+			}
+		}),
+		// Full exchanges, secrets redacted, for TF_LOG=TRACE.
+		lookergo.WithHTTPLogging(lookergo.HTTPLogOptions{
+			Logger: lookergo.LoggerFunc(func(ctx context.Context, msg string, fields map[string]interface{}) {
+				tflog.Trace(ctx, msg, fields)
+			}),
+			MaxBodySize: d.Get("http_log_max_body_size").(int),
+			Enabled:     func(context.Context) bool { return traceLogging },
+		}),
+	}
+
+	if tp != nil {
+		opts = append(opts, lookergo.WithTracerProvider(tp))
+	}
+
+	client, err := lookergo.New(append(opts, credentials)...)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to configure the looker API client",
+			Detail:   "Err: " + err.Error(),
+		})
+		return nil, diags
+	}
+	// Not authenticated.
+	anonymous, err := lookergo.New(opts...)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// Used to reject the attributes the instance is too old for at plan time, instead of a 422 at apply. Asked
+	// without credentials, as the login itself is below the API version.
+	var lookerVersion lookergo.Version
+	var apiVersions []string
+	versions, _, err := anonymous.Versions.Get(ctx)
+	if err == nil {
+		apiVersions = versions.SupportedVersionNames()
+		if !versions.Supports(apiVersion) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Looker API version %s is not supported by the instance", apiVersion),
+				Detail:        fmt.Sprintf("The instance serves the API versions %s.", strings.Join(apiVersions, ", ")),
+				AttributePath: cty.GetAttrPath("api_version"),
+			})
+			return nil, diags
+		}
+		lookerVersion, err = versions.Release()
+	}
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to determine the Looker version",
+			Detail:   "Attributes needing a recent Looker release are not checked at plan time: " + err.Error(),
+		})
+	}
+	tflog.Debug(ctx, "Looker version", map[string]interface{}{"release": lookerVersion.String(), "api_versions": apiVersions})
 
 	session, _, err := client.Sessions.Get(ctx)
 	if err != nil {
 		errMsg := err.Error()
 		var errBodyMd string
 		// Otherwise the error is the full html doc. :O
-		if reflect.TypeOf(err).Elem() == reflect.TypeOf((*url.Error)(nil)).Elem() {
-			if reflect.TypeOf(err.(*url.Error).Err).Elem() == reflect.TypeOf((*oauth2.RetrieveError)(nil)).Elem() {
-				errMsg = fmt.Sprintf("oauth2: cannot fetch token: %v", err.(*url.Error).Err.(*oauth2.RetrieveError).Response.Status)
-				converter := md.NewConverter("", true, nil)
-				mdBytes, _ := converter.ConvertBytes(err.(*url.Error).Err.(*oauth2.RetrieveError).Body)
-				errBodyMd = string(mdBytes)
-				// errBodyMd = string(err.(*url.Error).Err.(*oauth2.RetrieveError).Body)
-			}
+		var tokenErr *oauth2.RetrieveError
+		if errors.As(err, &tokenErr) {
+			errMsg = fmt.Sprintf("oauth2: cannot fetch token: %v", tokenErr.Response.Status)
+			converter := md.NewConverter("", true, nil)
+			mdBytes, _ := converter.ConvertBytes(tokenErr.Body)
+			errBodyMd = string(mdBytes)
 		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -203,12 +556,18 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 	if err != nil {
 		return nil, diagErrAppend(diags, err)
 	}
+	if audit != nil {
+		audit.SetUserID(user.Id)
+	}
+
+	// Projects are changed in the dev workspace, through a session of its own.
+	devSession := lookergo.NewWorkspaceSession(client, "dev")
 
 	switch session.WorkspaceId {
 	case "production":
-		config = Config{Api: client, ApiUserID: user.Id, DevClient: devClient, Workspace: WorkspaceProduction}
+		config = Config{Api: client, ApiUserID: user.Id, DevSession: devSession, Workspace: WorkspaceProduction}
 	case "dev":
-		config = Config{Api: client, ApiUserID: user.Id, DevClient: devClient, Workspace: WorkspaceDev}
+		config = Config{Api: client, ApiUserID: user.Id, DevSession: devSession, Workspace: WorkspaceDev}
 	default:
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -217,13 +576,23 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 		})
 		return nil, diags
 	}
+	config.LookerVersion = lookerVersion
+	if tp != nil {
+		config.Tracer = tp.Tracer(tracerName)
+	}
+	config.ApiVersions = apiVersions
+
+	openSessions.Lock()
+	openSessions.sessions = append(openSessions.sessions, devSession)
+	openSessions.Unlock()
 
-	return &config, nil
+	return &config, diags
 }
 
 func diagErrAppend(diags diag.Diagnostics, err error) diag.Diagnostics {
-	switch e := err.(type) {
-	case *lookergo.ErrorResponse:
+	var e *lookergo.ErrorResponse
+	switch {
+	case errors.As(err, &e):
 		if len(e.Errors) >= 1 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -251,21 +620,100 @@ func diagErrAppend(diags diag.Diagnostics, err error) diag.Diagnostics {
 	return diags
 }
 
-func ensureDevClient(ctx context.Context, m interface{}) error {
-	if m.(*Config).DevClient == nil {
-		tflog.Debug(ctx, fmt.Sprintf("Fn: %v, Action: create dev client connection", currFuncName()))
-		devClient, _, err := m.(*Config).Api.CreateDevConnection(ctx, func(req *http.Request, resp *http.Response) {
-			if code := resp.StatusCode; code >= 200 && code <= 299 {
-				tflog.Debug(ctx, "DevClient: HTTP Request", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "resp_status": resp.Status})
+// apiBaseURL returns the URL of the API for the URL of an instance, which may or may not include the /api/ path:
+// the SDKs expect it without, the provider used to require it.
+func apiBaseURL(u string) string {
+	if u == "" {
+		return u
+	}
+	u = strings.TrimSuffix(u, "/")
+	if !strings.HasSuffix(u, "/api") {
+		u += "/api"
+	}
+	return u + "/"
+}
+
+// splitCommand splits a command line into the program and its arguments, at spaces outside of single or double
+// quotes. A backslash escapes the next character, except within single quotes.
+func splitCommand(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
 			} else {
-				tflog.Debug(ctx, "DevClient: HTTP Error", map[string]interface{}{"req_url": req.URL.String(), "req_method": req.Method, "resp_status": resp.Status, "resp_length": resp.ContentLength, "resp_headers": resp.Header})
+				arg.WriteRune(r)
 			}
-		})
-		if err != nil {
-			return err
-		} else {
-			m.(*Config).DevClient = devClient
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", line)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	if len(args) == 0 {
+		return nil, errors.New("the command is empty")
+	}
+	return args, nil
+}
+
+// devClient returns the client of the dev workspace, in which projects are changed. The session is created on
+// first use, and logged out by Shutdown.
+func devClient(ctx context.Context, m interface{}) (*lookergo.Client, error) {
+	return m.(*Config).DevSession.Client(ctx)
+}
+
+// openSessions are the dev workspace sessions, the audit logs and the tracer providers of the providers configured in
+// this process.
+var openSessions struct {
+	sync.Mutex
+	sessions        []*lookergo.WorkspaceSession
+	auditLogs       []*os.File
+	tracerProviders []*sdktrace.TracerProvider
+}
+
+// Shutdown logs out the dev workspace sessions, closes the audit logs and exports the pending spans. It is called when
+// the plugin stops serving Terraform.
+func Shutdown(ctx context.Context) {
+	openSessions.Lock()
+	sessions, auditLogs, tracerProviders := openSessions.sessions, openSessions.auditLogs, openSessions.tracerProviders
+	openSessions.sessions, openSessions.auditLogs, openSessions.tracerProviders = nil, nil, nil
+	openSessions.Unlock()
+
+	for _, session := range sessions {
+		if err := session.Close(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to log out the dev workspace session", map[string]interface{}{"error": err.Error()})
+		}
+	}
+	for _, f := range auditLogs {
+		if err := f.Close(); err != nil {
+			tflog.Warn(ctx, "Unable to close the audit log", map[string]interface{}{"path": f.Name(), "error": err.Error()})
+		}
+	}
+	for _, tp := range tracerProviders {
+		if err := tp.Shutdown(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to export the spans", map[string]interface{}{"error": err.Error()})
 		}
 	}
-	return nil
 }
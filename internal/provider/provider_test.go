@@ -0,0 +1,694 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// providerFactories are used to instantiate the provider during unit testing.
+var providerFactories = map[string]func() (*schema.Provider, error){
+	"looker": func() (*schema.Provider, error) {
+		return New("test")(), nil
+	},
+}
+
+func init() {
+	// The fake server settles instantly.
+	projectRenameWait = 0
+}
+
+func TestProvider(t *testing.T) {
+	if err := New("test")().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestAccProvider_apiVersion(t *testing.T) {
+	srv := newTestServer(t)
+
+	config := func(apiVersion string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  client_id           = %q
+  client_secret       = %q
+  api_version         = %q
+  requests_per_second = 0
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, srv.BaseURL(), srv.ClientID, srv.ClientSecret, apiVersion)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("5.0"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Looker API version 5.0 is not supported by the instance`),
+			},
+			{
+				Config:      config("latest"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be of the form 4.0`),
+			},
+			{
+				Config:             config("4.0"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccProvider_accessToken(t *testing.T) {
+	srv := newTestServer(t)
+
+	config := func(accessToken string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  access_token        = %q
+  requests_per_second = 0
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, srv.BaseURL(), accessToken)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("unknown"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unable to create Looker client`),
+			},
+			{
+				Config: config(srv.NewToken()),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+		},
+	})
+}
+
+func TestAccProvider_configFile(t *testing.T) {
+	srv := newTestServer(t)
+
+	// As written for the Python SDK: no /api/ path.
+	path := filepath.Join(t.TempDir(), "looker.ini")
+	ini := fmt.Sprintf(`
+[Looker]
+base_url=%s
+client_id=wrong
+client_secret=wrong
+
+[Terraform]
+base_url=%s
+client_id=%s
+client_secret=%s
+verify_ssl=True
+timeout=30
+`, srv.URL, srv.URL, srv.ClientID, srv.ClientSecret)
+	if err := os.WriteFile(path, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  config_file         = %q
+  requests_per_second = 0
+  %s
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, path, settings)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unable to create Looker client`),
+			},
+			{
+				Config:      config(`config_section = "Production"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`section\s+\[Production\]\s+not found`),
+			},
+			{
+				// The provider settings win over the file.
+				Config: config(fmt.Sprintf("client_id = %q\nclient_secret = %q", srv.ClientID, srv.ClientSecret)),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+			{
+				Config: config(`config_section = "Terraform"`),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+		},
+	})
+}
+
+func TestAccProvider_tls(t *testing.T) {
+	srv := newTLSTestServer(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, srv.CertificatePEM(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  client_id           = %q
+  client_secret       = %q
+  requests_per_second = 0
+  %s
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, srv.BaseURL(), srv.ClientID, srv.ClientSecret, settings)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`certificate`),
+			},
+			{
+				Config:      config(fmt.Sprintf("client_cert_file = %q", caFile)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid connection settings`),
+			},
+			{
+				Config:      config(`proxy_url = "ftp://proxy.example.com"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid connection settings`),
+			},
+			{
+				Config: config(fmt.Sprintf("ca_cert_file = %q\nrequest_timeout = 30", caFile)),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+			{
+				Config: config("insecure_skip_verify = true"),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+		},
+	})
+}
+
+func TestAccProvider_readOnly(t *testing.T) {
+	srv := newTestServer(t)
+	groupID := srv.Put(lookertest.Groups, map[string]interface{}{"name": "Existing"})
+	srv.Put(lookertest.Projects, map[string]interface{}{"id": "marketing", "name": "marketing"})
+
+	config := func(group string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  client_id           = %q
+  client_secret       = %q
+  read_only           = true
+  requests_per_second = 0
+}
+
+data "looker_project" "marketing" {
+  name = "marketing"
+}
+
+resource "looker_group" "test" {
+  name = %q
+}
+`, srv.BaseURL(), srv.ClientID, srv.ClientSecret, group)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				// Plans read through the dev workspace session.
+				Config:             config("Analysts"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      config("Analysts"),
+				ExpectError: regexp.MustCompile(`client is read-only, refusing POST /api/4.0/groups`),
+			},
+			{
+				Config:        config("Existing"),
+				ResourceName:  "looker_group.test",
+				ImportState:   true,
+				ImportStateId: groupID,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					for _, state := range states {
+						if state.ID == groupID && state.Attributes["name"] == "Existing" {
+							return nil
+						}
+					}
+					return fmt.Errorf("imported %v, expected the Existing group", states)
+				},
+			},
+		},
+	})
+	groups, _, err := testClient(t, srv).Groups.List(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// All Users and Existing.
+	if len(groups) != 2 {
+		t.Errorf("%d groups, expected the 2 existing ones", len(groups))
+	}
+}
+
+func TestAccProvider_auditLog(t *testing.T) {
+	srv := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  client_id           = %q
+  client_secret       = %q
+  audit_log_path      = %q
+  requests_per_second = 0
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, srv.BaseURL(), srv.ClientID, srv.ClientSecret, path),
+			},
+		},
+	})
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requests []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var r lookergo.AuditRecord
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("line %q is not a record: %v", line, err)
+		}
+		if r.UserID != lookertest.AdminUserID || r.Workspace != "production" || r.Status/100 != 2 {
+			t.Errorf("record %q is not of a successful request of the admin in production", line)
+		}
+		requests = append(requests, r.Method+" "+regexp.MustCompile(`\d+$`).ReplaceAllString(r.Path, "{id}"))
+	}
+	// Only the creation and the deletion changed the instance.
+	if expected := []string{"POST /api/4.0/groups", "DELETE /api/4.0/groups/{id}"}; !reflect.DeepEqual(requests, expected) {
+		t.Errorf("audit log records %q, expected %q", requests, expected)
+	}
+}
+
+func TestAccProvider_tracing(t *testing.T) {
+	srv := newTestServer(t)
+	// Exports the spans as they end.
+	t.Setenv("OTEL_BSP_SCHEDULE_DELAY", "10")
+
+	var mu sync.Mutex
+	spans := make(map[string]*tracepb.Span)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var traces coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &traces); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rs := range traces.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}))
+	defer collector.Close()
+
+	config := func(endpoint string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  client_id           = %q
+  client_secret       = %q
+  tracing             = "otlp"
+  tracing_endpoint    = %q
+  requests_per_second = 0
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, srv.BaseURL(), srv.ClientID, srv.ClientSecret, endpoint)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("localhost:4318"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid tracing settings`),
+			},
+			{
+				Config: config(collector.URL),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+		},
+	})
+
+	expected := []string{"configure", "looker token", "looker_group create", "POST groups", "DELETE groups/{group_id}"}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		mu.Lock()
+		missing := 0
+		for _, name := range expected {
+			if spans[name] == nil {
+				missing++
+			}
+		}
+		mu.Unlock()
+		if missing == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the collector did not receive the spans %q", expected)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	create, post := spans["looker_group create"], spans["POST groups"]
+	if !bytes.Equal(post.ParentSpanId, create.SpanId) || !bytes.Equal(post.TraceId, create.TraceId) {
+		t.Errorf("the span of the API call %v is not a child of the span of the resource %v", post, create)
+	}
+	if len(spans["looker token"].ParentSpanId) == 0 {
+		t.Error("the token fetch is not traced within a request")
+	}
+}
+
+// TestCredentialProcessHelper is the credential process run by TestAccProvider_credentialProcess: it prints
+// LOOKER_TEST_CREDENTIALS, or fails with the message LOOKER_TEST_CREDENTIALS_ERROR. It does nothing when run as a test.
+func TestCredentialProcessHelper(t *testing.T) {
+	out, ok := os.LookupEnv("LOOKER_TEST_CREDENTIALS")
+	if !ok {
+		return
+	}
+	if msg := os.Getenv("LOOKER_TEST_CREDENTIALS_ERROR"); msg != "" {
+		fmt.Fprintln(os.Stderr, msg)
+		os.Exit(3)
+	}
+	fmt.Print(out)
+	os.Exit(0)
+}
+
+func TestAccProvider_credentialProcess(t *testing.T) {
+	srv := newTestServer(t)
+	t.Setenv("LOOKER_TEST_CREDENTIALS", fmt.Sprintf(`{"client_id":%q,"client_secret":%q}`, srv.ClientID, srv.ClientSecret))
+
+	config := func(settings string) string {
+		return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  credential_process  = %q
+  requests_per_second = 0
+  %s
+}
+
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+`, srv.BaseURL(), fmt.Sprintf("'%s' -test.run=^TestCredentialProcessHelper$", os.Args[0]), settings)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { t.Setenv("LOOKER_TEST_CREDENTIALS_ERROR", "vault is sealed") },
+				Config:      config(""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`vault is sealed`),
+			},
+			{
+				PreConfig:   func() { t.Setenv("LOOKER_TEST_CREDENTIALS_ERROR", "") },
+				Config:      config(`client_id = "id"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting Looker credentials`),
+			},
+			{
+				Config: config(""),
+				Check:  testCheckRemote(srv, "groups", "looker_group.test", nil),
+			},
+		},
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := map[string][]string{
+		"vault-creds looker":                    {"vault-creds", "looker"},
+		"  get-creds   --profile  prod ":        {"get-creds", "--profile", "prod"},
+		`"/opt/my tools/creds" --name 'a b' ""`: {"/opt/my tools/creds", "--name", "a b", ""},
+		`creds a\ b 'c\d'`:                      {"creds", "a b", `c\d`},
+	}
+	for in, expected := range tests {
+		got, err := splitCommand(in)
+		if err != nil {
+			t.Errorf("splitCommand(%q) returned error: %v", in, err)
+		} else if !reflect.DeepEqual(got, expected) {
+			t.Errorf("splitCommand(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+	for _, in := range []string{"", "  ", `creds "unterminated`, `creds \`} {
+		if got, err := splitCommand(in); err == nil {
+			t.Errorf("splitCommand(%q) = %q, expected an error", in, got)
+		}
+	}
+}
+
+func TestApiBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"https://org.cloud.looker.com":          "https://org.cloud.looker.com/api/",
+		"https://org.cloud.looker.com/":         "https://org.cloud.looker.com/api/",
+		"https://org.cloud.looker.com/api":      "https://org.cloud.looker.com/api/",
+		"https://org.cloud.looker.com/api/":     "https://org.cloud.looker.com/api/",
+		"https://looker.example.org:19999":      "https://looker.example.org:19999/api/",
+		"https://looker.example.org:19999/api/": "https://looker.example.org:19999/api/",
+		"":                                      "",
+	}
+	for in, expected := range tests {
+		if got := apiBaseURL(in); got != expected {
+			t.Errorf("apiBaseURL(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+// newTestServer starts a fake Looker instance for the test. The lifecycle tests drive the Terraform CLI,
+// so they fail when it is not installed and neither TF_ACC_TERRAFORM_PATH nor TF_ACC_TERRAFORM_VERSION
+// tells where to find it. make test downloads it when needed.
+func newTestServer(t *testing.T) *lookertest.Server {
+	t.Helper()
+	return startTestServer(t, lookertest.NewServer)
+}
+
+// newTLSTestServer is like newTestServer, but the instance serves HTTPS with a self-signed certificate.
+func newTLSTestServer(t *testing.T) *lookertest.Server {
+	t.Helper()
+	return startTestServer(t, lookertest.NewTLSServer)
+}
+
+func startTestServer(t *testing.T, start func() *lookertest.Server) *lookertest.Server {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" && os.Getenv("TF_ACC_TERRAFORM_VERSION") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Fatal("terraform CLI not found: install it, set TF_ACC_TERRAFORM_PATH to its binary, " +
+				"or set TF_ACC_TERRAFORM_VERSION to the version to download, as make test does")
+		}
+	}
+
+	srv := start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testConfig returns a configuration of the provider against srv, followed by resources.
+func testConfig(srv *lookertest.Server, resources string) string {
+	return fmt.Sprintf(`
+provider "looker" {
+  base_url            = %q
+  client_id           = %q
+  client_secret       = %q
+  requests_per_second = 0
+}
+%s`, srv.BaseURL(), srv.ClientID, srv.ClientSecret, resources)
+}
+
+// testClient returns a client of srv, to change objects behind the back of Terraform.
+func testClient(t *testing.T, srv *lookertest.Server) *lookergo.Client {
+	t.Helper()
+
+	c, err := lookergo.New(
+		lookergo.WithBaseURL(srv.BaseURL()),
+		lookergo.WithOAuthCredentials(srv.ClientID, srv.ClientSecret),
+		lookergo.WithLimiter(nil),
+	)
+	if err != nil {
+		t.Fatalf("lookergo.New: %v", err)
+	}
+	return c
+}
+
+// newUnpagedClient returns a client of a stub instance serving the body of each API path, with no pagination
+// headers and whatever the limit and offset, as Looker serves the lists it does not page. The second result counts
+// the requests served.
+func newUnpagedClient(t *testing.T, bodies map[string]string) (*lookergo.Client, *int32) {
+	t.Helper()
+
+	var requests int32
+	mux := http.NewServeMux()
+	for path, body := range bodies {
+		body := body
+		mux.HandleFunc("/api/4.0/"+path, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			fmt.Fprint(w, body)
+		})
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, err := lookergo.New(lookergo.WithBaseURL(srv.URL+"/api/"), lookergo.WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("lookergo.New: %v", err)
+	}
+	return c, &requests
+}
+
+// testRows returns a JSON array of n objects with the ids 1 to n.
+func testRows(n int) string {
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = fmt.Sprintf(`{"id":"%d","name":"Group %d"}`, i+1, i+1)
+	}
+	return "[" + strings.Join(rows, ",") + "]"
+}
+
+// testCheckRemote checks that the object the resource name points to exists in srv, and stores its id in id
+// when not nil, e.g. to change it behind the back of Terraform in a later step.
+func testCheckRemote(srv *lookertest.Server, kind, name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+		if _, ok := srv.Get(kind, rs.Primary.ID); !ok {
+			return fmt.Errorf("%s %s not found in Looker", kind, rs.Primary.ID)
+		}
+		if id != nil {
+			*id = rs.Primary.ID
+		}
+		return nil
+	}
+}
+
+// testCheckDestroyed checks that no resource of type resourceType is left in srv.
+func testCheckDestroyed(srv *lookertest.Server, kind, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if _, ok := srv.Get(kind, rs.Primary.ID); ok {
+				return fmt.Errorf("%s %s still exists in Looker", kind, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testChangeRemote returns a PreConfig function changing fields of the object with the given id, as someone
+// editing it in the Looker UI would.
+func testChangeRemote(t *testing.T, srv *lookertest.Server, kind string, id *string, fields map[string]interface{}) func() {
+	return func() {
+		obj, ok := srv.Get(kind, *id)
+		if !ok {
+			t.Fatalf("%s %s not found in Looker", kind, *id)
+		}
+		for k, v := range fields {
+			obj[k] = v
+		}
+		srv.Put(kind, obj)
+	}
+}
+
+// testRemoveRemote returns a PreConfig function deleting the object with the given id behind the back of
+// Terraform.
+func testRemoveRemote(t *testing.T, srv *lookertest.Server, kind string, id *string) func() {
+	return func() {
+		if !srv.Remove(kind, *id) {
+			t.Fatalf("%s %s not found in Looker", kind, *id)
+		}
+	}
+}
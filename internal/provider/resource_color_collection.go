@@ -177,7 +177,7 @@ func resourceColorCollection() *schema.Resource {
 
 func cocoSchemaToStruct(ctx context.Context, d *schema.ResourceData, coco *lookergo.WriteColorCollection) {
 	if cocoLabel, ok := d.GetOk("label"); ok {
-		coco.Label = castToPtr(cocoLabel.(string))
+		coco.Label = lookergo.Value(cocoLabel.(string))
 	}
 
 	if categoricalpalettesSet, ok := d.GetOk("categoricalpalettes"); ok {
@@ -190,7 +190,7 @@ func cocoSchemaToStruct(ctx context.Context, d *schema.ResourceData, coco *looke
 			pal.Type = castToPtr(obj["type"].(string))
 			catPal = append(catPal, pal)
 		}
-		coco.CategoricalPalettes = &catPal
+		coco.CategoricalPalettes = lookergo.Value(catPal)
 	}
 
 	if sequentialpalettesSet, ok := d.GetOk("sequentialpalettes"); ok {
@@ -211,7 +211,7 @@ func cocoSchemaToStruct(ctx context.Context, d *schema.ResourceData, coco *looke
 			pal.Stops = &stopsList
 			seqPal = append(seqPal, pal)
 		}
-		coco.SequentialPalettes = &seqPal
+		coco.SequentialPalettes = lookergo.Value(seqPal)
 	}
 
 	if divergingpalettesSet, ok := d.GetOk("divergingpalettes"); ok {
@@ -232,7 +232,7 @@ func cocoSchemaToStruct(ctx context.Context, d *schema.ResourceData, coco *looke
 			pal.Stops = &stopsList
 			divPal = append(divPal, pal)
 		}
-		coco.DivergingPalettes = &divPal
+		coco.DivergingPalettes = lookergo.Value(divPal)
 	}
 }
 
@@ -253,15 +253,18 @@ func resourceColorCollectionCreate(ctx context.Context, d *schema.ResourceData,
 		fmt.Println(newCoCo)
 		return diag.FromErr(err)
 	}
-	d.SetId(*newCoCo.Id)
+	d.SetId(newCoCo.Id.String())
 	return resourceColorCollectionRead(ctx, d, m)
 }
 
 func resourceColorCollectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	cocoID := d.Id()
+	cocoID := lookergo.ID(d.Id())
 
-	coco, _, err := c.ColorCollection.Get(ctx, cocoID)
+	coco, _, err := c.ColorCollection.Get(ctx, cocoID, nil)
+	if goneFromRemote(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -325,24 +328,34 @@ func resourceColorCollectionRead(ctx context.Context, d *schema.ResourceData, m
 
 func resourceColorCollectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	cocoID := d.Id()
+	cocoID := lookergo.ID(d.Id())
 
-	if d.HasChanges("label", "catogoricalpalletes", "sequentialpalettes", "divergingpalettes") {
+	if d.HasChanges("label", "categoricalpalettes", "sequentialpalettes", "divergingpalettes") {
 		var coco lookergo.WriteColorCollection
 		cocoSchemaToStruct(ctx, d, &coco)
+		// Palettes removed from the configuration are cleared.
+		if d.HasChange("categoricalpalettes") && coco.CategoricalPalettes == nil {
+			coco.CategoricalPalettes = lookergo.Value([]lookergo.DiscretePalette{})
+		}
+		if d.HasChange("sequentialpalettes") && coco.SequentialPalettes == nil {
+			coco.SequentialPalettes = lookergo.Value([]lookergo.ContinuousPalette{})
+		}
+		if d.HasChange("divergingpalettes") && coco.DivergingPalettes == nil {
+			coco.DivergingPalettes = lookergo.Value([]lookergo.ContinuousPalette{})
+		}
 		newCoco, _, err := c.ColorCollection.Update(ctx, cocoID, &coco)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		d.Set("id", *newCoco.Id)
-		d.SetId(*newCoco.Id)
+		d.SetId(newCoco.Id.String())
 	}
 	return resourceColorCollectionRead(ctx, d, m)
 }
 
 func resourceColorCollectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	CoCoId := d.Id()
+	CoCoId := lookergo.ID(d.Id())
 
 	if _, err := c.ColorCollection.Delete(ctx, CoCoId); err != nil {
 		return diag.FromErr(err)
@@ -1,17 +1,91 @@
 package provider
 
-/*
 import (
 	"testing"
 
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
-func TestColorCollection(t *testing.T) {
-	resourceName := "lookerg_color_collection.coco"
+func TestAccColorCollection(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
 
-	resource.Test(t, resource.TestCase{
-		PreCheck: func() {},
+	config := func(label, color string) string {
+		return testConfig(srv, `
+resource "looker_color_collection" "test" {
+  label = "`+label+`"
+
+  categoricalpalettes {
+    label  = "Brand"
+    colors = ["#1A73E8", "`+color+`"]
+  }
+
+  sequentialpalettes {
+    label = "Heat"
+    stops {
+      color  = "#FFFFFF"
+      offset = 0
+    }
+    stops {
+      color  = "#EA4335"
+      offset = 100
+    }
+  }
+
+  divergingpalettes {
+    label = "Balance"
+    stops {
+      color  = "#4285F4"
+      offset = 0
+    }
+    stops {
+      color  = "#EA4335"
+      offset = 100
+    }
+  }
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.ColorCollections, "looker_color_collection"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Corporate", "#34A853"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.ColorCollections, "looker_color_collection.test", &id),
+					resource.TestCheckResourceAttr("looker_color_collection.test", "label", "Corporate"),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_color_collection.test", "categoricalpalettes.*", map[string]string{
+						"label": "Brand",
+						"type":  "Categorical",
+					}),
+					resource.TestCheckTypeSetElemAttr("looker_color_collection.test", "categoricalpalettes.*.colors.*", "#34A853"),
+				),
+			},
+			{
+				Config: config("Corporate 2023", "#FBBC04"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_color_collection.test", "label", "Corporate 2023"),
+					resource.TestCheckTypeSetElemAttr("looker_color_collection.test", "categoricalpalettes.*.colors.*", "#FBBC04"),
+				),
+			},
+			{
+				ResourceName:      "looker_color_collection.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.ColorCollections, &id, map[string]interface{}{"label": "Changed"}),
+				Config:    config("Corporate 2023", "#FBBC04"),
+				Check:     resource.TestCheckResourceAttr("looker_color_collection.test", "label", "Corporate 2023"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.ColorCollections, &id),
+				Config:    config("Corporate 2023", "#FBBC04"),
+				Check:     testCheckRemote(srv, lookertest.ColorCollections, "looker_color_collection.test", nil),
+			},
+		},
 	})
 }
-*/
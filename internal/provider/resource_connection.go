@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
-	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -157,10 +156,81 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
-	connectionName := d.Get("name").(string)
+	nc := connectionFromResourceData(d)
 
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: test conf", currFuncName()))
+	validateConfig, _, err := c.Connections.ValidateConfig(ctx, nc)
+	if err != nil {
+		return diagErrAppend(diags, err)
+	}
+
+	for i, dbcv := range validateConfig {
+		tflog.Debug(ctx, fmt.Sprintf("Config [%v] validation message: %v", i, dbcv.Message))
+		tflog.Debug(ctx, fmt.Sprintf("Config [%v] validation status: %v", i, dbcv.Status))
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: create", currFuncName()))
+	connection, _, err := c.Connections.Create(ctx, nc)
+	if err != nil {
+		return diagErrAppend(diags, err)
+	}
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: created, Name: %v", currFuncName(), connection.Name))
+
+	d.SetId(connection.Name)
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
+	return diags
+}
+
+func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	c := m.(*Config).Api // .(*lookergo.Client)
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
+
+	connection, _, err := c.Connections.Get(ctx, d.Id(), nil)
+	if goneFromRemote(ctx, d, err) {
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Write-only attributes such as password and certificate are never returned, so they keep their configured value.
+	for k, v := range map[string]interface{}{
+		"name":         connection.Name,
+		"host":         connection.Host,
+		"port":         connection.Port,
+		"username":     connection.Username,
+		"database":     connection.Database,
+		"schema":       connection.Schema,
+		"dialect_name": connection.DialectName,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
+	return diags
+}
+
+func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	c := m.(*Config).Api // .(*lookergo.Client)
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
+
+	connection, _, err := c.Connections.Update(ctx, d.Id(), connectionUpdateFromResourceData(d))
+	if err != nil {
+		return diagErrAppend(diags, err)
+	}
+
+	// The name is the identifier, so a rename changes it.
+	d.SetId(connection.Name)
+	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
+	return resourceConnectionRead(ctx, d, m)
+}
+
+// connectionFromResourceData builds the connection described by the configuration of d.
+func connectionFromResourceData(d *schema.ResourceData) *lookergo.DBConnection {
 	nc := new(lookergo.DBConnection)
-	nc.Name = connectionName
+	nc.Name = d.Get("name").(string)
 
 	if val, ok := d.GetOk("host"); ok {
 		nc.Host = val.(string)
@@ -253,78 +323,45 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 		nc.PdtConcurrency = int64(val.(int))
 	}
 
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: test conf", currFuncName()))
-	validateConfig, _, err := c.Connections.ValidateConfig(ctx, nc)
-	if err != nil {
-		return diagErrAppend(diags, err)
-	}
-
-	for i, dbcv := range validateConfig {
-		tflog.Debug(ctx, fmt.Sprintf("Config [%v] validation message: %v", i, dbcv.Message))
-		tflog.Debug(ctx, fmt.Sprintf("Config [%v] validation status: %v", i, dbcv.Status))
-	}
-
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: create", currFuncName()))
-	connection, _, err := c.Connections.Create(ctx, nc)
-	if err != nil {
-		return diagErrAppend(diags, err)
-	}
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: created, Name: %v", currFuncName(), connection.Name))
-
-	d.SetId(connection.Name)
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
-	return diags
+	return nc
 }
 
-func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-
-	connectionName := d.Get("name").(string)
-	connection, _, err := c.Connections.Get(ctx, connectionName)
-	if err != nil {
-		return diag.FromErr(err)
+// connectionUpdateFromResourceData returns the changed attributes of the connection. Attributes removed from
+// the configuration are cleared.
+func connectionUpdateFromResourceData(d *schema.ResourceData) *lookergo.WriteDBConnection {
+	return &lookergo.WriteDBConnection{
+		Name:                     changedValue[string](d, "name"),
+		Host:                     changedString(d, "host"),
+		Port:                     changedString(d, "port"),
+		Username:                 changedString(d, "username"),
+		Password:                 changedString(d, "password"),
+		Certificate:              changedString(d, "certificate"),
+		FileType:                 changedString(d, "file_type"),
+		Database:                 changedString(d, "database"),
+		DbTimezone:               changedString(d, "db_timezone"),
+		QueryTimezone:            changedString(d, "query_timezone"),
+		Schema:                   changedString(d, "schema"),
+		MaxBillingGigabytes:      changedString(d, "max_billing_gigabytes"),
+		TmpDbName:                changedString(d, "tmp_db_name"),
+		JdbcAdditionalParams:     changedString(d, "jdbc_additional_params"),
+		DialectName:              changedValue[string](d, "dialect_name"),
+		MaintenanceCron:          changedString(d, "maintenance_cron"),
+		AfterConnectStatements:   changedString(d, "after_connect_statements"),
+		TunnelId:                 changedString(d, "tunnel_id"),
+		OauthApplicationId:       changedString(d, "oauth_application_id"),
+		Ssl:                      changedValue[bool](d, "ssl"),
+		VerifySsl:                changedValue[bool](d, "verify_ssl"),
+		UserDbCredentials:        changedValue[bool](d, "user_db_credentials"),
+		SqlRunnerPrecacheTables:  changedValue[bool](d, "sql_runner_precache_tables"),
+		SqlWritingWithInfoSchema: changedValue[bool](d, "sql_writing_with_info_schema"),
+		DisableContextComment:    changedValue[bool](d, "disable_context_comment"),
+		AlwaysRetryFailedBuilds:  changedValue[bool](d, "always_retry_failed_builds"),
+		CostEstimateEnabled:      changedValue[bool](d, "cost_estimate_enabled"),
+		PdtApiControlEnabled:     changedValue[bool](d, "pdt_api_control_enabled"),
+		MaxConnections:           changedInt64(d, "max_connections"),
+		PoolTimeout:              changedInt64(d, "pool_timeout"),
+		PdtConcurrency:           changedInt64(d, "pdt_concurrency"),
 	}
-
-	if d.HasChanges("name", "host", "port", "username") {
-		if d.Set("name", connection.Name) != nil {
-			return diag.FromErr(err)
-		}
-		if d.Set("host", connection.Host) != nil {
-			return diag.FromErr(err)
-		}
-		if d.Set("port", connection.Port) != nil {
-			return diag.FromErr(err)
-		}
-		if d.Set("username", connection.Username) != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
-	return diags
-}
-
-func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-
-	connectionName := d.Get("name").(string)
-	_, _, err := c.Connections.Get(ctx, connectionName)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	_ = append(diags, diag.Diagnostic{
-		Severity: diag.Warning,
-		Summary:  "WARNING connection update is not implemented",
-		AttributePath: cty.Path{
-			cty.GetAttrStep{Name: "name"},
-		},
-	})
-
-	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
-	return resourceConnectionRead(ctx, d, m)
 }
 
 func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccConnection(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name, host string) string {
+		return testConfig(srv, `
+resource "looker_connection" "test" {
+  name         = "`+name+`"
+  dialect_name = "postgres"
+  host         = "`+host+`"
+  port         = "5432"
+  username     = "looker"
+  password     = "secret"
+  database     = "analytics"
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Connections, "looker_connection"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("warehouse", "db.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Connections, "looker_connection.test", &id),
+					resource.TestCheckResourceAttr("looker_connection.test", "id", "warehouse"),
+					resource.TestCheckResourceAttr("looker_connection.test", "host", "db.example.com"),
+				),
+			},
+			{
+				Config: config("warehouse_v2", "db2.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Connections, "looker_connection.test", &id),
+					resource.TestCheckResourceAttr("looker_connection.test", "id", "warehouse_v2"),
+					resource.TestCheckResourceAttr("looker_connection.test", "host", "db2.example.com"),
+				),
+			},
+			{
+				ResourceName:            "looker_connection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.Connections, &id, map[string]interface{}{"host": "changed.example.com"}),
+				Config:    config("warehouse_v2", "db2.example.com"),
+				Check:     resource.TestCheckResourceAttr("looker_connection.test", "host", "db2.example.com"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.Connections, &id),
+				Config:    config("warehouse_v2", "db2.example.com"),
+				Check:     testCheckRemote(srv, lookertest.Connections, "looker_connection.test", nil),
+			},
+		},
+	})
+}
+
+func TestAccConnection_clearAttributes(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(extra string) string {
+		return testConfig(srv, `
+resource "looker_connection" "test" {
+  name         = "warehouse"
+  dialect_name = "postgres"
+  host         = "db.example.com"
+  database     = "analytics"
+`+extra+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Connections, "looker_connection"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  schema = "public"
+  ssl    = true
+`),
+				Check: testCheckRemote(srv, lookertest.Connections, "looker_connection.test", &id),
+			},
+			{
+				// Removing schema clears it, and ssl = false is sent rather than left out.
+				Config: config(`
+  ssl = false
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_connection.test", "schema", ""),
+					func(*terraform.State) error {
+						obj, _ := srv.Get(lookertest.Connections, id)
+						if obj["schema"] != nil || obj["ssl"] != false || obj["database"] != "analytics" {
+							return fmt.Errorf("connection = %v, expected no schema, ssl false and the database kept", obj)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
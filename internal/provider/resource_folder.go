@@ -49,14 +49,14 @@ func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		folder.Name = value.(string)
 	}
 	if value, ok := d.GetOk("parent_id"); ok {
-		folder.ParentId = value.(string)
+		folder.ParentId = lookergo.ID(value.(string))
 	}
 	newFolder, _, err := c.Folders.Create(ctx, folder)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(newFolder.Id)
+	d.SetId(newFolder.Id.String())
 	d.Set("name", newFolder.Name)
 	d.Set("parent_id", newFolder.ParentId)
 
@@ -67,10 +67,9 @@ func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	FolderID := d.Id()
-	Folder, response, err := c.Folders.Get(ctx, FolderID)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	FolderID := lookergo.ID(d.Id())
+	Folder, _, err := c.Folders.Get(ctx, FolderID, nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
@@ -92,22 +91,14 @@ func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	FolderID := d.Id()
+	FolderID := lookergo.ID(d.Id())
 
-	Folder, _, err := c.Folders.Get(ctx, FolderID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if d.HasChanges() {
-		if d.HasChange("name") {
-			Folder.Name = d.Get("name").(string)
-		}
-		if d.HasChange("parent_id") {
-			Folder.ParentId = d.Get("parent_id").(string)
+	if d.HasChanges("name", "parent_id") {
+		folder := lookergo.WriteFolder{
+			Name:     changedValue[string](d, "name"),
+			ParentId: changedID(d, "parent_id"),
 		}
-
-		if _, _, err = c.Folders.Update(ctx, FolderID, Folder); err != nil {
+		if _, _, err := c.Folders.Update(ctx, FolderID, &folder); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -117,7 +108,7 @@ func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	FolderID := d.Id()
+	FolderID := lookergo.ID(d.Id())
 
 	if _, err := c.Folders.Delete(ctx, FolderID); err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFolder(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name string) string {
+		return testConfig(srv, `
+resource "looker_folder" "parent" {
+  name      = "Marketing"
+  parent_id = "`+lookertest.SharedFolderID+`"
+}
+
+resource "looker_folder" "test" {
+  name      = "`+name+`"
+  parent_id = looker_folder.parent.id
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Folders, "looker_folder"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Campaigns"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Folders, "looker_folder.test", &id),
+					resource.TestCheckResourceAttr("looker_folder.test", "name", "Campaigns"),
+					resource.TestCheckResourceAttrPair("looker_folder.test", "parent_id", "looker_folder.parent", "id"),
+				),
+			},
+			{
+				Config: config("Campaigns 2023"),
+				Check:  resource.TestCheckResourceAttr("looker_folder.test", "name", "Campaigns 2023"),
+			},
+			{
+				ResourceName:      "looker_folder.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.Folders, &id, map[string]interface{}{"name": "Changed"}),
+				Config:    config("Campaigns 2023"),
+				Check:     resource.TestCheckResourceAttr("looker_folder.test", "name", "Campaigns 2023"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.Folders, &id),
+				Config:    config("Campaigns 2023"),
+				Check:     testCheckRemote(srv, lookertest.Folders, "looker_folder.test", nil),
+			},
+		},
+	})
+}
@@ -75,7 +75,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
-	d.SetId(idAsString(newGroup.Id))
+	d.SetId(newGroup.Id.String())
 
 	tflog.Info(ctx, "Created Looker Group", map[string]interface{}{"id": newGroup.Id, "name": newGroup.Name})
 
@@ -84,32 +84,31 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	groupID := idAsInt(d.Id())
+	groupID := lookergo.ID(d.Id())
 
-	group, response, err := c.Groups.Get(ctx, groupID)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	group, _, err := c.Groups.Get(ctx, groupID, nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("id", idAsString(group.Id)); err != nil {
+	if err = d.Set("id", group.Id.String()); err != nil {
 		return diag.FromErr(err)
 	}
 	if err = d.Set("name", group.Name); err != nil {
 		return diag.FromErr(err)
 	}
 
-	listGroups, _, err := c.Groups.ListById(ctx, []int{idAsInt(group.Id)}, nil)
+	listGroups, _, err := c.Groups.ListById(ctx, []lookergo.ID{group.Id}, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	group = &listGroups[0]
 
 	if len(group.RoleIds) > 0 {
-		if err = d.Set("roles", group.RoleIds.ToSliceOfStrings()); err != nil {
+		if err = d.Set("roles", group.RoleIds.Strings()); err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
@@ -119,7 +118,7 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 
 	if len(group.ParentGroupIds) > 0 {
-		if err = d.Set("parent_groups", group.ParentGroupIds.ToSliceOfStrings()); err != nil {
+		if err = d.Set("parent_groups", group.ParentGroupIds.Strings()); err != nil {
 			return diag.FromErr(err)
 		}
 	} else {
@@ -133,19 +132,11 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 
 func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	groupID := idAsInt(d.Id())
+	groupID := lookergo.ID(d.Id())
 
-	group, _, err := c.Groups.Get(ctx, groupID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if d.HasChanges() {
-		if d.HasChange("name") {
-			group.Name = d.Get("name").(string)
-		}
-
-		if _, _, err = c.Groups.Update(ctx, groupID, group); err != nil {
+	if d.HasChange("name") {
+		group := lookergo.WriteGroup{Name: changedValue[string](d, "name")}
+		if _, _, err := c.Groups.Update(ctx, groupID, &group); err != nil {
 			return diag.FromErr(err)
 		}
 		d.Set("last_updated", time.Now().Format(time.RFC850))
@@ -156,7 +147,7 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	groupID := idAsInt(d.Id())
+	groupID := lookergo.ID(d.Id())
 
 	if d.Get("delete_on_destroy").(bool) {
 		if _, err := c.Groups.Delete(ctx, groupID); err != nil {
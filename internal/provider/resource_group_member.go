@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+
 	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -59,14 +60,45 @@ func resourceGroupMember() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceGroupMemberImport,
 		},
 	}
 }
 
+// resourceGroupMemberImport takes the id of the parent group, and imports all of its current members.
+func resourceGroupMemberImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*Config).Api // .(*lookergo.Client)
+
+	groupID := lookergo.ID(d.Id())
+
+	memberUsers, _, err := c.Groups.ListMemberUsers(ctx, groupID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var userItems []interface{}
+	for _, user := range memberUsers {
+		userItems = append(userItems, map[string]interface{}{"id": user.Id.String()})
+	}
+
+	memberGroups, _, err := c.Groups.ListMemberGroups(ctx, groupID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var groupItems []interface{}
+	for _, group := range memberGroups {
+		groupItems = append(groupItems, map[string]interface{}{"id": group.Id.String()})
+	}
+
+	d.Set("target_group_id", d.Id())
+	d.Set("user", userItems)
+	d.Set("group", groupItems)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func parentGroup(ctx context.Context, d *schema.ResourceData, c *lookergo.Client) (*lookergo.Group, error) {
 	tflog.Info(ctx, "Verifying parent group.")
-	group, _, err := c.Groups.Get(ctx, idAsInt(d.Get("target_group_id").(string)))
+	group, _, err := c.Groups.Get(ctx, lookergo.ID(d.Get("target_group_id").(string)), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -91,11 +123,11 @@ func resourceGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m in
 			val := obj["id"].(string)
 			tflog.Info(ctx, "Add user", map[string]interface{}{"id": val})
 
-			memberUser, _, err := c.Groups.AddMemberUser(ctx, idAsInt(pg.Id), idAsInt(val))
+			memberUser, _, err := c.Groups.AddMemberUser(ctx, pg.Id, lookergo.ID(val))
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			userItems[i] = map[string]interface{}{"id": idAsString(memberUser.Id), "first_name": memberUser.FirstName, "last_name": memberUser.LastName}
+			userItems[i] = map[string]interface{}{"id": memberUser.Id.String(), "first_name": memberUser.FirstName, "last_name": memberUser.LastName}
 		}
 		d.Set("user", userItems)
 	}
@@ -108,15 +140,15 @@ func resourceGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m in
 			val := obj["id"].(string)
 			tflog.Info(ctx, "Add group", map[string]interface{}{"id": val})
 
-			memberGroup, _, err := c.Groups.AddMemberGroup(ctx, idAsInt(pg.Id), idAsInt(val))
+			memberGroup, _, err := c.Groups.AddMemberGroup(ctx, pg.Id, lookergo.ID(val))
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			groupItems[i] = map[string]interface{}{"id": idAsString(memberGroup.Id), "name": memberGroup.Name}
+			groupItems[i] = map[string]interface{}{"id": memberGroup.Id.String(), "name": memberGroup.Name}
 		}
 		d.Set("group", groupItems)
 	}
-	d.SetId("-")
+	d.SetId(pg.Id.String())
 	return resourceGroupMemberRead(ctx, d, m)
 }
 
@@ -124,10 +156,15 @@ func resourceGroupMemberRead(ctx context.Context, d *schema.ResourceData, m inte
 	c := m.(*Config).Api // .(*lookergo.Client)
 
 	pg, err := parentGroup(ctx, d, c)
+	if goneFromRemote(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	tflog.Info(ctx, "Read group members for", map[string]interface{}{"target_group_id": pg.Id})
+	// Members created before the id was the parent group have "-" as id.
+	d.SetId(pg.Id.String())
 
 	userSet, ok := d.GetOk("user")
 	if ok {
@@ -140,8 +177,8 @@ func resourceGroupMemberRead(ctx context.Context, d *schema.ResourceData, m inte
 			obj := raw.(map[string]interface{})
 
 			for _, user := range memberUsers {
-				if idAsString(user.Id) == obj["id"].(string) {
-					userItems = append(userItems, map[string]interface{}{"id": idAsString(user.Id), "first_name": user.FirstName, "last_name": user.LastName})
+				if user.Id.String() == obj["id"].(string) {
+					userItems = append(userItems, map[string]interface{}{"id": user.Id.String(), "first_name": user.FirstName, "last_name": user.LastName})
 				}
 			}
 		}
@@ -159,8 +196,8 @@ func resourceGroupMemberRead(ctx context.Context, d *schema.ResourceData, m inte
 			obj := raw.(map[string]interface{})
 
 			for _, group := range memberGroups {
-				if idAsString(group.Id) == obj["id"].(string) {
-					groupItems = append(groupItems, map[string]interface{}{"id": idAsString(group.Id), "name": group.Name})
+				if group.Id.String() == obj["id"].(string) {
+					groupItems = append(groupItems, map[string]interface{}{"id": group.Id.String(), "name": group.Name})
 				}
 			}
 		}
@@ -217,13 +254,13 @@ func resourceGroupMemberUpdate(ctx context.Context, d *schema.ResourceData, m in
 			idsFromMysteryInterface(newRaw.(*schema.Set).List()))
 
 		for _, item := range remove {
-			_, err := c.Groups.RemoveMemberUser(ctx, idAsInt(pg.Id), idAsInt(item))
+			_, err := c.Groups.RemoveMemberUser(ctx, pg.Id, lookergo.ID(item))
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 		for _, item := range create {
-			_, _, err := c.Groups.AddMemberUser(ctx, idAsInt(pg.Id), idAsInt(item))
+			_, _, err := c.Groups.AddMemberUser(ctx, pg.Id, lookergo.ID(item))
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -237,13 +274,13 @@ func resourceGroupMemberUpdate(ctx context.Context, d *schema.ResourceData, m in
 			idsFromMysteryInterface(newRaw.(*schema.Set).List()))
 
 		for _, item := range remove {
-			_, err := c.Groups.RemoveMemberGroup(ctx, idAsInt(pg.Id), idAsInt(item))
+			_, err := c.Groups.RemoveMemberGroup(ctx, pg.Id, lookergo.ID(item))
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 		for _, item := range create {
-			_, _, err := c.Groups.AddMemberGroup(ctx, idAsInt(pg.Id), idAsInt(item))
+			_, _, err := c.Groups.AddMemberGroup(ctx, pg.Id, lookergo.ID(item))
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -270,8 +307,9 @@ func resourceGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m in
 			val := obj["id"].(string)
 			tflog.Info(ctx, "Remove user from group", map[string]interface{}{"id": val})
 
-			_, err := c.Groups.RemoveMemberUser(ctx, idAsInt(pg.Id), idAsInt(val))
-			if err != nil {
+			// A member which is already gone, e.g. a deleted user, needs no removal.
+			_, err := c.Groups.RemoveMemberUser(ctx, pg.Id, lookergo.ID(val))
+			if err != nil && !lookergo.IsNotFound(err) {
 				return diag.FromErr(err)
 			}
 		}
@@ -286,8 +324,9 @@ func resourceGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m in
 			val := obj["id"].(string)
 			tflog.Info(ctx, "Remove group from group", map[string]interface{}{"id": val})
 
-			_, err := c.Groups.RemoveMemberGroup(ctx, idAsInt(pg.Id), idAsInt(val))
-			if err != nil {
+			// A member which is already gone, e.g. a deleted user, needs no removal.
+			_, err := c.Groups.RemoveMemberGroup(ctx, pg.Id, lookergo.ID(val))
+			if err != nil && !lookergo.IsNotFound(err) {
 				return diag.FromErr(err)
 			}
 		}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGroupMember(t *testing.T) {
+	srv := newTestServer(t)
+	c := testClient(t, srv)
+	var groupID, userID string
+
+	config := func(members string) string {
+		return testConfig(srv, `
+resource "looker_group" "parent" {
+  name = "Marketing"
+}
+
+resource "looker_group" "child" {
+  name = "Campaigns"
+}
+
+resource "looker_user" "jane" {
+  first_name = "Jane"
+  email      = "jane@example.com"
+}
+
+resource "looker_user" "john" {
+  first_name = "John"
+  email      = "john@example.com"
+}
+
+resource "looker_group_member" "test" {
+  target_group_id = looker_group.parent.id
+`+members+`
+}
+`)
+	}
+	initial := config(`
+  user {
+    id = looker_user.jane.id
+  }
+  group {
+    id = looker_group.child.id
+  }
+`)
+	updated := config(`
+  user {
+    id = looker_user.jane.id
+  }
+  user {
+    id = looker_user.john.id
+  }
+  group {
+    id = looker_group.child.id
+  }
+`)
+
+	testCheckMembers := func(users, groups int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs := s.RootModule().Resources["looker_group_member.test"]
+			groupID, userID = rs.Primary.ID, s.RootModule().Resources["looker_user.jane"].Primary.ID
+
+			memberUsers, _, err := c.Groups.ListMemberUsers(context.Background(), lookergo.ID(groupID), nil)
+			if err != nil {
+				return err
+			}
+			memberGroups, _, err := c.Groups.ListMemberGroups(context.Background(), lookergo.ID(groupID), nil)
+			if err != nil {
+				return err
+			}
+			if len(memberUsers) != users || len(memberGroups) != groups {
+				return fmt.Errorf("group %s has %d users and %d groups, expected %d and %d", groupID, len(memberUsers), len(memberGroups), users, groups)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Groups, "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: initial,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMembers(1, 1),
+					resource.TestCheckResourceAttrPair("looker_group_member.test", "id", "looker_group.parent", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_group_member.test", "user.*", map[string]string{"first_name": "Jane"}),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_group_member.test", "group.*", map[string]string{"name": "Campaigns"}),
+				),
+			},
+			{
+				Config: updated,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMembers(2, 1),
+					resource.TestCheckResourceAttr("looker_group_member.test", "user.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "looker_group_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					if _, err := c.Groups.RemoveMemberUser(context.Background(), lookergo.ID(groupID), lookergo.ID(userID)); err != nil {
+						t.Fatalf("RemoveMemberUser: %v", err)
+					}
+				},
+				Config: updated,
+				Check:  testCheckMembers(2, 1),
+			},
+			{
+				Config: initial,
+				Check:  testCheckMembers(1, 1),
+			},
+		},
+	})
+}
+
+func TestResourceGroupMemberRead_unpaged(t *testing.T) {
+	// Exactly one default page of members, which the API serves again whatever the offset.
+	c, requests := newUnpagedClient(t, map[string]string{
+		"groups/1":        `{"id":"1","name":"Marketing"}`,
+		"groups/1/users":  testRows(100),
+		"groups/1/groups": testRows(100),
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	d := schema.TestResourceDataRaw(t, resourceGroupMember().Schema, map[string]interface{}{
+		"target_group_id": "1",
+		"user":            []interface{}{map[string]interface{}{"id": "100"}},
+		"group":           []interface{}{map[string]interface{}{"id": "100"}},
+	})
+	if diags := resourceGroupMemberRead(ctx, d, &Config{Api: c}); diags.HasError() {
+		t.Fatalf("resourceGroupMemberRead: %v", diags)
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Errorf("resourceGroupMemberRead sent %d requests, expected 3", n)
+	}
+	if users, groups := d.Get("user.#").(int), d.Get("group.#").(int); users != 1 || groups != 1 {
+		t.Errorf("user.# = %d and group.# = %d, expected 1 and 1", users, groups)
+	}
+}
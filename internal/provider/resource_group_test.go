@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGroup(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name string) string {
+		return testConfig(srv, `
+resource "looker_group" "test" {
+  name = "`+name+`"
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Groups, "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Analysts"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Groups, "looker_group.test", &id),
+					resource.TestCheckResourceAttr("looker_group.test", "name", "Analysts"),
+					resource.TestCheckResourceAttr("looker_group.test", "roles.#", "0"),
+				),
+			},
+			{
+				Config: config("Data Analysts"),
+				Check:  resource.TestCheckResourceAttr("looker_group.test", "name", "Data Analysts"),
+			},
+			{
+				ResourceName:            "looker_group.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_on_destroy", "last_updated"},
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.Groups, &id, map[string]interface{}{"name": "Renamed in the UI"}),
+				Config:    config("Data Analysts"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_group.test", "name", "Data Analysts"),
+					resource.TestCheckResourceAttrPtr("looker_group.test", "id", &id),
+				),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.Groups, &id),
+				Config:    config("Data Analysts"),
+				Check:     testCheckRemote(srv, lookertest.Groups, "looker_group.test", nil),
+			},
+		},
+	})
+}
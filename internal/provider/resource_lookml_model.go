@@ -78,11 +78,8 @@ func resourceLookMlModelCreate(ctx context.Context, d *schema.ResourceData, m in
 func resourceLookMlModelRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	lmlMdlName := d.Get("name").(string)
-
-	newModel, response, err := c.LookMLModel.Get(ctx, lmlMdlName)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	newModel, _, err := c.LookMLModel.Get(ctx, d.Id(), nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
@@ -91,6 +88,9 @@ func resourceLookMlModelRead(ctx context.Context, d *schema.ResourceData, m inte
 	if newModel == nil {
 		return diag.FromErr(new(lookergo.ArgError))
 	}
+	if err = d.Set("name", newModel.Name); err != nil {
+		return diag.FromErr(err)
+	}
 	if err = d.Set("project_name", newModel.ProjectName); err != nil {
 		return diag.FromErr(err)
 	}
@@ -110,15 +110,18 @@ func resourceLookMlModelRead(ctx context.Context, d *schema.ResourceData, m inte
 func resourceLookMlModelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	lmlMdlName := d.Get("name").(string)
-	projectName := d.Get("project_name").(string)
-	dbConnNames := schemaSetToStringSlice(d.Get("allowed_db_connection_names").(*schema.Set))
-	unlimitedConn := d.Get("unlimited_db_connections").(bool)
-	oldLookMl := lookergo.LookMLModel{Name: lmlMdlName, ProjectName: projectName, AllowedDbConnectionNames: dbConnNames, UnlimitedDbConnections: unlimitedConn}
-	lookerML, _, err := c.LookMLModel.Update(ctx, d.Get("name").(string), &oldLookMl)
+	lookMl := lookergo.WriteLookMLModel{
+		Name:                     changedValue[string](d, "name"),
+		ProjectName:              changedValue[string](d, "project_name"),
+		AllowedDbConnectionNames: changedStringSet(d, "allowed_db_connection_names"),
+		UnlimitedDbConnections:   changedValue[bool](d, "unlimited_db_connections"),
+	}
+	lookerML, _, err := c.LookMLModel.Update(ctx, d.Id(), &lookMl)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	// The name is the identifier, so a rename changes it.
+	d.SetId(lookerML.Name)
 	if err = d.Set("project_name", lookerML.ProjectName); err != nil {
 		return diag.FromErr(err)
 	}
@@ -130,7 +133,7 @@ func resourceLookMlModelDelete(ctx context.Context, d *schema.ResourceData, m in
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
-	_, err := c.LookMLModel.Delete(ctx, d.Get("name").(string))
+	_, err := c.LookMLModel.Delete(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
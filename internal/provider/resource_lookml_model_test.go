@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookMlModel(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name string, unlimited bool) string {
+		return testConfig(srv, fmt.Sprintf(`
+resource "looker_project" "test" {
+  name = "marketing"
+}
+
+resource "looker_connection" "test" {
+  name         = "warehouse"
+  dialect_name = "postgres"
+  host         = "db.example.com"
+}
+
+resource "looker_lookml_model" "test" {
+  name                        = %q
+  project_name                = looker_project.test.name
+  allowed_db_connection_names = [looker_connection.test.name]
+  unlimited_db_connections    = %t
+}
+`, name, unlimited))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.LookMLModels, "looker_lookml_model"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("campaigns", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.LookMLModels, "looker_lookml_model.test", &id),
+					resource.TestCheckResourceAttr("looker_lookml_model.test", "project_name", "marketing"),
+					resource.TestCheckResourceAttr("looker_lookml_model.test", "allowed_db_connection_names.#", "1"),
+				),
+			},
+			{
+				Config: config("campaigns_v2", true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.LookMLModels, "looker_lookml_model.test", &id),
+					resource.TestCheckResourceAttr("looker_lookml_model.test", "id", "campaigns_v2"),
+					resource.TestCheckResourceAttr("looker_lookml_model.test", "unlimited_db_connections", "true"),
+				),
+			},
+			{
+				ResourceName:      "looker_lookml_model.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.LookMLModels, &id, map[string]interface{}{"unlimited_db_connections": false}),
+				Config:    config("campaigns_v2", true),
+				Check:     resource.TestCheckResourceAttr("looker_lookml_model.test", "unlimited_db_connections", "true"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.LookMLModels, &id),
+				Config:    config("campaigns_v2", true),
+				Check:     testCheckRemote(srv, lookertest.LookMLModels, "looker_lookml_model.test", nil),
+			},
+		},
+	})
+}
@@ -44,12 +44,10 @@ func resourceModelSet() *schema.Resource {
 func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	var interfaceModel = d.Get("models").(*schema.Set)
-	var model_list []string
-	for _, raw := range interfaceModel.List() {
-		model_list = append(model_list, raw.(string))
+	var modelSet = lookergo.WriteModelSet{
+		Name:   lookergo.Value(d.Get("name").(string)),
+		Models: lookergo.Value(schemaSetToStringSlice(d.Get("models").(*schema.Set))),
 	}
-	var modelSet = lookergo.ModelSet{Name: d.Get("name").(string), Models: model_list}
 	newSet, _, err := c.ModelSets.Create(ctx, &modelSet)
 	if err != nil {
 		return diag.FromErr(err)
@@ -57,7 +55,7 @@ func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, m inter
 	if newSet == nil {
 		return diag.FromErr(&lookergo.ArgError{})
 	}
-	d.SetId(newSet.Id)
+	d.SetId(newSet.Id.String())
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
 	return resourceModelSetRead(ctx, d, m)
 }
@@ -65,10 +63,9 @@ func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, m inter
 func resourceModelSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	var id = d.Id()
-	newModel, response, err := c.ModelSets.Get(ctx, id)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	var id = lookergo.ID(d.Id())
+	newModel, _, err := c.ModelSets.Get(ctx, id, nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
@@ -94,25 +91,15 @@ func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
-	var id = d.Id()
-	currentModel, _, err := c.ModelSets.Get(ctx, id)
-	if err != nil {
-		return diag.FromErr(err)
+	var id = lookergo.ID(d.Id())
+	modelSet := lookergo.WriteModelSet{
+		Name:   changedValue[string](d, "name"),
+		Models: changedStringSet(d, "models"),
 	}
-
-	var interfaceModel = d.Get("models").(*schema.Set)
-	var model_list []string
-	for _, raw := range interfaceModel.List() {
-		model_list = append(model_list, raw.(string))
-	}
-
-	currentModel.Name = d.Get("name").(string)
-	currentModel.Models = model_list
-	_, _, err = c.ModelSets.Update(ctx, id, currentModel)
+	_, _, err := c.ModelSets.Update(ctx, id, &modelSet)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	resourceModelSetRead(ctx, d, m)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
 	return resourceModelSetRead(ctx, d, m)
 }
@@ -120,7 +107,7 @@ func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, m inter
 func resourceModelSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	var Id = d.Id()
+	var Id = lookergo.ID(d.Id())
 	_, err := c.ModelSets.Delete(ctx, Id)
 	if err != nil {
 		return diag.FromErr(err)
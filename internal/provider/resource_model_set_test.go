@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccModelSet(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name, models string) string {
+		return testConfig(srv, `
+resource "looker_model_set" "test" {
+  name   = "`+name+`"
+  models = `+models+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.ModelSets, "looker_model_set"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Marketing", `["marketing"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.ModelSets, "looker_model_set.test", &id),
+					resource.TestCheckResourceAttr("looker_model_set.test", "name", "Marketing"),
+					resource.TestCheckResourceAttr("looker_model_set.test", "models.#", "1"),
+				),
+			},
+			{
+				Config: config("Marketing and sales", `["marketing", "sales"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_model_set.test", "name", "Marketing and sales"),
+					resource.TestCheckTypeSetElemAttr("looker_model_set.test", "models.*", "sales"),
+				),
+			},
+			{
+				ResourceName:      "looker_model_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.ModelSets, &id, map[string]interface{}{"models": []interface{}{"finance"}}),
+				Config:    config("Marketing and sales", `["marketing", "sales"]`),
+				Check:     resource.TestCheckResourceAttr("looker_model_set.test", "models.#", "2"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.ModelSets, &id),
+				Config:    config("Marketing and sales", `["marketing", "sales"]`),
+				Check:     testCheckRemote(srv, lookertest.ModelSets, "looker_model_set.test", nil),
+			},
+		},
+	})
+}
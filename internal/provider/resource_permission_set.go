@@ -63,7 +63,7 @@ func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(new_permset.Id)
+	d.SetId(new_permset.Id.String())
 	d.Set("name", new_permset.Name)
 	d.Set("permissions", new_permset.Permissions)
 
@@ -72,10 +72,9 @@ func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, m
 
 func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	permissionSetID := d.Id()
-	permissionSet, response, err := c.PermissionSets.Get(ctx, permissionSetID)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	permissionSetID := lookergo.ID(d.Id())
+	permissionSet, _, err := c.PermissionSets.Get(ctx, permissionSetID, nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
@@ -97,26 +96,14 @@ func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m in
 
 func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	permissionSetID := d.Id()
+	permissionSetID := lookergo.ID(d.Id())
 
-	permissionSet, _, err := c.PermissionSets.Get(ctx, permissionSetID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if d.HasChanges() {
-		if d.HasChange("name") {
-			permissionSet.Name = d.Get("name").(string)
-		}
-		if d.HasChange("permissions") {
-			var value = d.Get("permissions").(*schema.Set)
-			var permission_list []string
-			for _, raw := range value.List() {
-				permission_list = append(permission_list, raw.(string))
-			}
-			permissionSet.Permissions = permission_list
+	if d.HasChanges("name", "permissions") {
+		permissionSet := lookergo.WritePermissionSet{
+			Name:        changedValue[string](d, "name"),
+			Permissions: changedStringSet(d, "permissions"),
 		}
-		if _, _, err = c.PermissionSets.Update(ctx, permissionSetID, permissionSet); err != nil {
+		if _, _, err := c.PermissionSets.Update(ctx, permissionSetID, &permissionSet); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -126,7 +113,7 @@ func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourcePermissionSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	PermissionSetId := d.Id()
+	PermissionSetId := lookergo.ID(d.Id())
 
 	if _, err := c.PermissionSets.Delete(ctx, PermissionSetId); err != nil {
 		return diag.FromErr(err)
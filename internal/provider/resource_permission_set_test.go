@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPermissionSet(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name, permissions string) string {
+		return testConfig(srv, `
+resource "looker_permission_set" "test" {
+  name        = "`+name+`"
+  permissions = `+permissions+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.PermissionSets, "looker_permission_set"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Viewer", `["access_data", "see_looks"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.PermissionSets, "looker_permission_set.test", &id),
+					resource.TestCheckResourceAttr("looker_permission_set.test", "name", "Viewer"),
+					resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "2"),
+				),
+			},
+			{
+				Config: config("Explorer", `["access_data", "explore", "see_looks"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_permission_set.test", "name", "Explorer"),
+					resource.TestCheckTypeSetElemAttr("looker_permission_set.test", "permissions.*", "explore"),
+				),
+			},
+			{
+				ResourceName:      "looker_permission_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.PermissionSets, &id, map[string]interface{}{"permissions": []interface{}{"access_data"}}),
+				Config:    config("Explorer", `["access_data", "explore", "see_looks"]`),
+				Check:     resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "3"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.PermissionSets, &id),
+				Config:    config("Explorer", `["access_data", "explore", "see_looks"]`),
+				Check:     testCheckRemote(srv, lookertest.PermissionSets, "looker_permission_set.test", nil),
+			},
+		},
+	})
+}
@@ -19,6 +19,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// projectRenameWait is how long to wait for Looker to settle after renaming a project on delete.
+var projectRenameWait = 5 * time.Second
+
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceProjectCreate,
@@ -61,17 +64,10 @@ func resourceProject() *schema.Resource {
 }
 
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	// c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, m.(*Config).Api, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
-	/*	if err := ensureDevClient(ctx, m); err != nil {
-			return diagErrAppend(diags, err)
-		}
-		dc := m.(*Config).DevClient*/
 
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
@@ -97,13 +93,7 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	if err := ensureDevClient(ctx, m); err != nil {
-		return diagErrAppend(diags, err)
-	}
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
@@ -111,9 +101,8 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	projectId := d.Id()
 	var project *lookergo.Project
-	project, response, err := dc.Projects.Get(ctx, projectId)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	project, _, err = dc.Projects.Get(ctx, projectId, nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
@@ -129,32 +118,28 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	dc := m.(*Config).DevClient // .(*lookergo.Client)
-	err := dc.EnsureStaticToken(ctx, m.(*Config).Api, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	project := &lookergo.Project{
-		Name: d.Get("name").(string),
+	project := &lookergo.WriteProject{
+		Name: changedValue[string](d, "name"),
 	}
-	_, _, err = dc.Projects.Update(ctx, project.Name, project)
+	_, _, err = dc.Projects.Update(ctx, d.Id(), project)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	// The name is the identifier, so a rename changes it.
+	d.SetId(d.Get("name").(string))
 
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
 	return resourceProjectRead(ctx, d, m)
 }
 
 func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
-	if err := ensureDevClient(ctx, m); err != nil {
-		return diagErrAppend(diags, err)
-	}
-	dc := m.(*Config).DevClient
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
@@ -162,53 +147,53 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 	projectId := d.Id()
 
 	tflog.Debug(ctx, fmt.Sprintf("Trying to get project details for %s", projectId))
-	project, _, err := dc.Projects.Get(ctx, projectId)
+	project, _, err := dc.Projects.Get(ctx, projectId, nil)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
 
 	// API not available
-	deletedProject := &lookergo.Project{Name: fmt.Sprintf("deleteme-%s-%s", project.Name, srand(4))}
+	deletedName := fmt.Sprintf("deleteme-%s-%s", project.Name, srand(4))
 
-	_, resp, err := dc.Projects.Update(ctx, projectId, deletedProject)
-	if resp.StatusCode == http.StatusInternalServerError {
+	_, resp, err := dc.Projects.Update(ctx, projectId, &lookergo.WriteProject{Name: lookergo.Value(deletedName)})
+	if resp != nil && resp.StatusCode == http.StatusInternalServerError {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary: fmt.Sprintf("Tried to rename project '%s' to '%s', "+
-				"but got internal server error", project.Name, deletedProject.Name),
+				"but got internal server error", project.Name, deletedName),
 			Detail: fmt.Sprintf("%v\nA server 500 error is to be expected. "+
 				"The resource might actually have been renamed. "+
 				"We will check if the previous resource still exists to verify it has been renamed.\n"+
 				"Err:%v", `¯\_(ツ)_/¯`, err.Error()),
 		})
 		tflog.Debug(ctx, "Action: tried renaming project, but got err 500",
-			map[string]interface{}{"orig_name": project.Name, "new_name": deletedProject.Name})
-		time.Sleep(5 * time.Second)
-	} else if 300 <= resp.StatusCode && err != nil {
+			map[string]interface{}{"orig_name": project.Name, "new_name": deletedName})
+		time.Sleep(projectRenameWait)
+	} else if err != nil {
 		return diagErrAppend(diags, err)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Action: renamed project, New name: %v", deletedProject.Name))
+	tflog.Debug(ctx, fmt.Sprintf("Action: renamed project, New name: %v", deletedName))
 
-	_, resp, err = dc.Projects.Get(ctx, projectId)
-	if resp.StatusCode == http.StatusNotFound {
-		tflog.Debug(ctx, fmt.Sprintf("Action: project '%v' not found, so let's assume it has been renamed to '%s'.", project.Name, deletedProject.Name))
+	_, _, err = dc.Projects.Get(ctx, projectId, nil)
+	if lookergo.IsNotFound(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Action: project '%v' not found, so let's assume it has been renamed to '%s'.", project.Name, deletedName))
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
-			Summary:  fmt.Sprintf("Project '%v' not found, so let's assume it has been renamed to '%s'.", project.Name, deletedProject.Name),
+			Summary:  fmt.Sprintf("Project '%v' not found, so let's assume it has been renamed to '%s'.", project.Name, deletedName),
 			Detail:   fmt.Sprintf("%v\n Err:%v ", `¯\_(ツ)_/¯`, err.Error()),
 		})
-	} else if 300 <= resp.StatusCode && err != nil {
+	} else if err != nil {
 		return diagErrAppend(diags, err)
 	}
-	time.Sleep(5 * time.Second)
+	time.Sleep(projectRenameWait)
 
-	renamedProject, _, err := dc.Projects.Get(ctx, deletedProject.Name)
+	renamedProject, _, err := dc.Projects.Get(ctx, deletedName, nil)
 	tflog.Debug(ctx, fmt.Sprintf("Err is %v", err))
 	if renamedProject != nil && err == nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
-			Summary:  fmt.Sprintf("Renamed project '%v' was found, so it's safe to assume '%s' has been deleted", deletedProject.Name, project.Name),
+			Summary:  fmt.Sprintf("Renamed project '%v' was found, so it's safe to assume '%s' has been deleted", deletedName, project.Name),
 			Detail:   `¯\_(ツ)_/¯`,
 		})
 	} else if err != nil {
@@ -224,7 +209,7 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 			Severity: diag.Warning,
 			Summary:  fmt.Sprintf("will use private API with email '%v' and pass ****", uaccEmail),
 		})
-		err := dodgyProjectDelete(dc.BaseURL, uaccEmail, uaccPass, deletedProject.Name)
+		err := dodgyProjectDelete(dc.BaseURL(), uaccEmail, uaccPass, deletedName)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
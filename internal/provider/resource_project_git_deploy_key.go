@@ -14,6 +14,12 @@ func resourceProjectGitDeployKey() *schema.Resource {
 		ReadContext:   resourceProjectGitDeployKeyRead,
 		// UpdateContext: resourceProjectGitDeployKeyUpdate, All fields are ForceNew or Computed w/out Optional, Update is superfluous
 		DeleteContext: resourceProjectGitDeployKeyDelete,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    resourceProjectGitDeployKeyV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceProjectGitDeployKeyStateUpgradeV0,
+		}},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
@@ -33,10 +39,7 @@ func resourceProjectGitDeployKey() *schema.Resource {
 }
 
 func resourceProjectGitDeployKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
@@ -55,7 +58,7 @@ func resourceProjectGitDeployKeyCreate(ctx context.Context, d *schema.ResourceDa
 		d.Set("public_key", pubKey)
 	}
 
-	d.SetId("-")
+	d.SetId(projectName)
 
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
 	return diags
@@ -63,24 +66,26 @@ func resourceProjectGitDeployKeyCreate(ctx context.Context, d *schema.ResourceDa
 
 func resourceProjectGitDeployKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
-	projectName := d.Get("project_id").(string)
+	projectName := d.Id()
 
 	pubKey, _, err := c.Projects.GitDeployKeyGet(ctx, projectName)
 	if err != nil {
 		pubKey, _, err = dc.Projects.GitDeployKeyGet(ctx, projectName)
+		if goneFromRemote(ctx, d, err) {
+			return diags
+		}
 		if err != nil {
 			return logErrDiag(ctx, diags, "Could not read ssh public key", "err", err)
 		}
 	}
 
+	d.Set("project_id", projectName)
 	d.Set("public_key", *pubKey)
 
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
@@ -99,3 +104,22 @@ func resourceProjectGitDeployKeyDelete(ctx context.Context, d *schema.ResourceDa
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
 	return diags
 }
+
+// resourceProjectGitDeployKeyV0 is the schema of the deploy keys whose id was "-".
+func resourceProjectGitDeployKeyV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {Type: schema.TypeString, Required: true, ForceNew: true},
+			"public_key": {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+// resourceProjectGitDeployKeyStateUpgradeV0 takes the project as id of the deploy keys created with "-" as id,
+// as the key would otherwise be looked up in a project named "-" and created again.
+func resourceProjectGitDeployKeyStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState["id"] == "-" {
+		rawState["id"] = rawState["project_id"]
+	}
+	return rawState, nil
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccProjectGitDeployKey(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := testConfig(srv, `
+resource "looker_project" "test" {
+  name = "marketing"
+}
+
+resource "looker_project_git_deploy_key" "test" {
+  project_id = looker_project.test.id
+}
+`)
+
+	checkKey := resource.TestCheckResourceAttrWith("looker_project_git_deploy_key.test", "public_key", func(v string) error {
+		if !strings.HasPrefix(v, "ssh-") {
+			return fmt.Errorf("public_key = %q, expected an ssh public key", v)
+		}
+		return nil
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Projects, "looker_project"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Projects, "looker_project.test", &id),
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "id", "marketing"),
+					checkKey,
+				),
+			},
+			{
+				ResourceName:      "looker_project_git_deploy_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Looker cannot delete a deploy key, it goes away with its project.
+				PreConfig: testRemoveRemote(t, srv, lookertest.Projects, &id),
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Projects, "looker_project.test", nil),
+					checkKey,
+				),
+			},
+		},
+	})
+}
+
+// deployKeyV0ProviderFactories instantiate the provider as it was when deploy keys were created with "-" as id.
+var deployKeyV0ProviderFactories = map[string]func() (*schema.Provider, error){
+	"looker": func() (*schema.Provider, error) {
+		p := New("test")()
+		r := p.ResourcesMap["looker_project_git_deploy_key"]
+		create := r.CreateContext
+		r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := create(ctx, d, m)
+			d.SetId("-")
+			return diags
+		}
+		r.ReadContext = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil }
+		r.SchemaVersion = 0
+		r.StateUpgraders = nil
+		return p, nil
+	},
+}
+
+func TestAccProjectGitDeployKey_stateV0(t *testing.T) {
+	srv := newTestServer(t)
+	var publicKey string
+
+	config := testConfig(srv, `
+resource "looker_project" "test" {
+  name = "marketing"
+}
+
+resource "looker_project_git_deploy_key" "test" {
+  project_id = looker_project.test.id
+}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: testCheckDestroyed(srv, lookertest.Projects, "looker_project"),
+		Steps: []resource.TestStep{
+			{
+				ProviderFactories: deployKeyV0ProviderFactories,
+				Config:            config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "id", "-"),
+					resource.TestCheckResourceAttrWith("looker_project_git_deploy_key.test", "public_key", func(v string) error {
+						publicKey = v
+						return nil
+					}),
+				),
+			},
+			{
+				// The key of the state is kept, rather than a new one replacing it.
+				ProviderFactories: providerFactories,
+				Config:            config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "id", "marketing"),
+					resource.TestCheckResourceAttrWith("looker_project_git_deploy_key.test", "public_key", func(v string) error {
+						if v != publicKey {
+							return fmt.Errorf("public_key = %q, expected the key created before the upgrade %q", v, publicKey)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceProjectGitDeployKeyStateUpgradeV0(t *testing.T) {
+	tests := []struct {
+		state, want map[string]interface{}
+	}{
+		{
+			state: map[string]interface{}{"id": "-", "project_id": "marketing", "public_key": "ssh-ed25519 AAAA"},
+			want:  map[string]interface{}{"id": "marketing", "project_id": "marketing", "public_key": "ssh-ed25519 AAAA"},
+		},
+		{
+			state: map[string]interface{}{"id": "marketing", "project_id": "marketing", "public_key": "ssh-ed25519 AAAA"},
+			want:  map[string]interface{}{"id": "marketing", "project_id": "marketing", "public_key": "ssh-ed25519 AAAA"},
+		},
+	}
+	for _, tt := range tests {
+		got, err := resourceProjectGitDeployKeyStateUpgradeV0(context.Background(), tt.state, nil)
+		if err != nil {
+			t.Fatalf("resourceProjectGitDeployKeyStateUpgradeV0 returned error: %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("resourceProjectGitDeployKeyStateUpgradeV0 = %v, expected %v", got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,10 @@ import (
 	"strings"
 )
 
+// gitProductionBranchNameSince is the first Looker release with git_production_branch_name. On older instances the
+// default is not sent, and configuring the attribute fails at plan time.
+const gitProductionBranchNameSince = "21.0"
+
 func resourceProjectGitRepo() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceProjectGitRepoCreate,
@@ -89,6 +93,9 @@ func resourceProjectGitRepo() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: requireVersions(map[string]string{
+			"git_production_branch_name": gitProductionBranchNameSince,
+		}),
 	}
 }
 
@@ -118,28 +125,37 @@ func resourceProjectGitRepo() *schema.Resource {
 */
 
 func resourceProjectGitRepoRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
 
-	projectName := d.Get("project_id").(string)
+	projectName := d.Id()
 
-	project, _, err := dc.Projects.Get(ctx, projectName)
+	project, _, err := dc.Projects.Get(ctx, projectName, nil)
+	if goneFromRemote(ctx, d, err) {
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	// Removing the repository turns the project back into a bare one.
+	if project.GitRemoteUrl == "" {
+		tflog.Warn(ctx, "Project has no git repository anymore, removing from state", map[string]interface{}{"id": projectName})
+		d.SetId("")
+		return diags
+	}
 
+	d.Set("project_id", project.Id)
 	d.Set("git_remote_url", project.GitRemoteUrl)
 	d.Set("git_username", project.GitUsername)
 	d.Set("use_git_cookie_auth", project.UseGitCookieAuth)
 	d.Set("git_service_name", project.GitServiceName)
 	d.Set("pull_request_mode", project.PullRequestMode)
 	d.Set("validation_required", project.ValidationRequired)
-	d.Set("git_production_branch_name", project.GitProductionBranchName)
+	if m.(*Config).RequireVersion(gitProductionBranchNameSince, "git_production_branch_name") == nil {
+		d.Set("git_production_branch_name", project.GitProductionBranchName)
+	}
 	d.Set("allow_warnings", project.AllowWarnings)
 	d.Set("is_example", project.IsExample)
 	d.Set("git_release_mgmt_enabled", project.GitReleaseMgmtEnabled)
@@ -148,78 +164,79 @@ func resourceProjectGitRepoRead(ctx context.Context, d *schema.ResourceData, m i
 	return diags
 }
 
-func resourceProjectGitRepoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
-	if err != nil {
-		return diagErrAppend(diags, err)
-	}
-	projectName := d.Get("project_id").(string)
-
-	projectGitRepoUpdate := lookergo.Project{}
+// projectGitRepoFromResourceData returns the configured settings of the repository.
+func projectGitRepoFromResourceData(d *schema.ResourceData) *lookergo.WriteProject {
+	p := new(lookergo.WriteProject)
 	if value, ok := d.GetOk("allow_warnings"); ok {
-		projectGitRepoUpdate.AllowWarnings = boolPtr(value.(bool))
+		p.AllowWarnings = lookergo.Value(value.(bool))
 	}
 	if value, ok := d.GetOk("git_remote_url"); ok {
-		projectGitRepoUpdate.GitRemoteUrl = value.(string)
+		p.GitRemoteUrl = lookergo.Value(value.(string))
 	}
 	if value, ok := d.GetOk("git_username"); ok {
-		projectGitRepoUpdate.GitUsername = value.(string)
+		p.GitUsername = lookergo.Value(value.(string))
+	}
+	if value, ok := d.GetOk("git_password"); ok {
+		p.GitPassword = lookergo.Value(value.(string))
 	}
 	if value, ok := d.GetOk("use_git_cookie_auth"); ok {
-		projectGitRepoUpdate.UseGitCookieAuth = boolPtr(value.(bool))
+		p.UseGitCookieAuth = lookergo.Value(value.(bool))
 	}
 	if value, ok := d.GetOk("git_service_name"); ok {
-		projectGitRepoUpdate.GitServiceName = value.(string)
+		p.GitServiceName = lookergo.Value(value.(string))
 	}
 	if value, ok := d.GetOk("pull_request_mode"); ok {
-		projectGitRepoUpdate.PullRequestMode = value.(string)
+		p.PullRequestMode = lookergo.Value(value.(string))
 	}
 	if value, ok := d.GetOk("git_production_branch_name"); ok {
-		projectGitRepoUpdate.GitProductionBranchName = value.(string)
+		p.GitProductionBranchName = lookergo.Value(value.(string))
 	}
 	if value, ok := d.GetOk("validation_required"); ok {
-		projectGitRepoUpdate.ValidationRequired = boolPtr(value.(bool))
+		p.ValidationRequired = lookergo.Value(value.(bool))
 	}
 	if value, ok := d.GetOk("is_example"); ok {
-		projectGitRepoUpdate.IsExample = boolPtr(value.(bool))
+		p.IsExample = lookergo.Value(value.(bool))
 	}
 	if value, ok := d.GetOk("git_release_mgmt_enabled"); ok {
-		projectGitRepoUpdate.GitReleaseMgmtEnabled = boolPtr(value.(bool))
+		p.GitReleaseMgmtEnabled = lookergo.Value(value.(bool))
 	}
 	if value, ok := d.GetOk("deploy_secret"); ok {
-		projectGitRepoUpdate.DeploySecret = value.(string)
+		p.DeploySecret = lookergo.Value(value.(string))
 	}
+	return p
+}
 
-	if value, ok := d.GetOk("git_password"); ok {
-		payload := lookergo.Project{}
-		payload.GitRemoteUrl = projectGitRepoUpdate.GitRemoteUrl
-		projectGitRepoUpdate.GitPassword = value.(string)
+func resourceProjectGitRepoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	dc, err := devClient(ctx, m)
+	if err != nil {
+		return diagErrAppend(diags, err)
+	}
+	projectName := d.Get("project_id").(string)
+
+	projectGitRepoUpdate := projectGitRepoFromResourceData(d)
+	if m.(*Config).RequireVersion(gitProductionBranchNameSince, "git_production_branch_name") != nil {
+		projectGitRepoUpdate.GitProductionBranchName = nil
+	}
+	gitRemoteUrl := d.Get("git_remote_url").(string)
+
+	// The remote and its credentials are set first, as the other settings need a repository.
+	payload := lookergo.WriteProject{GitRemoteUrl: projectGitRepoUpdate.GitRemoteUrl}
+	if _, ok := d.GetOk("git_password"); ok {
 		payload.GitUsername = projectGitRepoUpdate.GitUsername
 		payload.GitPassword = projectGitRepoUpdate.GitPassword
 		payload.GitServiceName = projectGitRepoUpdate.GitServiceName
-		if !strings.HasPrefix(projectGitRepoUpdate.GitRemoteUrl, "https://") {
+		if !strings.HasPrefix(gitRemoteUrl, "https://") {
 			return diag.Errorf("HTTPS Authentication requires URL starts with http://..")
 		}
-		_, _, err = dc.Projects.Update(ctx, projectName, &payload)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	} else {
-		payload := lookergo.Project{}
-		payload.GitRemoteUrl = projectGitRepoUpdate.GitRemoteUrl
-		if !strings.HasPrefix(projectGitRepoUpdate.GitRemoteUrl, "git@") && !strings.HasPrefix(payload.GitRemoteUrl, "ssh://") {
-			return diag.Errorf("SSH Authentication requires URL starts with git@.. or ssh://..")
-		}
-		_, _, err = dc.Projects.Update(ctx, projectName, &payload)
-		if err != nil {
-			return diag.FromErr(err)
-		}
+	} else if !strings.HasPrefix(gitRemoteUrl, "git@") && !strings.HasPrefix(gitRemoteUrl, "ssh://") {
+		return diag.Errorf("SSH Authentication requires URL starts with git@.. or ssh://..")
+	}
+	_, _, err = dc.Projects.Update(ctx, projectName, &payload)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, _, err = dc.Projects.Update(ctx, projectName, &projectGitRepoUpdate)
+	_, _, err = dc.Projects.Update(ctx, projectName, projectGitRepoUpdate)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
@@ -239,62 +256,46 @@ func resourceProjectGitRepoCreate(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(projectName)
 
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
-	return resourceProjectRead(ctx, d, m)
+	return resourceProjectGitRepoRead(ctx, d, m)
 }
 
 func resourceProjectGitRepoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
 	projectName := d.Get("project_id").(string)
 
-	projectGitRepoUpdate := lookergo.Project{}
-	if value, ok := d.GetOk("allow_warnings"); ok {
-		projectGitRepoUpdate.AllowWarnings = boolPtr(value.(bool))
-	}
-	if value, ok := d.GetOk("git_remote_url"); ok {
-		projectGitRepoUpdate.GitRemoteUrl = value.(string)
-	}
-	if value, ok := d.GetOk("git_username"); ok {
-		projectGitRepoUpdate.GitUsername = value.(string)
-	}
-	if value, ok := d.GetOk("use_git_cookie_auth"); ok {
-		projectGitRepoUpdate.UseGitCookieAuth = boolPtr(value.(bool))
-	}
-	if value, ok := d.GetOk("git_service_name"); ok {
-		projectGitRepoUpdate.GitServiceName = value.(string)
-	}
-	if value, ok := d.GetOk("pull_request_mode"); ok {
-		projectGitRepoUpdate.PullRequestMode = value.(string)
-	}
-	if value, ok := d.GetOk("git_production_branch_name"); ok {
-		projectGitRepoUpdate.GitProductionBranchName = value.(string)
-	}
-	if value, ok := d.GetOk("validation_required"); ok {
-		projectGitRepoUpdate.ValidationRequired = boolPtr(value.(bool))
-	}
-	if value, ok := d.GetOk("is_example"); ok {
-		projectGitRepoUpdate.IsExample = boolPtr(value.(bool))
-	}
-	if value, ok := d.GetOk("git_release_mgmt_enabled"); ok {
-		projectGitRepoUpdate.GitReleaseMgmtEnabled = boolPtr(value.(bool))
-	}
-	if value, ok := d.GetOk("deploy_secret"); ok {
-		projectGitRepoUpdate.DeploySecret = value.(string)
-	}
-	if value, ok := d.GetOk("git_password"); ok {
-		projectGitRepoUpdate.GitPassword = value.(string)
-		if !strings.HasPrefix(projectGitRepoUpdate.GitRemoteUrl, "https://") {
+	gitRemoteUrl := d.Get("git_remote_url").(string)
+	if _, ok := d.GetOk("git_password"); ok {
+		if !strings.HasPrefix(gitRemoteUrl, "https://") {
 			return diag.Errorf("HTTPS Authentication requires URL starts with http://..")
 		}
-	} else {
-		if !strings.HasPrefix(projectGitRepoUpdate.GitRemoteUrl, "git@") && !strings.HasPrefix(projectGitRepoUpdate.GitRemoteUrl, "ssh://") {
-			return diag.Errorf("SSH Authentication requires URL starts with git@.. or ssh://..")
-		}
+	} else if !strings.HasPrefix(gitRemoteUrl, "git@") && !strings.HasPrefix(gitRemoteUrl, "ssh://") {
+		return diag.Errorf("SSH Authentication requires URL starts with git@.. or ssh://..")
+	}
+
+	// Only the changed settings are sent, and the ones removed from the configuration are cleared.
+	projectGitRepoUpdate := lookergo.WriteProject{
+		AllowWarnings:           changedValue[bool](d, "allow_warnings"),
+		GitRemoteUrl:            changedValue[string](d, "git_remote_url"),
+		GitUsername:             changedString(d, "git_username"),
+		GitPassword:             changedString(d, "git_password"),
+		UseGitCookieAuth:        changedValue[bool](d, "use_git_cookie_auth"),
+		GitServiceName:          changedString(d, "git_service_name"),
+		PullRequestMode:         changedString(d, "pull_request_mode"),
+		GitProductionBranchName: changedString(d, "git_production_branch_name"),
+		ValidationRequired:      changedValue[bool](d, "validation_required"),
+		IsExample:               changedValue[bool](d, "is_example"),
+		GitReleaseMgmtEnabled:   changedValue[bool](d, "git_release_mgmt_enabled"),
+		DeploySecret:            changedString(d, "deploy_secret"),
+	}
+	if m.(*Config).RequireVersion(gitProductionBranchNameSince, "git_production_branch_name") != nil {
+		projectGitRepoUpdate.GitProductionBranchName = nil
+	}
+	if projectGitRepoUpdate.DeploySecret.IsNull() {
+		projectGitRepoUpdate.DeploySecret = nil
+		projectGitRepoUpdate.UnsetDeploySecret = lookergo.Value(true)
 	}
 
 	_, _, err = dc.Projects.Update(ctx, projectName, &projectGitRepoUpdate)
@@ -322,10 +323,7 @@ func resourceProjectGitRepoUpdate(ctx context.Context, d *schema.ResourceData, m
 
 // resourceProjectGitRepoDelete
 func resourceProjectGitRepoDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	c := m.(*Config).Api // .(*lookergo.Client)
-	dc := m.(*Config).DevClient
-	// Refresh token for dev Api connection if not used before.
-	err := dc.EnsureStaticToken(ctx, c, m.(*Config).ApiUserID)
+	dc, err := devClient(ctx, m)
 	if err != nil {
 		return diagErrAppend(diags, err)
 	}
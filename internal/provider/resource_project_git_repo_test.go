@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccProjectGitRepo(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(pullRequestMode string) string {
+		return testConfig(srv, `
+resource "looker_project" "test" {
+  name = "marketing"
+}
+
+resource "looker_project_git_repo" "test" {
+  project_id        = looker_project.test.id
+  git_remote_url    = "git@github.com:example/marketing.git"
+  git_service_name  = "github"
+  pull_request_mode = "`+pullRequestMode+`"
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Projects, "looker_project"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("off"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Projects, "looker_project_git_repo.test", &id),
+					resource.TestCheckResourceAttr("looker_project_git_repo.test", "git_service_name", "github"),
+					resource.TestCheckResourceAttr("looker_project_git_repo.test", "git_production_branch_name", "main"),
+				),
+			},
+			{
+				Config: config("required"),
+				Check:  resource.TestCheckResourceAttr("looker_project_git_repo.test", "pull_request_mode", "required"),
+			},
+			{
+				ResourceName:      "looker_project_git_repo.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.Projects, &id, map[string]interface{}{"pull_request_mode": "links"}),
+				Config:    config("required"),
+				Check:     resource.TestCheckResourceAttr("looker_project_git_repo.test", "pull_request_mode", "required"),
+			},
+			{
+				PreConfig: func() {
+					c := testClient(t, srv)
+					if _, _, err := c.Sessions.SetWorkspaceId(context.Background(), "dev"); err != nil {
+						t.Fatalf("SetWorkspaceId: %v", err)
+					}
+					if _, err := c.Projects.DeleteGitRepo(context.Background(), id); err != nil {
+						t.Fatalf("DeleteGitRepo: %v", err)
+					}
+				},
+				Config: config("required"),
+				Check:  resource.TestCheckResourceAttr("looker_project_git_repo.test", "git_remote_url", "git@github.com:example/marketing.git"),
+			},
+		},
+	})
+}
+
+func TestAccProjectGitRepo_oldLooker(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Release = "7.20.3"
+	var id string
+
+	config := func(extra string) string {
+		return testConfig(srv, `
+resource "looker_project" "test" {
+  name = "marketing"
+}
+
+resource "looker_project_git_repo" "test" {
+  project_id       = looker_project.test.id
+  git_remote_url   = "git@github.com:example/marketing.git"
+  git_service_name = "github"
+  `+extra+`
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Projects, "looker_project"),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`git_production_branch_name = "release"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`git_production_branch_name is supported only in Looker 21.0 and higher, the\s+instance runs Looker 7.20.3`),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Projects, "looker_project_git_repo.test", &id),
+					func(*terraform.State) error {
+						project, _ := srv.Get(lookertest.Projects, id)
+						if v, ok := project["git_production_branch_name"]; ok {
+							return fmt.Errorf("git_production_branch_name sent to an old Looker: %v", v)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
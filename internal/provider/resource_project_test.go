@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProject(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(name string) string {
+		return testConfig(srv, `
+resource "looker_project" "test" {
+  name = "`+name+`"
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Projects, "looker_project"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("marketing"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Projects, "looker_project.test", &id),
+					resource.TestCheckResourceAttr("looker_project.test", "id", "marketing"),
+				),
+			},
+			{
+				Config: config("marketing_v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Projects, "looker_project.test", &id),
+					resource.TestCheckResourceAttr("looker_project.test", "id", "marketing_v2"),
+					resource.TestCheckResourceAttr("looker_project.test", "name", "marketing_v2"),
+				),
+			},
+			{
+				ResourceName:            "looker_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rename_when_delete"},
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.Projects, &id),
+				Config:    config("marketing_v2"),
+				Check:     testCheckRemote(srv, lookertest.Projects, "looker_project.test", nil),
+			},
+		},
+	})
+}
@@ -2,13 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+
 	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"net/http"
-	"strconv"
 )
 
 func resourceRole() *schema.Resource {
@@ -17,6 +18,12 @@ func resourceRole() *schema.Resource {
 		ReadContext:   resourceRoleRead,
 		UpdateContext: resourceRoleUpdate,
 		DeleteContext: resourceRoleDelete,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    resourceRoleV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceRoleStateUpgradeV0,
+		}},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "Role name",
@@ -25,7 +32,7 @@ func resourceRole() *schema.Resource {
 			},
 			"permission_set_id": {
 				Description:  "PermissionSet ID",
-				Type:         schema.TypeInt,
+				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
 				ExactlyOneOf: []string{"permission_set_id", "permission_set_name"},
@@ -39,7 +46,7 @@ func resourceRole() *schema.Resource {
 			},
 			"model_set_id": {
 				Description: "Modelset ID",
-				Type:        schema.TypeInt,
+				Type:        schema.TypeString,
 				Required:    true,
 			},
 		},
@@ -52,28 +59,19 @@ func resourceRole() *schema.Resource {
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	logTrace(ctx, "query role", "role_id", d.Id())
-	role, response, err := c.Roles.Get(ctx, idAsInt(d.Id()))
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	role, _, err := c.Roles.Get(ctx, lookergo.ID(d.Id()), nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
-	switch e := err.(type) {
-	case *lookergo.ErrorResponse:
-		if errResp := e.Response; errResp.StatusCode == http.StatusNotFound {
-			logTrace(ctx, "role not found", "role_id", d.Id())
-			d.SetId("")
-			return // Resource was not found.
-		}
-	case error:
+	if err != nil {
 		return logErrDiag(ctx, diags, "unable to query role", "role_id", d.Id()) // Connection error.
-	default:
-		logTrace(ctx, "role found", "role", role)
 	}
+	logTrace(ctx, "role found", "role", role)
 
 	d.Set("name", role.Name)
-	d.Set("permission_set_id", role.PermissionSet.Id)
+	d.Set("permission_set_id", role.PermissionSet.Id.String())
 	d.Set("permission_set_name", role.PermissionSet.Name)
-	d.Set("model_set_id", role.ModelSet.Id)
+	d.Set("model_set_id", role.ModelSet.Id.String())
 
 	return diags
 }
@@ -83,14 +81,14 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 	var permissionSet lookergo.PermissionSet
 	if psId, ok := d.GetOk("permission_set_id"); ok {
-		perm, _, err := c.PermissionSets.Get(ctx, strconv.Itoa(psId.(int)))
+		perm, _, err := c.PermissionSets.Get(ctx, lookergo.ID(psId.(string)), nil)
 		if err != nil {
 			return logErrDiag(ctx, diags, "PermissionSet not found", "permission_set_id", psId)
 		}
 		permissionSet.Id = perm.Id
 		permissionSet.Name = perm.Name
 	} else if psName, ok := d.GetOk("permission_set_name"); ok {
-		permissions, _, err := c.PermissionSets.GetByName(ctx, psName.(string), nil)
+		permissions, err := listAll(ctx, nil, c.PermissionSets.GetByName, psName.(string))
 		if err != nil {
 			return logErrDiag(ctx, diags, "Failed to query permission sets", "err", err)
 		}
@@ -102,8 +100,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 		}
 
 	}
-	model_set_id := strconv.Itoa(d.Get("model_set_id").(int))
-	modelSet, _, err := c.ModelSets.Get(ctx, model_set_id)
+	modelSet, _, err := c.ModelSets.Get(ctx, lookergo.ID(d.Get("model_set_id").(string)), nil)
 	if err != nil {
 		return logErrDiag(ctx, diags, "Failed to find ModelSet", "model_set_id", err)
 	}
@@ -124,12 +121,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return logErrDiag(ctx, diags, "Failed to create Role", "err", err)
 	}
-	d.Set("name", newRole.Name)
-	d.Set("permission_set_id", newRole.PermissionSet.Id)
-	d.Set("permission_set_name", newRole.PermissionSet.Name)
-	d.Set("model_set_id", newRole.ModelSet.Id)
-
-	d.SetId(idAsString(newRole.Id))
+	d.SetId(newRole.Id.String())
 
 	return resourceRoleRead(ctx, d, m)
 }
@@ -139,14 +131,15 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
 	var permissionSet lookergo.PermissionSet
-	if psId, ok := d.GetOk("permission_set_id"); ok {
-		perm, _, err := c.PermissionSets.Get(ctx, strconv.Itoa(psId.(int)))
+	// Both attributes are computed, so the one which is not configured still holds the previous value.
+	if psId, ok := d.GetOk("permission_set_id"); ok && !d.HasChange("permission_set_name") {
+		perm, _, err := c.PermissionSets.Get(ctx, lookergo.ID(psId.(string)), nil)
 		if err != nil {
 			return logErrDiag(ctx, diags, "PermissionSet not found", "permission_set_id", psId)
 		}
 		permissionSet.Id = perm.Id
 	} else if psName, ok := d.GetOk("permission_set_name"); ok {
-		permissions, _, err := c.PermissionSets.GetByName(ctx, psName.(string), nil)
+		permissions, err := listAll(ctx, nil, c.PermissionSets.GetByName, psName.(string))
 		if err != nil {
 			return logErrDiag(ctx, diags, "Failed to query permission sets", "err", err)
 		}
@@ -158,18 +151,18 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		}
 	}
 
-	role := lookergo.Role{
-		Name:            d.Get("name").(string),
-		PermissionSetID: permissionSet.Id,
-		ModelSetID:      strconv.Itoa(d.Get("model_set_id").(int)),
+	role := lookergo.WriteRole{
+		Name:       changedValue[string](d, "name"),
+		ModelSetID: lookergo.Value(lookergo.ID(d.Get("model_set_id").(string))),
+	}
+	if permissionSet.Id != "" {
+		role.PermissionSetID = lookergo.Value(permissionSet.Id)
 	}
 
-	newRole, _, err := c.Roles.Update(ctx, idAsInt(d.Id()), &role)
+	newRole, _, err := c.Roles.Update(ctx, lookergo.ID(d.Id()), &role)
 	if err != nil {
 		return logErrDiag(ctx, diags, "Failed to create Role", "err", err)
 	}
-	d.Set("permission_set_id", newRole.PermissionSet.Id)
-	d.Set("permission_set_name", newRole.PermissionSet.Name)
 
 	logTrace(ctx, "updated role", "new_role", newRole)
 
@@ -181,7 +174,7 @@ func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
 
-	_, err := c.Roles.Delete(ctx, idAsInt(d.Id()))
+	_, err := c.Roles.Delete(ctx, lookergo.ID(d.Id()))
 	if err != nil {
 		return logErrDiag(ctx, diags, "failed to delete role", "err", err)
 	}
@@ -189,3 +182,29 @@ func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface
 	d.SetId("") // Finally mark as deleted
 	return diags
 }
+
+// resourceRoleV0 is the schema of looker_role before the ids of the permission and model sets became strings,
+// to hold the opaque ids of newer Looker versions.
+func resourceRoleV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":                {Type: schema.TypeString, Required: true},
+			"permission_set_id":   {Type: schema.TypeInt, Optional: true, Computed: true},
+			"permission_set_name": {Type: schema.TypeString, Optional: true, Computed: true},
+			"model_set_id":        {Type: schema.TypeInt, Required: true},
+		},
+	}
+}
+
+// resourceRoleStateUpgradeV0 turns the numeric ids of the permission and model sets into strings.
+func resourceRoleStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	for _, key := range []string{"permission_set_id", "model_set_id"} {
+		switch v := rawState[key].(type) {
+		case float64:
+			rawState[key] = strconv.FormatInt(int64(v), 10)
+		case json.Number:
+			rawState[key] = v.String()
+		}
+	}
+	return rawState, nil
+}
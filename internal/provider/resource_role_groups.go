@@ -3,8 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"strconv"
 
 	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -15,6 +13,10 @@ import (
 
 func resourceRoleGroups() *schema.Resource {
 	return &schema.Resource{
+		Description: `Manage the groups a role is assigned to.
+Groups assigned to the role outside of Terraform are kept. Importing takes all the groups the role is currently assigned to,
+so they become managed: the ones missing from the configuration are removed from the role on the next apply, and all of them on destroy.
+`,
 		CreateContext: resourceRoleGroupsCreate,
 		ReadContext:   resourceRoleGroupsRead,
 		UpdateContext: resourceRoleGroupsUpdate,
@@ -44,11 +46,32 @@ func resourceRoleGroups() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRoleGroupsImport,
 		},
 	}
 }
 
+// resourceRoleGroupsImport takes the id of the role, and imports all of the groups it is currently assigned to.
+func resourceRoleGroupsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*Config).Api // .(*lookergo.Client)
+
+	roleID := lookergo.ID(d.Id())
+
+	roleMemberGroups, _, err := c.Roles.RoleGroupsList(ctx, roleID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var groupItems []interface{}
+	for _, group := range roleMemberGroups {
+		groupItems = append(groupItems, map[string]interface{}{"id": group.Id.String()})
+	}
+
+	d.Set("role_id", d.Id())
+	d.Set("group", groupItems)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: start", currFuncName()))
@@ -56,19 +79,14 @@ func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m inter
 	roleMemberGroupsSet, ok := d.GetOk("group")
 	if ok {
 		// Fetch and verify existence
-		roleMemberGroups, _, err := c.Roles.RoleGroupsList(ctx, idAsInt(d.Get("role_id")), nil)
-		switch err.(type) {
-		case *lookergo.ErrorResponse:
-			if errResp := err.(*lookergo.ErrorResponse).Response; errResp.StatusCode == http.StatusNotFound {
-				logTrace(ctx, "role not found", "role_id", d.Get("role_id").(string))
-				d.SetId("")
-				return // Resource was not found.
-			}
-		case error:
+		roleMemberGroups, _, err := c.Roles.RoleGroupsList(ctx, lookergo.ID(d.Get("role_id").(string)), nil)
+		if goneFromRemote(ctx, d, err) {
+			return diags
+		}
+		if err != nil {
 			return logErrDiag(ctx, diags, "unable to query role", "role_id", d.Get("role_id").(string)) // Connection error.
-		default:
-			logTrace(ctx, "role group members", "roleMemberGroups", roleMemberGroups)
 		}
+		logTrace(ctx, "role group members", "roleMemberGroups", roleMemberGroups)
 
 		// Flatten
 		var groupItems []interface{}
@@ -76,13 +94,13 @@ func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m inter
 			obj := raw.(map[string]interface{})
 
 			for _, group := range roleMemberGroups {
-				if idAsString(group.Id) == obj["id"].(string) {
-					groupItems = append(groupItems, map[string]interface{}{"id": idAsString(group.Id), "name": group.Name})
+				if group.Id.String() == obj["id"].(string) {
+					groupItems = append(groupItems, map[string]interface{}{"id": group.Id.String(), "name": group.Name})
 				}
 			}
 		}
 		d.Set("group", groupItems)
-		d.SetId("-")
+		d.SetId(d.Get("role_id").(string))
 	} else {
 		d.SetId("")
 	}
@@ -97,39 +115,33 @@ func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, m int
 	managedGroupIds := getSetIds(d, "group")
 
 	var unmanagedGroupIds []string
-	role_id, err := strconv.Atoi(d.Get("role_id").(string))
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	role_id := lookergo.ID(d.Get("role_id").(string))
 	roleMemberGroups, _, err := c.Roles.RoleGroupsList(ctx, role_id, nil)
 	if err == nil {
 		for _, group := range roleMemberGroups {
-			unmanagedGroupIds = append(unmanagedGroupIds, idAsString(group.Id))
+			unmanagedGroupIds = append(unmanagedGroupIds, group.Id.String())
 		}
 	}
 
 	groupIds := append(managedGroupIds, unmanagedGroupIds...)
 	slices.Sort(groupIds)
 	groupIds = slices.Compact(groupIds)
-	if _, _, err = c.Roles.RoleGroupsSet(ctx, role_id, groupIds); err != nil {
+	if _, _, err = c.Roles.RoleGroupsSet(ctx, role_id, lookergo.IDsFromStrings(groupIds)); err != nil {
 		return logErrDiag(ctx, diags, "Failed to update Role member Groups", "err", err)
 	}
 
-	d.SetId("-")
+	d.SetId(d.Get("role_id").(string))
 	return resourceRoleGroupsRead(ctx, d, m)
 }
 
 func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	role_id, err := strconv.Atoi(d.Get("role_id").(string))
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	role_id := lookergo.ID(d.Get("role_id").(string))
 	var currentGroupIds []string
 	roleMemberGroups, _, err := c.Roles.RoleGroupsList(ctx, role_id, nil)
 	if err == nil {
 		for _, group := range roleMemberGroups {
-			currentGroupIds = append(currentGroupIds, idAsString(group.Id))
+			currentGroupIds = append(currentGroupIds, group.Id.String())
 		}
 	}
 
@@ -143,12 +155,12 @@ func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, m int
 	slices.Sort(finalTemp)
 	finalIds := slices.Compact(finalTemp)
 
-	_, _, err = c.Roles.RoleGroupsSet(ctx, role_id, finalIds)
+	_, _, err = c.Roles.RoleGroupsSet(ctx, role_id, lookergo.IDsFromStrings(finalIds))
 	if err != nil {
 		return logErrDiag(ctx, diags, "Failed to update Role member Groups", "err", err)
 	}
 
-	d.SetId("-")
+	d.SetId(d.Get("role_id").(string))
 
 	tflog.Trace(ctx, fmt.Sprintf("Fn: %v, Action: end", currFuncName()))
 	return resourceRoleGroupsRead(ctx, d, m)
@@ -156,27 +168,23 @@ func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 func resourceRoleGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	role_id, err := strconv.Atoi(d.Get("role_id").(string))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	var currentGroupIds []string
+	role_id := lookergo.ID(d.Get("role_id").(string))
+	// Without the current groups, the ones assigned outside of Terraform would be removed as well.
 	roleMemberGroups, _, err := c.Roles.RoleGroupsList(ctx, role_id, nil)
-	if err == nil {
-		for _, group := range roleMemberGroups {
-			currentGroupIds = append(currentGroupIds, idAsString(group.Id))
-		}
+	if err != nil {
+		return logErrDiag(ctx, diags, "Failed to list Role member Groups", "err", err)
 	}
 
-	_, _, oldIds, _ := getSetChangeIdsDiff(d, "group")
+	// On destroy the group set has no change, so the groups to remove are the ones in the state.
+	managedGroupIds := getSetIds(d, "group")
 	var finalIds []string
-	for _, id := range currentGroupIds {
-		if !slices.Contains(oldIds, id) {
-			finalIds = append(finalIds, id)
+	for _, group := range roleMemberGroups {
+		if !slices.Contains(managedGroupIds, group.Id.String()) {
+			finalIds = append(finalIds, group.Id.String())
 		}
 	}
 
-	_, _, err = c.Roles.RoleGroupsSet(ctx, role_id, finalIds)
+	_, _, err = c.Roles.RoleGroupsSet(ctx, role_id, lookergo.IDsFromStrings(finalIds))
 	if err != nil {
 		return logErrDiag(ctx, diags, "Failed to update Role member Groups", "err", err)
 	}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRoleGroups(t *testing.T) {
+	srv := newTestServer(t)
+	c := testClient(t, srv)
+	var roleID string
+
+	objects := `
+resource "looker_role" "test" {
+  name                = "Marketing admin"
+  permission_set_name = "Admin"
+  model_set_id        = ` + lookertest.AllModelSetID + `
+}
+
+resource "looker_group" "marketing" {
+  name = "Marketing"
+}
+
+resource "looker_group" "sales" {
+  name = "Sales"
+}
+`
+	config := func(groups string) string {
+		return testConfig(srv, objects+`
+resource "looker_role_groups" "test" {
+  role_id = looker_role.test.id
+`+groups+`
+}
+`)
+	}
+	initial := config(`
+  group {
+    id = looker_group.marketing.id
+  }
+`)
+	updated := config(`
+  group {
+    id = looker_group.marketing.id
+  }
+  group {
+    id = looker_group.sales.id
+  }
+`)
+
+	testCheckGroups := func(want int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			roleID = s.RootModule().Resources["looker_role_groups.test"].Primary.ID
+			groups, _, err := c.Roles.RoleGroupsList(context.Background(), lookergo.ID(roleID), nil)
+			if err != nil {
+				return err
+			}
+			if len(groups) != want {
+				return fmt.Errorf("role %s has %d groups, expected %d", roleID, len(groups), want)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Roles, "looker_role"),
+		Steps: []resource.TestStep{
+			{
+				Config: initial,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckGroups(1),
+					resource.TestCheckResourceAttrPair("looker_role_groups.test", "id", "looker_role.test", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_role_groups.test", "group.*", map[string]string{"name": "Marketing"}),
+				),
+			},
+			{
+				Config: updated,
+				Check:  testCheckGroups(2),
+			},
+			{
+				ResourceName:      "looker_role_groups.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Groups assigned outside of Terraform are left alone.
+				PreConfig: func() {
+					if _, _, err := c.Roles.RoleGroupsSet(context.Background(), lookergo.ID(roleID), []lookergo.ID{lookertest.AllUsersGroupID}); err != nil {
+						t.Fatalf("RoleGroupsSet: %v", err)
+					}
+				},
+				Config: updated,
+				Check:  testCheckGroups(3),
+			},
+			{
+				Config: initial,
+				Check:  testCheckGroups(2),
+			},
+			{
+				// Destroying the resource removes its groups, and leaves the others alone.
+				Config: testConfig(srv, objects),
+				Check:  testCheckRoleGroups(c, &roleID, "All Users"),
+			},
+		},
+	})
+}
+
+func TestAccRoleGroups_destroyLastGroup(t *testing.T) {
+	srv := newTestServer(t)
+	c := testClient(t, srv)
+	var roleID string
+
+	objects := `
+resource "looker_role" "test" {
+  name                = "Marketing admin"
+  permission_set_name = "Admin"
+  model_set_id        = ` + lookertest.AllModelSetID + `
+}
+
+resource "looker_group" "marketing" {
+  name = "Marketing"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Roles, "looker_role"),
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(srv, objects+`
+resource "looker_role_groups" "test" {
+  role_id = looker_role.test.id
+  group {
+    id = looker_group.marketing.id
+  }
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("looker_role_groups.test", "id", func(v string) error {
+						roleID = v
+						return nil
+					}),
+					testCheckRoleGroups(c, &roleID, "Marketing"),
+				),
+			},
+			{
+				// The role is left with no group.
+				Config: testConfig(srv, objects),
+				Check:  testCheckRoleGroups(c, &roleID),
+			},
+		},
+	})
+}
+
+// testCheckRoleGroups checks that the role *roleID is assigned to exactly the groups of the given names.
+func testCheckRoleGroups(c *lookergo.Client, roleID *string, names ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		groups, _, err := c.Roles.RoleGroupsList(context.Background(), lookergo.ID(*roleID), nil)
+		if err != nil {
+			return err
+		}
+		var got []string
+		for _, g := range groups {
+			got = append(got, g.Name)
+		}
+		if !reflect.DeepEqual(got, names) {
+			return fmt.Errorf("role %s has groups %q, expected %q", *roleID, got, names)
+		}
+		return nil
+	}
+}
+
+func TestResourceRoleGroupsRead_unpaged(t *testing.T) {
+	// Exactly one default page of groups, which the API serves again whatever the offset.
+	c, requests := newUnpagedClient(t, map[string]string{"roles/1/groups": testRows(100)})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	d := schema.TestResourceDataRaw(t, resourceRoleGroups().Schema, map[string]interface{}{
+		"role_id": "1",
+		"group":   []interface{}{map[string]interface{}{"id": "100"}},
+	})
+	if diags := resourceRoleGroupsRead(ctx, d, &Config{Api: c}); diags.HasError() {
+		t.Fatalf("resourceRoleGroupsRead: %v", diags)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("resourceRoleGroupsRead sent %d requests, expected 1", n)
+	}
+	if n := d.Get("group.#").(int); n != 1 {
+		t.Errorf("group.# = %d, expected 1", n)
+	}
+}
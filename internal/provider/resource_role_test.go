@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRole(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(role string) string {
+		return testConfig(srv, `
+resource "looker_permission_set" "test" {
+  name        = "Viewer"
+  permissions = ["access_data", "see_looks"]
+}
+
+resource "looker_model_set" "test" {
+  name   = "Marketing"
+  models = ["marketing"]
+}
+`+role)
+	}
+	byID := config(`
+resource "looker_role" "test" {
+  name              = "Marketing viewer"
+  permission_set_id = looker_permission_set.test.id
+  model_set_id      = looker_model_set.test.id
+}
+`)
+	byName := config(`
+resource "looker_role" "test" {
+  name                = "Marketing admin"
+  permission_set_name = "Admin"
+  model_set_id        = looker_model_set.test.id
+}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Roles, "looker_role"),
+		Steps: []resource.TestStep{
+			{
+				Config: byID,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Roles, "looker_role.test", &id),
+					resource.TestCheckResourceAttr("looker_role.test", "name", "Marketing viewer"),
+					resource.TestCheckResourceAttrPair("looker_role.test", "permission_set_id", "looker_permission_set.test", "id"),
+					resource.TestCheckResourceAttr("looker_role.test", "permission_set_name", "Viewer"),
+					resource.TestCheckResourceAttrPair("looker_role.test", "model_set_id", "looker_model_set.test", "id"),
+				),
+			},
+			{
+				Config: byName,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_role.test", "name", "Marketing admin"),
+					resource.TestCheckResourceAttr("looker_role.test", "permission_set_id", lookertest.AdminPermissionSetID),
+				),
+			},
+			{
+				ResourceName:      "looker_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.Roles, &id, map[string]interface{}{"name": "Changed"}),
+				Config:    byName,
+				Check:     resource.TestCheckResourceAttr("looker_role.test", "name", "Marketing admin"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.Roles, &id),
+				Config:    byName,
+				Check:     testCheckRemote(srv, lookertest.Roles, "looker_role.test", nil),
+			},
+		},
+	})
+}
+
+func TestResourceRoleStateUpgradeV0(t *testing.T) {
+	state := map[string]interface{}{
+		"id":                  "7",
+		"name":                "Marketing viewer",
+		"permission_set_id":   float64(2),
+		"permission_set_name": "Viewer",
+		"model_set_id":        float64(3),
+	}
+	got, err := resourceRoleStateUpgradeV0(context.Background(), state, nil)
+	if err != nil {
+		t.Fatalf("resourceRoleStateUpgradeV0 returned error: %v", err)
+	}
+	want := map[string]interface{}{
+		"id":                  "7",
+		"name":                "Marketing viewer",
+		"permission_set_id":   "2",
+		"permission_set_name": "Viewer",
+		"model_set_id":        "3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resourceRoleStateUpgradeV0 = %v, expected %v", got, want)
+	}
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"strconv"
 	"strings"
 	"time"
 
@@ -83,7 +82,7 @@ func resourceUser() *schema.Resource {
 }
 
 func checkUserAlreadyExists(ctx context.Context, d *schema.ResourceData, c *lookergo.Client, email string) (lookergo.User, error) {
-	users, _, err := c.Users.ListByEmail(ctx, email, &lookergo.ListOptions{})
+	users, err := listAll(ctx, nil, c.Users.ListByEmail, email)
 	if err != nil {
 		return lookergo.User{}, err
 	}
@@ -117,7 +116,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 				return diag.FromErr(err)
 			}
 			if user.Id != "" {
-				d.SetId(user.Id)
+				d.SetId(user.Id.String())
 				resourceUserRead(ctx, d, m)
 				return diags
 			}
@@ -149,19 +148,13 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 	roles := d.Get("roles").(*schema.Set)
 	var newRoles []lookergo.Role
 	if roles.Len() >= 1 {
-		var r []string
-		for _, role := range roles.List() {
-			i, _ := strconv.Atoi(role.(string))
-			r = append(r, idAsString(i))
-		}
-
-		newRoles, _, err = c.Users.SetRoles(ctx, newUser.Id, r)
+		newRoles, _, err = c.Users.SetRoles(ctx, newUser.Id, lookergo.IDsFromStrings(schemaSetToStringSlice(roles)))
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
-	d.SetId(newUser.Id)
+	d.SetId(newUser.Id.String())
 
 	resourceUserRead(ctx, d, m)
 	tflog.Info(ctx, "Created Looker user", map[string]interface{}{"user": newUser, "email": newEmail, "roles": newRoles})
@@ -173,7 +166,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	c := m.(*Config).Api // .(*lookergo.Client)
 	var diags diag.Diagnostics
 	if d.Get("already_exists_ok") == true {
-		user, _, err := c.Users.Get(ctx, d.Id())
+		user, _, err := c.Users.Get(ctx, lookergo.ID(d.Id()), nil)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -186,14 +179,13 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		}
 		d.Set("first_name", user.FirstName)
 		d.Set("last_name", user.LastName)
-		d.Set("roles", user.RoleIds.ToSliceOfStrings())
+		d.Set("roles", user.RoleIds.Strings())
 		return diags
 	}
-	userID := d.Id()
+	userID := lookergo.ID(d.Id())
 
-	user, response, err := c.Users.Get(ctx, userID)
-	if response.StatusCode == 404 {
-		d.SetId("") // Mark as deleted
+	user, _, err := c.Users.Get(ctx, userID, nil)
+	if goneFromRemote(ctx, d, err) {
 		return diags
 	}
 	if err != nil {
@@ -210,7 +202,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.FromErr(err)
 	}
 	if user.RoleIds != nil {
-		err = d.Set("roles", user.RoleIds.ToSliceOfStrings())
+		err = d.Set("roles", user.RoleIds.Strings())
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -230,17 +222,18 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Config).Api // .(*lookergo.Client)
-	userID := d.Id()
+	userID := lookergo.ID(d.Id())
 
-	userOptions, _, err := c.Users.Get(ctx, userID)
+	userOptions, _, err := c.Users.Get(ctx, userID, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	userOptions.LastName = d.Get("last_name").(string)
-	userOptions.FirstName = d.Get("first_name").(string)
-
-	_, _, err = c.Users.Update(ctx, userID, userOptions)
+	userUpdate := lookergo.WriteUser{
+		FirstName: changedString(d, "first_name"),
+		LastName:  changedString(d, "last_name"),
+	}
+	_, _, err = c.Users.Update(ctx, userID, &userUpdate)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -253,7 +246,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 			}
 		} else {
 			if userOptions.CredentialsEmail != nil {
-				emailOptions := lookergo.CredentialsEmail{Email: d.Get("email").(string)}
+				emailOptions := lookergo.WriteCredentialsEmail{Email: lookergo.Value(d.Get("email").(string))}
 				_, _, err := c.Users.UpdateEmail(ctx, userID, &emailOptions)
 				if err != nil {
 					return diag.FromErr(err)
@@ -273,7 +266,7 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	userID := d.Id()
+	userID := lookergo.ID(d.Id())
 
 	if d.Get("delete_on_destroy") == true {
 		_, err := c.Users.Delete(ctx, userID)
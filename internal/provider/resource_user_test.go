@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUser(t *testing.T) {
+	srv := newTestServer(t)
+	var id string
+
+	config := func(lastName, email string) string {
+		return testConfig(srv, `
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = "`+lastName+`"
+  email      = "`+email+`"
+  roles      = ["`+lookertest.AdminRoleID+`"]
+}
+`)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckDestroyed(srv, lookertest.Users, "looker_user"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Doe", "jane@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Users, "looker_user.test", &id),
+					resource.TestCheckResourceAttr("looker_user.test", "first_name", "Jane"),
+					resource.TestCheckResourceAttr("looker_user.test", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("looker_user.test", "roles.#", "1"),
+				),
+			},
+			{
+				Config: config("Smith", "jane.smith@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user.test", "last_name", "Smith"),
+					resource.TestCheckResourceAttr("looker_user.test", "email", "jane.smith@example.com"),
+				),
+			},
+			{
+				ResourceName:            "looker_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"already_exists_ok", "delete_on_destroy", "last_updated"},
+			},
+			{
+				PreConfig: testChangeRemote(t, srv, lookertest.Users, &id, map[string]interface{}{"last_name": "Changed"}),
+				Config:    config("Smith", "jane.smith@example.com"),
+				Check:     resource.TestCheckResourceAttr("looker_user.test", "last_name", "Smith"),
+			},
+			{
+				PreConfig: testRemoveRemote(t, srv, lookertest.Users, &id),
+				Config:    config("Smith", "jane.smith@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRemote(srv, lookertest.Users, "looker_user.test", nil),
+					resource.TestCheckResourceAttr("looker_user.test", "email", "jane.smith@example.com"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// tracerName is the name of the tracer of the spans of the resources and data sources.
+const tracerName = "github.com/devoteamgcloud/terraform-provider-looker/internal/provider"
+
+// Values of the tracing attribute.
+const (
+	tracingStdout = "stdout"
+	tracingOTLP   = "otlp"
+)
+
+// newTracerProvider returns a tracer provider exporting the spans to the standard output, or to the OTLP/HTTP
+// collector listening at endpoint, e.g. http://localhost:4318.
+func newTracerProvider(ctx context.Context, exporter, endpoint, version string) (*sdktrace.TracerProvider, error) {
+	var exp sdktrace.SpanExporter
+	switch exporter {
+	case tracingStdout:
+		stdout, err := stdouttrace.New()
+		if err != nil {
+			return nil, err
+		}
+		exp = stdout
+	case tracingOTLP:
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("the endpoint %q is not an absolute http(s) URL", endpoint)
+		}
+		otlp, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"))
+		if err != nil {
+			return nil, err
+		}
+		exp = otlp
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", exporter)
+	}
+
+	res := sdkresource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String("terraform-provider-looker"),
+		semconv.ServiceVersionKey.String(version),
+	)
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(res)), nil
+}
+
+// traceResource makes the functions of r run in spans named after name, e.g. "looker_group create", when the
+// provider is traced. The spans of the API calls of the functions are their children.
+func traceResource(name string, r *schema.Resource) {
+	r.CreateContext = traced(name+" create", r.CreateContext)
+	r.ReadContext = traced(name+" read", r.ReadContext)
+	r.UpdateContext = traced(name+" update", r.UpdateContext)
+	r.DeleteContext = traced(name+" delete", r.DeleteContext)
+}
+
+// traced returns f, running in a span named name if the provider is traced.
+func traced[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](name string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		config, ok := m.(*Config)
+		if !ok || config.Tracer == nil {
+			return f(ctx, d, m)
+		}
+
+		ctx, span := config.Tracer.Start(ctx, name)
+		defer span.End()
+		diags := f(ctx, d, m)
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				span.SetStatus(codes.Error, d.Summary)
+				break
+			}
+		}
+		return diags
+	}
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/devoteamgcloud/terraform-provider-looker/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
@@ -29,4 +32,9 @@ func main() {
 
 		ProviderFunc: provider.New(version),
 	})
+
+	// Terraform asks the plugin to stop once done with it, and kills it shortly after.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	provider.Shutdown(ctx)
 }
@@ -6,7 +6,7 @@ import (
 )
 
 func TestAcptGroups_ListGroups(t *testing.T) {
-	setup()
+	client := setup(t)
 
 	groups, _, err := client.Groups.List(ctx, nil)
 	if err != nil {
@@ -17,9 +17,9 @@ func TestAcptGroups_ListGroups(t *testing.T) {
 }
 
 func TestAcptGroups_GetGroup(t *testing.T) {
-	setup()
+	client := setup(t)
 
-	groups, _, err := client.Groups.Get(ctx, 1)
+	groups, _, err := client.Groups.Get(ctx, "1", nil)
 	if err != nil {
 		t.Errorf("Groups.List returned error: %v", err)
 	}
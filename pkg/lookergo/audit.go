@@ -0,0 +1,147 @@
+package lookergo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/internal/redact"
+)
+
+// AuditRecord is a line of an AuditLog: a request which may have changed the instance, and the status it was answered,
+// or 0 and the error if it got no response.
+type AuditRecord struct {
+	Time      time.Time       `json:"time"`
+	Method    string          `json:"method"`
+	Path      string          `json:"path"`
+	Status    int             `json:"status"`
+	Error     string          `json:"error,omitempty"`
+	UserID    ID              `json:"user_id,omitempty"`
+	Workspace string          `json:"workspace,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	Body      json.RawMessage `json:"body,omitempty"`
+}
+
+// AuditLog writes an AuditRecord, as a line of JSON, for every POST, PATCH, PUT and DELETE sent to the API, whether it
+// was answered or not: a request which timed out may still have changed the instance. The logins, logouts and
+// workspace switches of the client, which only change its session, are not recorded. The secrets of the bodies are
+// redacted as by a LoggingTransport.
+//
+// Record is meant to be called by a RequestCompletionCallback, and RecordError by a RequestErrorCallback. AuditLog is
+// safe for concurrent use.
+type AuditLog struct {
+	mu     sync.Mutex
+	w      io.Writer
+	userID ID
+	redact *redact.Redactor
+
+	// now is replaced by the tests.
+	now func() time.Time
+}
+
+// NewAuditLog returns an audit log writing to w, redacting the given fields on top of DefaultRedactFields.
+func NewAuditLog(w io.Writer, redactFields ...string) *AuditLog {
+	return &AuditLog{w: w, redact: newRedactor(nil, redactFields), now: time.Now}
+}
+
+// SetUserID sets the ID of the user the requests are made as, which is recorded with them.
+func (l *AuditLog) SetUserID(id ID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.userID = id
+}
+
+// Record writes the record of req, answered by resp, if it may have changed the instance. The other requests are
+// ignored.
+func (l *AuditLog) Record(req *http.Request, resp *http.Response) error {
+	// Do passes its context to the request of the response only.
+	ctx := req.Context()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	return l.record(ctx, req, AuditRecord{Status: resp.StatusCode, RequestID: resp.Header.Get("X-Request-Id")})
+}
+
+// RecordError writes the record of req, which got no response because of err, if it may have changed the instance.
+// The other requests are ignored.
+func (l *AuditLog) RecordError(req *http.Request, err error) error {
+	return l.record(req.Context(), req, AuditRecord{Error: err.Error()})
+}
+
+// record completes r with req, sent with ctx, and writes it if req may have changed the instance.
+func (l *AuditLog) record(ctx context.Context, req *http.Request, r AuditRecord) error {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+	default:
+		return nil
+	}
+	if ok, _ := ctx.Value(sessionRequestCtxKey{}).(bool); ok {
+		return nil
+	}
+
+	r.Method = req.Method
+	r.Path = req.URL.Path
+	r.Workspace = requestWorkspace(ctx)
+	body, err := l.body(req)
+	if err != nil {
+		return err
+	}
+	r.Body = body
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r.Time = l.now().UTC()
+	r.UserID = l.userID
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	// A single write, so the lines of processes appending to the same file are not interleaved.
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// body returns the redacted body of req, as JSON. The body itself was consumed by the request: it is read again
+// through GetBody.
+func (l *AuditLog) body(req *http.Request) (json.RawMessage, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	s := l.redact.Body(req.Header.Get("Content-Type"), b)
+	if json.Valid([]byte(s)) {
+		return json.RawMessage(s), nil
+	}
+	return json.Marshal(s)
+}
+
+type workspaceCtxKey struct{}
+
+// withRequestWorkspace records, in the context of a request, the workspace of the client sending it.
+func withRequestWorkspace(ctx context.Context, workspace string) context.Context {
+	return context.WithValue(ctx, workspaceCtxKey{}, workspace)
+}
+
+// requestWorkspace returns the workspace recorded by withRequestWorkspace.
+func requestWorkspace(ctx context.Context) string {
+	w, _ := ctx.Value(workspaceCtxKey{}).(string)
+	return w
+}
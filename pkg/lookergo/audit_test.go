@@ -0,0 +1,155 @@
+package lookergo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+func TestAuditLog(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	audit := NewAuditLog(&buf, "first_name")
+	audit.now = func() time.Time { return time.Date(2022, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600)) }
+	audit.SetUserID(lookertest.AdminUserID)
+	c, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret), WithLimiter(nil),
+		WithRequestCompletionCallback(func(req *http.Request, resp *http.Response) {
+			if err := audit.Record(req, resp); err != nil {
+				t.Errorf("Record returned error: %v", err)
+			}
+		}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	group, _, err := c.Groups.Create(ctx, &Group{Name: "Analysts"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	if _, _, err := c.Groups.Get(ctx, group.Id, nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	req, _ := c.newAPIRequest(ctx, http.MethodPost, "users", map[string]string{"first_name": "Ada", "password": "hunter2", "last_name": "Lovelace"})
+	if _, err := c.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if _, err := c.Groups.Delete(ctx, "404"); err == nil {
+		t.Fatal("Groups.Delete of an unknown group succeeded")
+	}
+
+	// The login and the workspace switch of the session are not recorded, its changes are.
+	session := NewWorkspaceSession(c, "dev")
+	dev, err := session.Client(ctx)
+	if err != nil {
+		t.Fatalf("WorkspaceSession.Client returned error: %v", err)
+	}
+	if _, _, err := dev.Projects.Create(ctx, &Project{Name: "marketing"}); err != nil {
+		t.Fatalf("Projects.Create returned error: %v", err)
+	}
+	if err := session.Close(ctx); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("line %q is not a record: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+	expected := []struct {
+		method, path, workspace string
+		status                  int
+	}{
+		{"POST", "/api/4.0/groups", "production", 200},
+		{"POST", "/api/4.0/users", "production", 200},
+		{"DELETE", "/api/4.0/groups/404", "production", 404},
+		{"POST", "/api/4.0/projects", "dev", 200},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("%d records, expected %d:\n%s", len(records), len(expected), buf.String())
+	}
+	for i, e := range expected {
+		r := records[i]
+		if r.Method != e.method || r.Path != e.path || r.Workspace != e.workspace || r.Status != e.status {
+			t.Errorf("record %d is %s %s in %q: %d, expected %s %s in %q: %d", i, r.Method, r.Path, r.Workspace, r.Status,
+				e.method, e.path, e.workspace, e.status)
+		}
+		if r.UserID != lookertest.AdminUserID {
+			t.Errorf("record %d has user %q, expected %q", i, r.UserID, lookertest.AdminUserID)
+		}
+		if !r.Time.Equal(time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)) || r.Time.Location() != time.UTC {
+			t.Errorf("record %d has time %v, expected 2022-05-01 10:00 UTC", i, r.Time)
+		}
+	}
+	if body := string(records[0].Body); !strings.Contains(body, `"name":"Analysts"`) {
+		t.Errorf("body of the group is %s", body)
+	}
+	body := string(records[1].Body)
+	if strings.Contains(body, "hunter2") || strings.Contains(body, "Ada") {
+		t.Errorf("body of the user is not redacted: %s", body)
+	}
+	if !strings.Contains(body, `"last_name":"Lovelace"`) {
+		t.Errorf("body of the user is %s", body)
+	}
+	if records[2].Body != nil {
+		t.Errorf("body of the deletion is %s, expected none", records[2].Body)
+	}
+}
+
+func TestAuditLog_noResponse(t *testing.T) {
+	// The connection is lost after the request was received, so it may have been applied.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack returned error: %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	audit := NewAuditLog(&buf)
+	c, err := New(WithBaseURL(srv.URL+"/api/"), WithLimiter(nil), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithRequestErrorCallback(func(req *http.Request, err error) {
+			if err := audit.RecordError(req, err); err != nil {
+				t.Errorf("RecordError returned error: %v", err)
+			}
+		}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if _, _, err := c.Groups.Create(ctx, &Group{Name: "Analysts"}); err == nil {
+		t.Fatal("Groups.Create succeeded on a lost connection")
+	}
+	if _, _, err := c.Groups.Get(ctx, "1", nil); err == nil {
+		t.Fatal("Groups.Get succeeded on a lost connection")
+	}
+
+	var r AuditRecord
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatalf("log %q is not a single record: %v", buf.String(), err)
+	}
+	if r.Method != "POST" || r.Path != "/api/4.0/groups" || r.Workspace != "production" || r.Status != 0 {
+		t.Errorf("record is %s %s in %q: %d, expected POST /api/4.0/groups in \"production\": 0", r.Method, r.Path, r.Workspace, r.Status)
+	}
+	if r.Error == "" {
+		t.Error("record has no error")
+	}
+	if body := string(r.Body); !strings.Contains(body, `"name":"Analysts"`) {
+		t.Errorf("body of the group is %s", body)
+	}
+}
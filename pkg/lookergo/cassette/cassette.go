@@ -0,0 +1,81 @@
+// Package cassette provides an http.RoundTripper recording the exchanges of a lookergo.Client with a Looker
+// instance to a JSON fixture, and replaying them later without network or credentials.
+//
+//	rec, err := cassette.New("testdata/groups.json", cassette.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client, err := lookergo.New(
+//		lookergo.WithBaseURL("https://example.cloud.looker.com/api/"),
+//		lookergo.WithOAuthCredentials("id", "secret"),
+//		lookergo.WithTransport(rec),
+//	)
+//
+// Credentials never reach the fixtures: authentication headers and cookies, and the client_secret,
+// password, certificate and token fields of request and response bodies, are scrubbed when recording. So is the
+// host of the instance: the URL of a fixture has none, and the Link header and url fields are scrubbed.
+// Paginated listings still replay, from the X-Total-Count header.
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Cassette is the content of a fixture: the recorded exchanges, in the order they happened.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is one request with its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. The URL is kept without scheme and host, so that fixtures recorded against
+// one instance replay against any base URL.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads the fixture at path.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(Cassette)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the fixture to path, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,205 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Mode tells whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the fixture, without network. A request which was not recorded fails.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the instance and records the exchanges, replacing the fixture on Stop.
+	ModeRecord
+)
+
+// ErrNoInteraction is returned in replay mode for requests the fixture has no response for.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction")
+
+// Matcher reports whether the recorded request rec answers r. The URL of rec has no scheme nor host, and
+// both are scrubbed the same way.
+type Matcher func(r *Request, rec *Request) bool
+
+// DefaultMatcher matches requests with the same method and URL, including the query.
+func DefaultMatcher(r *Request, rec *Request) bool {
+	return r.Method == rec.Method && r.URL == rec.URL
+}
+
+// Recorder is an http.RoundTripper recording to or replaying from a fixture.
+type Recorder struct {
+	// Transport sends the requests in record mode. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// Matcher selects recorded requests in replay mode. Defaults to DefaultMatcher.
+	Matcher Matcher
+	// ScrubHeaders and ScrubFields list what is scrubbed when recording. They default to DefaultScrubHeaders
+	// and DefaultScrubFields, and can be extended before the first request.
+	ScrubHeaders []string
+	ScrubFields  []string
+
+	path     string
+	mode     Mode
+	mu       sync.Mutex
+	cassette *Cassette
+	// Interactions already replayed
+	used     []bool
+	scrubber *scrubber
+}
+
+var _ http.RoundTripper = &Recorder{}
+
+// New returns a recorder for the fixture at path. In replay mode the fixture must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		ScrubHeaders: append([]string(nil), DefaultScrubHeaders...),
+		ScrubFields:  append([]string(nil), DefaultScrubFields...),
+		path:         path,
+		mode:         mode,
+		cassette:     new(Cassette),
+	}
+
+	switch mode {
+	case ModeReplay:
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	case ModeRecord:
+	default:
+		return nil, fmt.Errorf("cassette: unknown mode %d", mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Stop saves the fixture in record mode. In replay mode it does nothing.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	if r.scrubber == nil {
+		r.scrubber = newScrubber(r.ScrubHeaders, r.ScrubFields)
+	}
+	r.mu.Unlock()
+
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		// The request of the caller must not be modified.
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(strings.NewReader(body))
+	}
+	recReq := Request{
+		Method:  req.Method,
+		URL:     r.scrubber.url(req.URL),
+		Headers: r.scrubber.Header(req.Header),
+		Body:    r.scrubber.body(req.Header.Get("Content-Type"), body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, &recReq)
+	}
+	return r.record(req, &recReq)
+}
+
+func (r *Recorder) replay(req *http.Request, recReq *Request) (*http.Response, error) {
+	match := r.Matcher
+	if match == nil {
+		match = DefaultMatcher
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !match(recReq, &in.Request) {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s in %s", ErrNoInteraction, recReq.Method, recReq.URL, r.path)
+}
+
+func (r *Recorder) record(req *http.Request, recReq *Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(strings.NewReader(body))
+
+	header := resp.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	in := &Interaction{
+		Request: *recReq,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubber.Header(header),
+			Body:       r.scrubber.body(header.Get("Content-Type"), body),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// readBody reads and closes rc.
+func readBody(rc io.ReadCloser) (string, error) {
+	if rc == nil || rc == http.NoBody {
+		return "", nil
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	return string(b), err
+}
+
+// Exists reports whether the fixture at path exists, e.g. to skip tests which were never recorded.
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
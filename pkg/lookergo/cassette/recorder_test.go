@@ -0,0 +1,144 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/cassette"
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+var ctx = context.Background()
+
+func newClient(t *testing.T, baseURL, clientID, clientSecret string, rec *cassette.Recorder) *lookergo.Client {
+	t.Helper()
+
+	c, err := lookergo.New(
+		lookergo.WithBaseURL(baseURL),
+		lookergo.WithOAuthCredentials(clientID, clientSecret),
+		lookergo.WithTransport(rec),
+		lookergo.WithLimiter(nil),
+		lookergo.WithRetryPolicy(lookergo.RetryPolicy{MaxAttempts: 1}),
+	)
+	if err != nil {
+		t.Fatalf("lookergo.New returned error: %v", err)
+	}
+	return c
+}
+
+// exercise runs the same calls against a recording and a replaying client.
+func exercise(t *testing.T, c *lookergo.Client) {
+	t.Helper()
+
+	group, _, err := c.Groups.Create(ctx, &lookergo.Group{Name: "Analysts"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	if _, _, err := c.Connections.Create(ctx, &lookergo.DBConnection{Name: "warehouse", DialectName: "postgres", Host: "db", Password: "hunter2"}); err != nil {
+		t.Fatalf("Connections.Create returned error: %v", err)
+	}
+	got, _, err := c.Groups.Get(ctx, group.Id, nil)
+	if err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	if got.Name != "Analysts" {
+		t.Errorf("Groups.Get = %+v, expected Analysts", got)
+	}
+	// Paged with the Link header, which is scrubbed, and X-Total-Count.
+	if groups, _, err := lookergo.ListAll(ctx, &lookergo.ListOptions{Limit: 1}, c.Groups.List); err != nil || len(groups) != 2 {
+		t.Errorf("ListAll = %d groups, %v, expected All Users and Analysts", len(groups), err)
+	}
+	if _, err := c.Groups.Delete(ctx, group.Id); err != nil {
+		t.Fatalf("Groups.Delete returned error: %v", err)
+	}
+	if _, _, err := c.Groups.Get(ctx, group.Id, nil); !lookergo.IsNotFound(err) {
+		t.Errorf("Groups.Get after delete = %v, expected 404", err)
+	}
+}
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.json")
+
+	srv := lookertest.NewServer()
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	exercise(t, newClient(t, srv.BaseURL(), srv.ClientID, srv.ClientSecret, rec))
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	srv.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	for _, secret := range []string{srv.ClientSecret, "hunter2", "Bearer ", "lookertest-token"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("fixture contains %q:\n%s", secret, b)
+		}
+	}
+	if strings.Contains(string(b), "127.0.0.1") {
+		t.Errorf("fixture contains the host of the instance:\n%s", b)
+	}
+
+	// The instance is gone: everything comes from the fixture.
+	rec, err = cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	c := newClient(t, "https://looker.invalid/api/", "id", "secret", rec)
+	exercise(t, c)
+
+	if _, _, err := c.Users.Get(ctx, lookertest.AdminUserID, nil); !errors.Is(err, cassette.ErrNoInteraction) {
+		t.Errorf("Users.Get which was not recorded = %v, expected ErrNoInteraction", err)
+	}
+}
+
+func TestRecorder_scrubInstanceURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/4.0/users?limit=1&offset=1>; rel="next"`, r.Host))
+		w.Header().Set("X-Total-Count", "2")
+		fmt.Fprintf(w, `[{"id":"1","url":"http://%s/api/4.0/users/1"}]`, r.Host)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "users.json")
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	c, err := lookergo.New(lookergo.WithBaseURL(srv.URL+"/api/"), lookergo.WithTransport(rec), lookergo.WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("lookergo.New returned error: %v", err)
+	}
+	if _, _, err := c.Users.List(ctx, &lookergo.ListOptions{Limit: 1}); err != nil {
+		t.Fatalf("Users.List returned error: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if host := strings.TrimPrefix(srv.URL, "http://"); strings.Contains(string(b), host) {
+		t.Errorf("fixture contains the host of the instance %s:\n%s", host, b)
+	}
+}
+
+func TestNew_replayWithoutFixture(t *testing.T) {
+	if _, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay); !os.IsNotExist(err) {
+		t.Errorf("New with a missing fixture = %v, expected a not exist error", err)
+	}
+}
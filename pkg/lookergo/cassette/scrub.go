@@ -0,0 +1,39 @@
+package cassette
+
+import (
+	"net/url"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/internal/redact"
+)
+
+// Redacted replaces scrubbed values in fixtures.
+const Redacted = redact.Redacted
+
+// DefaultScrubHeaders are the headers whose values are scrubbed by default: the ones lookergo redacts from its logs,
+// and Link, whose page URLs hold the host of the instance.
+var DefaultScrubHeaders = append(append([]string(nil), redact.DefaultHeaders...), "Link")
+
+// DefaultScrubFields are the JSON, form and query fields whose values are scrubbed by default: the ones lookergo
+// redacts from its logs, and url, the link to themselves which objects hold, with the host of the instance.
+var DefaultScrubFields = append(append([]string(nil), redact.DefaultFields...), "url")
+
+// scrubber removes secrets from recorded exchanges.
+type scrubber struct {
+	*redact.Redactor
+}
+
+func newScrubber(headers, fields []string) *scrubber {
+	return &scrubber{redact.New(headers, fields)}
+}
+
+// url scrubs the query of u, and returns it without scheme and host.
+func (s *scrubber) url(u *url.URL) string {
+	scrubbed := s.URL(u)
+	ref := url.URL{Path: scrubbed.Path, RawQuery: scrubbed.RawQuery}
+	return ref.String()
+}
+
+// body scrubs a JSON or form encoded body. Other bodies are returned as is.
+func (s *scrubber) body(contentType, body string) string {
+	return s.Body(contentType, []byte(body))
+}
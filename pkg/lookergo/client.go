@@ -8,10 +8,9 @@ import (
 	"path"
 	"reflect"
 	"strings"
-	"time"
 
-	"github.com/beefsack/go-rate"
 	"github.com/google/go-querystring/query"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 
@@ -20,6 +19,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 )
 
 const (
@@ -27,44 +27,39 @@ const (
 	defaultBaseURL = "https://api.example.com/"
 	userAgent      = "API/" + libraryVersion
 	mediaType      = "application/json"
-)
 
-var rl *rate.RateLimiter
-
-func init() {
-	rl = rate.New(1, 1000*time.Millisecond) // Once per second
-}
+	// DefaultAPIVersion is the version of the API clients use unless WithAPIVersion says otherwise.
+	DefaultAPIVersion = "4.0"
+)
 
 // Rate contains the rate limit for the current client.
 type Rate struct {
-	// The number of request per hour the client is currently limited to.
+	// The number of requests per window the client is currently limited to, 0 if the API did not tell.
 	Limit int `json:"limit"`
 
-	// The number of remaining requests the client can make this hour.
+	// The number of remaining requests the client can make in the current window.
 	Remaining int `json:"remaining"`
 
 	// The time at which the current rate limit will reset.
 	Reset Timestamp `json:"reset"`
 }
 
-// Client manages communication with the API.
+// Client manages communication with the API. A client is safe for concurrent use: its configuration is set by New
+// and does not change afterwards. WithHeaders, WithToken and WithWorkspace return derived clients with another one.
 type Client struct {
 	// Pointer reference to a shared HTTP client for communicating with the API.
 	client *http.Client
 
 	// Base URL for API requests.
-	BaseURL *url.URL
+	baseURL *url.URL
 
 	// User agent for HTTP client
-	UserAgent string
+	userAgent string
 
-	// Rate contains the current rate limit for the client as determined by the most recent
-	// API call. It is not thread-safe. Please consider using GetRate() instead.
-	Rate    Rate
+	// Current rate limit for the client as determined by the most recent API call which reported one.
+	rate    Rate
 	ratemtx sync.Mutex
 
-	mu sync.Mutex
-
 	// Resources used for communicating with the API
 	Groups          GroupsResource
 	Users           UsersResource
@@ -78,36 +73,88 @@ type Client struct {
 	LookMLModel     LookMlModelsResource
 	ColorCollection ColorCollectionResource
 	PermissionSets  PermissionSetResource
+	Versions        VersionsResource
 
 	// TODO: Expand
 
 	// Optional function called after every successful request made to the DO APIs
 	onRequestCompleted RequestCompletionCallback
 
+	// Optional function called after every request which got no response from the API
+	onRequestFailed RequestErrorCallback
+
 	// Optional extra HTTP headers to set on every request to the API.
 	headers map[string]string
 
+	// How transient failures are retried by Do
+	retryPolicy RetryPolicy
+
+	// Throttles the requests sent by Do, possibly shared with other clients
+	limiter *Limiter
+
+	// Receives diagnostic messages, such as retried requests
+	logger Logger
+
+	// Version of the API, used for the login endpoint
+	apiVersion string
+
 	// Production or dev workspace
-	Workspace string
+	workspace string
+
+	// Token source of the clients returned by AsUser, nil otherwise
+	sudo *userTokenSource
+
+	// Refuses the requests that could change the instance
+	readOnly bool
+
+	// Starts the spans of Do, nil if tracing is disabled
+	tracer trace.Tracer
 }
 
-// RequestCompletionCallback defines the type of the request callback function
+// RequestCompletionCallback defines the type of the request callback function. It is called once per request
+// answered by the API, with the final response, after the retries.
 type RequestCompletionCallback func(*http.Request, *http.Response)
 
+// RequestErrorCallback defines the type of the request error callback function. It is called once per request which
+// got no response, e.g. because it timed out or lost its connection, with the last error, after the retries. The
+// request carries the context it was sent with.
+type RequestErrorCallback func(*http.Request, error)
+
 // ListOptions specifies the optional parameters to various List methods that
-// support ~~pagination~~ limit/offset querystring.
+// support pagination through the limit/offset querystring.
+// Use ListAll or NewIterator to walk all pages.
 type ListOptions struct {
-	// For paginated result sets, page of results to retrieve.
+	// For paginated result sets, the number of results to include per page.
 	Limit int `url:"limit,omitempty"`
 
-	// For paginated result sets, the number of results to include per page.
+	// For paginated result sets, the number of results to skip.
 	Offset int `url:"offset,omitempty"`
+
+	// Fields to include in the results, e.g. []string{"id", "name"}. All fields are returned when empty.
+	// Nested fields use parentheses: "credentials_email(email)".
+	Fields []string `url:"fields,comma,omitempty"`
+}
+
+// GetOptions specifies the optional parameters to the Get methods.
+type GetOptions struct {
+	// Fields to include in the result, as in ListOptions.
+	Fields []string `url:"fields,comma,omitempty"`
 }
 
 // Response is an API response. This wraps the standard http.Response returned from the API.
 type Response struct {
 	*http.Response
 
+	// Pagination info parsed from the Link and X-Total-Count headers, nil on non-paginated responses.
+	Pages *Pages
+
+	// Total number of items as reported by the X-Total-Count header, -1 if the header was absent.
+	TotalCount int
+
+	// RequestID returned from the API (X-Request-Id), useful to contact support.
+	RequestID string
+
+	// Rate limit parsed from the rate limit headers, zero if the response had none.
 	Rate
 }
 
@@ -124,10 +171,12 @@ type ErrorResponse struct {
 	Response *http.Response
 	// Error message
 	Message string `json:"message"`
-	// RequestID returned from the API, useful to contact support.
-	DocsURL string `json:"docs"`
-	// More specific error msg
+	// Link to the documentation of the endpoint
+	DocsURL string `json:"documentation_url"`
+	// Field-level validation errors
 	Errors []Error `json:"errors,omitempty"`
+	// RequestID returned from the API (X-Request-Id), useful to contact support.
+	RequestID string `json:"-"`
 }
 
 // NewClient -
@@ -138,7 +187,7 @@ func NewClient(httpClient *http.Client) *Client {
 
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent}
+	c := &Client{client: httpClient, baseURL: baseURL, userAgent: userAgent}
 	c.Groups = &GroupsResourceOp{client: c}
 	c.Users = &UsersResourceOp{client: c}
 	c.Roles = &RolesResourceOp{client: c}
@@ -151,9 +200,13 @@ func NewClient(httpClient *http.Client) *Client {
 	c.LookMLModel = &LookMlModelsResourceOp{client: c}
 	c.ColorCollection = &ColorCollectionResourceOp{client: c}
 	c.PermissionSets = &PermissionSetResourceOp{client: c}
+	c.Versions = &VersionsResourceOp{client: c}
 
 	c.headers = make(map[string]string)
-	c.Workspace = "production"
+	c.workspace = "production"
+	c.retryPolicy = DefaultRetryPolicy
+	c.limiter = NewLimiter(DefaultRequestsPerSecond, DefaultRequestsPerSecond, DefaultMaxConcurrentRequests)
+	c.apiVersion = DefaultAPIVersion
 
 	return c
 }
@@ -175,6 +228,18 @@ type AuthToken struct {
 	raw          interface{}
 }
 
+// oauth2Token returns the token received at now, with its expiry.
+func (t *AuthToken) oauth2Token(now time.Time) *oauth2.Token {
+	token := &oauth2.Token{AccessToken: t.AccessToken, TokenType: t.TokenType}
+	if t.RefreshToken != nil {
+		token.RefreshToken = *t.RefreshToken
+	}
+	if t.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return token
+}
+
 // NewFromApiv3Creds -
 func NewFromApiv3Creds(config ApiConfig) *Client {
 	if config.BaseURL == "" {
@@ -185,7 +250,7 @@ func NewFromApiv3Creds(config ApiConfig) *Client {
 	oauthConfig := clientcredentials.Config{
 		ClientID:     config.ClientId,
 		ClientSecret: config.ClientSecret,
-		TokenURL:     fmt.Sprintf("%s4.0/login", config.BaseURL),
+		TokenURL:     fmt.Sprintf("%s%s/login", config.BaseURL, DefaultAPIVersion),
 		AuthStyle:    oauth2.AuthStyleInParams,
 	}
 
@@ -204,165 +269,274 @@ func NewFromStaticToken(token string) *Client {
 }
 
 // ClientOpt are options for New.
-type ClientOpt func(*Client) error
-
-// New returns a new API client instance.
-// func New(httpClient *http.Client, opts ...ClientOpt) (*Client, error) {
-// 	c := NewClient(httpClient)
-// 	for _, opt := range opts {
-// 		if err := opt(c); err != nil {
-// 			return nil, err
-// 		}
-// 	}
+type ClientOpt func(*clientOptions) error
+
+// New returns a new API client instance. A base URL is required; without credentials the client is
+// unauthenticated.
 //
-// 	return c, nil
-// }
+//	client, err := lookergo.New(
+//		lookergo.WithBaseURL("https://example.cloud.looker.com/api/"),
+//		lookergo.WithOAuthCredentials(clientID, clientSecret),
+//	)
+func New(opts ...ClientOpt) (*Client, error) {
+	o := &clientOptions{
+		retryPolicy: DefaultRetryPolicy,
+		apiVersion:  DefaultAPIVersion,
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	transport := o.transport
+	if o.httpLog != nil {
+		transport = NewLoggingTransport(transport, *o.httpLog)
+	}
 
-// SetBaseURL is a client option for setting the base URL.
-func (c *Client) SetBaseURL(bu string) error {
+	c := NewClient(&http.Client{Transport: transport, Timeout: o.timeout})
+	c.apiVersion = o.apiVersion
+	c.retryPolicy = o.retryPolicy
+	c.logger = o.logger
+	c.onRequestCompleted = o.callback
+	c.onRequestFailed = o.errCallback
+	c.readOnly = o.readOnly
+	if o.tracerProvider != nil {
+		c.tracer = o.tracerProvider.Tracer(TracerName)
+	}
+	if o.limiterSet {
+		c.limiter = o.limiter
+	}
+	baseURL, err := parseBaseURL(o.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	c.baseURL = baseURL
+	if o.userAgent != "" {
+		c.userAgent = o.userAgent + " " + c.userAgent
+	}
+	for k, v := range o.headers {
+		c.headers[k] = v
+	}
+
+	switch {
+	case o.clientID != "":
+		c.setOauthCredentials(context.Background(), o.clientID, o.clientSecret)
+	case o.staticToken != "":
+		c.setTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: o.staticToken}))
+	case o.credProcess != nil:
+		c.setCredentialProcess(context.Background(), o.credProcess)
+	}
+
+	return c, nil
+}
+
+// parseBaseURL parses the base URL bu, with a trailing slash.
+func parseBaseURL(bu string) (*url.URL, error) {
 	u, err := url.Parse(bu)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	// Paths of the services are resolved relative to the base URL.
+	if u.Path != "" && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
 	}
+	return u, nil
+}
 
-	c.BaseURL = u
-	return nil
+// BaseURL returns a copy of the URL of the API the client talks to.
+func (c *Client) BaseURL() *url.URL {
+	u := *c.baseURL
+	return &u
 }
 
-// SetUserAgent is a client option for setting the user agent.
-func (c *Client) SetUserAgent(ua string) error {
-	c.UserAgent = fmt.Sprintf("%s %s", ua, c.UserAgent)
-	return nil
+// UserAgent returns the user agent the client sends.
+func (c *Client) UserAgent() string {
+	return c.userAgent
 }
 
-// SetRequestHeaders sets optional HTTP headers on the client that are
-// sent on each HTTP request.
-func (c *Client) SetRequestHeaders(headers map[string]string) error {
-	for k, v := range headers {
-		c.headers[k] = v
-	}
-	return nil
+// setOauthCredentials authenticates the requests of the client with tokens obtained with API client credentials.
+func (c *Client) setOauthCredentials(ctx context.Context, clientId string, clientSecret string) {
+	oauthConfig := c.loginConfig(clientId, clientSecret)
+	c.setTokenSource(oauthConfig.TokenSource(c.loginContext(ctx)))
 }
 
-func (c *Client) SetOauthCredentials(ctx context.Context, clientId string, clientSecret string) error {
+// loginConfig returns the configuration of the login with API client credentials.
+func (c *Client) loginConfig(clientID, clientSecret string) *clientcredentials.Config {
 	var loginUrl url.URL
-	if c.BaseURL != nil {
-		loginUrl = *c.BaseURL
+	if c.baseURL != nil {
+		loginUrl = *c.baseURL
 	} else {
 		u, _ := url.Parse(defaultBaseURL)
 		loginUrl = *u
 	}
-	loginUrl.Path = path.Join(loginUrl.Path, "4.0", "login")
+	loginUrl.Path = path.Join(loginUrl.Path, c.apiVersion, "login")
 
-	oauthConfig := clientcredentials.Config{
-		ClientID:     strings.Trim(strings.TrimSpace(clientId), "'"),
+	return &clientcredentials.Config{
+		ClientID:     strings.Trim(strings.TrimSpace(clientID), "'"),
 		ClientSecret: strings.Trim(strings.TrimSpace(clientSecret), "'"),
 		TokenURL:     loginUrl.String(),
 		AuthStyle:    oauth2.AuthStyleInParams,
 	}
-
-	tokenSource := oauthConfig.TokenSource(ctx)
-	c.client = oauth2.NewClient(ctx, tokenSource)
-	return nil
 }
 
-func (c *Client) SetOauthStaticToken(ctx context.Context, token *oauth2.Token) error {
-	if token == nil {
-		return fmt.Errorf("no token provided")
+// loginContext returns ctx, set to fetch tokens through the same transport as the API calls.
+func (c *Client) loginContext(ctx context.Context) context.Context {
+	if base := c.baseTransport(); base != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: base, Timeout: c.client.Timeout})
 	}
-
-	tokenSource := oauth2.StaticTokenSource(token)
-	c.client = oauth2.NewClient(ctx, tokenSource)
-	return nil
+	return ctx
 }
 
-func (c *Client) EnsureStaticToken(ctx context.Context, parentClient *Client, apiUserID string) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if c.client.Transport == nil && c.Workspace != "dev" {
-		// Get duplicate API token for current user
-		token, _, err := parentClient.Sessions.GetLoginUserToken(ctx, apiUserID)
-		if err != nil {
-			return err
-		}
-
-		err = c.SetOauthStaticToken(ctx, token)
-		if err != nil {
-			return err
-		}
+// baseTransport returns the transport of the client, under the authentication layer if any.
+func (c *Client) baseTransport() http.RoundTripper {
+	if t, ok := c.client.Transport.(*tokenTransport); ok {
+		return t.base
+	}
+	return c.client.Transport
+}
 
-		// Set dev workspace for dup token
-		session, _, err := c.Sessions.SetWorkspaceId(ctx, "dev")
-		if err != nil {
-			return err
-		} else if session.WorkspaceId == "production" || session.WorkspaceId == "" {
-			return fmt.Errorf("did not find dev workspace")
-		} else {
-			c.Workspace = session.WorkspaceId
-		}
+// setTokenSource authenticates the requests of the client with ts, keeping its transport and timeout. It is only
+// called on clients which are not in use yet.
+func (c *Client) setTokenSource(ts oauth2.TokenSource) {
+	c.client = &http.Client{
+		Transport: &tokenTransport{source: &reuseTokenSource{new: ts}, base: c.baseTransport()},
+		Timeout:   c.client.Timeout,
 	}
+}
 
-	return nil
+// reuseTokenSource is oauth2.ReuseTokenSource, but fetches the new tokens with the context of the request needing
+// them, under a span of its own.
+type reuseTokenSource struct {
+	new oauth2.TokenSource
+
+	mu sync.Mutex
+	t  *oauth2.Token
 }
 
-func (c *Client) CreateDevConnection(ctx context.Context, rc RequestCompletionCallback) (*Client, *Session, error) {
+// Token implements oauth2.TokenSource.
+func (s *reuseTokenSource) Token() (*oauth2.Token, error) {
+	return s.token(context.Background())
+}
 
-	// Get current user ID
-	user, _, err := c.Sessions.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, nil, err
+func (s *reuseTokenSource) token(ctx context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.t.Valid() {
+		return s.t, nil
 	}
 
-	// Get duplicate API token for current user
-	token, _, err := c.Sessions.GetLoginUserToken(ctx, user.Id)
+	// Traced by the provider of the span of the request, if any.
+	_, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(TracerName).Start(ctx, "looker token")
+	t, err := s.new.Token()
+	endSpan(span, err)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	s.t = t
+	return t, nil
+}
 
-	// Create duplicate API client
-	devClient := NewClient(nil)
-	if err := devClient.SetBaseURL(c.BaseURL.String()); err != nil {
-		return nil, nil, err
-	}
+// tokenSource is an oauth2.TokenSource fetching the new tokens with the context of the request needing them.
+type tokenSource interface {
+	token(ctx context.Context) (*oauth2.Token, error)
+}
+
+// tokenTransport authenticates the requests sent through base with the tokens of source, as oauth2.Transport does.
+type tokenTransport struct {
+	source tokenSource
+	base   http.RoundTripper
+}
 
-	// Set token as static on dup client
-	err = devClient.SetOauthStaticToken(ctx, token)
+// RoundTrip implements http.RoundTripper.
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.token(req.Context())
 	if err != nil {
-		return nil, nil, err
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
 	}
 
-	devClient.OnRequestCompleted(rc)
+	authReq := req.Clone(req.Context())
+	token.SetAuthHeader(authReq)
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(authReq)
+}
 
-	// Set dev workspace for dup token
-	session, _, err := devClient.Sessions.SetWorkspaceId(ctx, "dev")
-	if err != nil {
-		return nil, nil, err
+// newChild returns an unauthenticated client with the settings of c, talking to the same instance through the same
+// transport, and sharing its limiter.
+func (c *Client) newChild() *Client {
+	child := NewClient(&http.Client{Transport: c.baseTransport(), Timeout: c.client.Timeout})
+	child.baseURL = c.BaseURL()
+	child.userAgent = c.userAgent
+	for k, v := range c.headers {
+		child.headers[k] = v
 	}
+	child.apiVersion = c.apiVersion
+	child.logger = c.logger
+	child.retryPolicy = c.retryPolicy
+	child.limiter = c.limiter
+	child.onRequestCompleted = c.onRequestCompleted
+	child.onRequestFailed = c.onRequestFailed
+	child.readOnly = c.readOnly
+	child.tracer = c.tracer
+	return child
+}
 
-	if session.WorkspaceId != "dev" {
-		return nil, nil, fmt.Errorf("failed to set dev environment for" +
-			"duplicate dev workspace connection")
-	} else {
-		c.Workspace = "dev"
+// clone returns a copy of c, authenticated as c is.
+func (c *Client) clone() *Client {
+	child := c.newChild()
+	child.client = c.client
+	child.sudo = c.sudo
+	child.workspace = c.workspace
+	return child
+}
+
+// WithHeaders returns a copy of c which also sets headers on each request, overriding the headers of c with the same
+// names. c is left unchanged.
+func (c *Client) WithHeaders(headers map[string]string) *Client {
+	child := c.clone()
+	for k, v := range headers {
+		child.headers[k] = v
 	}
+	return child
+}
 
-	return devClient, session, err
+// WithToken returns a copy of c authenticated with the API access token token instead of the credentials of c, e.g.
+// a token returned by SessionsResource.GetLoginUserToken. c is left unchanged.
+func (c *Client) WithToken(token string) *Client {
+	child := c.newChild()
+	child.setTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"}))
+	return child
 }
 
-/*
-TODO: Pagination.
-It is provided in the headers
-GET {{endpoint}}/4.0/users?limit=5
-(H) X-Total-Count: 107
-(H) Link: <https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=0>; rel="first",<https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=105>; rel="last",<https://reprise.cloud.looker.com:19999/api/4.0/users?limit=5&offset=5>; rel="next"
+// WithWorkspace returns a copy of c whose requests are recorded as made in the workspace workspaceID, in the audit log
+// and the spans. It does not switch the session of the client to the workspace: a WorkspaceSession does, and returns
+// a client derived with WithWorkspace. c is left unchanged.
+func (c *Client) WithWorkspace(workspaceID string) *Client {
+	child := c.clone()
+	child.workspace = workspaceID
+	return child
+}
 
-*/
+// Workspace returns the workspace the requests of the client are made in, "production" unless the client is the one
+// of a WorkspaceSession.
+func (c *Client) Workspace() string {
+	return c.workspace
+}
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
 // value pointed to by body is JSON encoded and included in as the request body.
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
-	u, err := c.BaseURL.Parse(urlStr)
+	u, err := c.baseURL.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -396,19 +570,29 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	}
 
 	req.Header.Set("Accept", mediaType)
-	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("User-Agent", c.userAgent)
 
 	return req, nil
 }
 
-// OnRequestCompleted sets the DO API request completion callback
-func (c *Client) OnRequestCompleted(rc RequestCompletionCallback) {
-	c.onRequestCompleted = rc
+// newAPIRequest is NewRequest for a path of the versioned API, e.g. "users/1", which is resolved below the API
+// version of the client.
+func (c *Client) newAPIRequest(ctx context.Context, method, apiPath string, body interface{}) (*http.Request, error) {
+	return c.NewRequest(ctx, method, c.apiVersion+"/"+apiPath, body)
+}
+
+// APIVersion returns the version of the API the client talks, e.g. "4.0".
+func (c *Client) APIVersion() string {
+	return c.apiVersion
 }
 
 // newResponse creates a new Response for the provided http.Response
 func newResponse(r *http.Response) *Response {
-	response := Response{Response: r}
+	response := Response{Response: r, TotalCount: -1, RequestID: r.Header.Get("X-Request-Id")}
+	response.Pages = parsePages(r.Header)
+	if response.Pages != nil {
+		response.TotalCount = response.Pages.TotalCount
+	}
 
 	return &response
 }
@@ -428,21 +612,45 @@ func DoRequestWithClient(
 }
 
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v",
+	msg := fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
+	for _, e := range r.Errors {
+		msg += fmt.Sprintf("; %v: %v (%v)", e.Field, e.Message, e.Code)
+	}
+	if r.RequestID != "" {
+		msg += fmt.Sprintf(" [request id: %v]", r.RequestID)
+	}
+	return msg
 }
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	if c.tracer == nil {
+		return c.do(ctx, req, v)
+	}
+	ctx, span := c.startSpan(ctx, req)
+	resp, err := c.do(ctx, req, v)
+	endSpan(span, err)
+	return resp, err
+}
+
+// do is Do, without its span.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if err := c.checkReadOnly(ctx, req); err != nil {
+		return nil, err
+	}
+	ctx = withRequestWorkspace(ctx, c.workspace)
+	resp, err := c.send(ctx, req)
 	if err != nil {
+		if c.onRequestFailed != nil {
+			c.onRequestFailed(req.WithContext(ctx), err)
+		}
 		return nil, err
 	}
-
 	if c.onRequestCompleted != nil {
-		c.onRequestCompleted(req, resp)
+		c.onRequestCompleted(req.WithContext(ctx), resp)
 	}
 
 	defer func() {
@@ -462,12 +670,14 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 		}
 	}()
 
-	c.ratemtx.Lock()
 	response := newResponse(resp)
-	rl.Wait()
-
-	c.Rate = response.Rate
-	c.ratemtx.Unlock()
+	if rate, ok := parseRate(resp.Header); ok {
+		response.Rate = rate
+		c.ratemtx.Lock()
+		c.rate = rate
+		c.ratemtx.Unlock()
+		c.limiter.Observe(response.Rate)
+	}
 
 	err = CheckResponse(resp)
 
@@ -494,6 +704,36 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return response, err
 }
 
+// send sends req through the retry loop, its attempts authenticated, throttled and traced as the client is
+// configured.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	attempt := sendFunc(c.sendAttempt)
+	if c.tracer != nil {
+		attempt = traceAttempts(attempt)
+	}
+	if c.sudo == nil {
+		return c.doWithRetry(ctx, req, attempt)
+	}
+
+	attempt = c.sudo.minting(attempt)
+	return c.sudo.reauthenticate(ctx, req, func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		return c.doWithRetry(ctx, req, attempt)
+	})
+}
+
+// sendAttempt sends req once, when the limiter lets it.
+func (c *Client) sendAttempt(ctx context.Context, req *http.Request) (*http.Response, error) {
+	waitStart := time.Now()
+	release, err := c.limiter.Wait(ctx)
+	addLimiterWait(ctx, time.Since(waitStart))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := DoRequestWithClient(ctx, c.client, req)
+	release()
+	return resp, err
+}
+
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
 // error if it has a status code outside the 200 range. API error responses are expected to have either no response
 // body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
@@ -503,7 +743,7 @@ func CheckResponse(r *http.Response) error {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{Response: r, RequestID: r.Header.Get("X-Request-Id")}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
 		err := json.Unmarshal(data, errorResponse)
@@ -515,6 +755,14 @@ func CheckResponse(r *http.Response) error {
 	return errorResponse
 }
 
+// GetRate returns the rate limit reported by the most recent API call which carried one.
+// It is safe for concurrent use.
+func (c *Client) GetRate() Rate {
+	c.ratemtx.Lock()
+	defer c.ratemtx.Unlock()
+	return c.rate
+}
+
 func (r Rate) String() string {
 	return Stringify(r)
 }
@@ -591,7 +839,7 @@ func doList[T any](ctx context.Context, client *Client, basePath string, opt *Li
 		return nil, nil, err
 	}
 
-	req, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -604,10 +852,14 @@ func doList[T any](ctx context.Context, client *Client, basePath string, opt *Li
 	return *svc, resp, err
 }
 
-func doGet[T any](ctx context.Context, client *Client, basePath string, svc *T, pathSuffix ...string) (*T, *Response, error) {
+func doGet[T any](ctx context.Context, client *Client, basePath string, opt *GetOptions, svc *T, pathSuffix ...string) (*T, *Response, error) {
 	path := fmt.Sprintf("%s%s", basePath, strings.Join(append([]string{""}, pathSuffix...), "/"))
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	req, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -620,20 +872,17 @@ func doGet[T any](ctx context.Context, client *Client, basePath string, svc *T,
 	return svc, resp, err
 }
 
-func doGetById[T any](ctx context.Context, client *Client, basePath string, id any, svc *T) (*T, *Response, error) {
-	switch id.(type) {
-	case int:
-		if id.(int) < 1 {
-			return nil, nil, NewArgError("id", "cannot be less than 1")
-		}
-	case string:
-	default:
-		panic("Invalid type for ID. Has to be either int or string")
+func doGetById[T any, I ~string](ctx context.Context, client *Client, basePath string, id I, opt *GetOptions, svc *T) (*T, *Response, error) {
+	path, err := idPath(basePath, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err = addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	path := fmt.Sprintf("%s/%v", basePath, id)
-
-	req, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -653,7 +902,7 @@ func doListByX[T service](ctx context.Context, client *Client, basePath string,
 		return nil, nil, err
 	}
 
-	req, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -669,7 +918,7 @@ func doListByX[T service](ctx context.Context, client *Client, basePath string,
 func doCreate[T any, N any](ctx context.Context, client *Client, basePath string, svc *T, newSvc *N, pathSuffix ...string) (*N, *Response, error) {
 	path := fmt.Sprintf("%s%s", basePath, strings.Join(append([]string{""}, pathSuffix...), "/"))
 
-	req, err := client.NewRequest(ctx, http.MethodPost, path, svc)
+	req, err := client.newAPIRequest(ctx, http.MethodPost, path, svc)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -690,7 +939,7 @@ func doCreateX(ctx context.Context, client *Client, basePath string, newSvc *str
 		path = fmt.Sprintf("%s%s", basePath, strings.Join(append([]string{""}, pathSuffix...), "/"))
 	}
 
-	req, err := client.NewRequest(ctx, http.MethodPost, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -706,7 +955,7 @@ func doCreateX(ctx context.Context, client *Client, basePath string, newSvc *str
 func doEmptyPost[N service](ctx context.Context, client *Client, basePath string, newSvc *N, pathSuffix ...string) (*N, *Response, error) {
 	path := fmt.Sprintf("%s%s", basePath, strings.Join(append([]string{""}, pathSuffix...), "/"))
 
-	req, err := client.NewRequest(ctx, http.MethodPost, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -719,22 +968,13 @@ func doEmptyPost[N service](ctx context.Context, client *Client, basePath string
 	return newSvc, resp, err
 }
 
-func doUpdate[T any, U any](ctx context.Context, client *Client, basePath string, id any, svc *T, uSvc *U, pathSuffix ...string) (*U, *Response, error) {
-	var path string
-
-	switch id.(type) {
-	case int:
-		if id.(int) < 1 {
-			return nil, nil, NewArgError("id", "cannot be less than 1")
-		}
-		path = fmt.Sprintf("%s/%d%s", basePath, id.(int), strings.Join(append([]string{""}, pathSuffix...), "/"))
-	case string:
-		path = fmt.Sprintf("%s/%s%s", basePath, id.(string), strings.Join(append([]string{""}, pathSuffix...), "/"))
-	default:
-		panic("Invalid type for ID. Has to be either int or string")
+func doUpdate[T any, U any, I ~string](ctx context.Context, client *Client, basePath string, id I, svc *T, uSvc *U, pathSuffix ...string) (*U, *Response, error) {
+	path, err := idPath(basePath, id, pathSuffix...)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	req, err := client.NewRequest(ctx, http.MethodPatch, path, svc)
+	req, err := client.newAPIRequest(ctx, http.MethodPatch, path, svc)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -747,14 +987,14 @@ func doUpdate[T any, U any](ctx context.Context, client *Client, basePath string
 	return uSvc, resp, err
 }
 
-func doSet[T any](ctx context.Context, client *Client, basePath string, ids []string, svc *[]T, pathSuffix ...string) ([]T, *Response, error) {
+func doSet[T any](ctx context.Context, client *Client, basePath string, ids []ID, svc *[]T, pathSuffix ...string) ([]T, *Response, error) {
 	if len(ids) < 1 {
 		return nil, nil, NewArgError("ids", "cannot be less than 1")
 	}
 
 	path := fmt.Sprintf("%s%s", basePath, strings.Join(append([]string{""}, pathSuffix...), "/"))
 
-	req, err := client.NewRequest(ctx, http.MethodPut, path, ids)
+	req, err := client.newAPIRequest(ctx, http.MethodPut, path, ids)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -767,22 +1007,28 @@ func doSet[T any](ctx context.Context, client *Client, basePath string, ids []st
 	return *svc, resp, err
 }
 
-func doDelete(ctx context.Context, client *Client, basePath string, id any, pathSuffix ...string) (*Response, error) {
-	var path string
+// doClear is doSet with no ids, which doSet refuses so that nothing is unlinked by mistake.
+func doClear[T any](ctx context.Context, client *Client, path string, svc *[]T) ([]T, *Response, error) {
+	req, err := client.newAPIRequest(ctx, http.MethodPut, path, []ID{})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	switch id.(type) {
-	case int:
-		if id.(int) < 1 {
-			return nil, NewArgError("id", "cannot be less than 1")
-		}
-		path = fmt.Sprintf("%s/%d%s", basePath, id.(int), strings.Join(append([]string{""}, pathSuffix...), "/"))
-	case string:
-		path = fmt.Sprintf("%s/%s%s", basePath, id.(string), strings.Join(append([]string{""}, pathSuffix...), "/"))
-	default:
-		panic("Invalid type for ID. Has to be either int or string")
+	resp, err := client.Do(ctx, req, svc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *svc, resp, err
+}
+
+func doDelete[I ~string](ctx context.Context, client *Client, basePath string, id I, pathSuffix ...string) (*Response, error) {
+	path, err := idPath(basePath, id, pathSuffix...)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := client.newAPIRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -797,7 +1043,7 @@ func doDelete(ctx context.Context, client *Client, basePath string, id any, path
 
 func doAddMember[T service](ctx context.Context, client *Client, path string, svc *T, addNew interface{}) (*T, *Response, error) {
 
-	req, err := client.NewRequest(ctx, http.MethodPost, path, addNew)
+	req, err := client.newAPIRequest(ctx, http.MethodPost, path, addNew)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6,13 +6,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/k0kubun/pp/v3"
 	_ "github.com/stretchr/testify/assert"
-	"golang.org/x/oauth2"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
-	"os"
 	"reflect"
+	"sync"
 	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
 )
 
 var (
@@ -30,7 +31,7 @@ func setup() {
 
 	client = NewClient(nil)
 	serverURL, _ := url.Parse(server.URL)
-	client.BaseURL = serverURL
+	client.baseURL = serverURL
 }
 
 func teardown() {
@@ -123,72 +124,230 @@ func TestDo(t *testing.T) {
 }
 
 func TestAuth3(t *testing.T) {
-	_ = os.Setenv("HTTP_PROXY", "http://localhost:9090")
-
-	c := NewClient(nil)
+	srv := lookertest.NewServer()
+	defer srv.Close()
 
 	ctx := context.Background()
-	err := c.SetBaseURL("http://dev.l0c4l.host:3001/api")
+	c, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret))
 	if err != nil {
 		t.Fatalf("New() unexpected error: %v", err)
 	}
-	err = c.SetOauthCredentials(ctx, "mrClientId", "mrClientSecret")
+
+	req, _ := c.NewRequest(ctx, http.MethodGet, "4.0/session", nil)
+	body := new(Session)
+	_, err = c.Do(context.Background(), req, body)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	if body.WorkspaceId != "production" {
+		t.Errorf("WorkspaceId = %q, expected production", body.WorkspaceId)
+	}
+}
+
+func TestAuth5(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	c, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret))
 	if err != nil {
 		t.Fatalf("New() unexpected error: %v", err)
 	}
 
-	type foo struct {
-		A string
+	token, _, err := c.Sessions.GetLoginUserToken(ctx, lookertest.AdminUserID)
+	if err != nil {
+		t.Fatalf("GetLoginUserToken(): %v", err)
 	}
 
-	req, _ := c.NewRequest(ctx, http.MethodGet, "/", nil)
-	body := new(foo)
-	_, err = c.Do(context.Background(), req, body)
+	devClient := c.WithToken(token.AccessToken)
+
+	session, _, err := devClient.Sessions.SetWorkspaceId(ctx, "dev")
 	if err != nil {
-		t.Fatalf("Do(): %v", err)
+		t.Fatalf("SetWorkspaceId(): %v", err)
+	}
+	if session.WorkspaceId != "dev" {
+		t.Errorf("WorkspaceId = %q, expected dev", session.WorkspaceId)
 	}
 
+	// The workspace belongs to the session of the dev client only.
+	session, _, err = c.Sessions.Get(ctx)
+	if err != nil {
+		t.Fatalf("Sessions.Get(): %v", err)
+	}
+	if session.WorkspaceId != "production" {
+		t.Errorf("WorkspaceId = %q, expected production", session.WorkspaceId)
+	}
+
+	badClient := c.WithToken("very-unique")
+	if _, _, err := badClient.Sessions.Get(ctx); !IsUnauthorized(err) {
+		t.Errorf("Sessions.Get() with an unknown token = %v, expected 401", err)
+	}
 }
 
-func TestAuth5(t *testing.T) {
-	_ = os.Setenv("HTTP_PROXY", "http://localhost:9090")
+func TestClient_derived(t *testing.T) {
+	c, _ := New(WithBaseURL("https://example.cloud.looker.com/api/"), WithHeaders(map[string]string{"X-Team": "data"}))
 
-	c := NewClient(nil)
+	h := c.WithHeaders(map[string]string{"X-Team": "ops", "X-Run": "42"})
+	req, _ := h.NewRequest(ctx, http.MethodGet, "4.0/session", nil)
+	if req.Header.Get("X-Team") != "ops" || req.Header.Get("X-Run") != "42" {
+		t.Errorf("headers of the derived client %v", req.Header)
+	}
+	req, _ = c.NewRequest(ctx, http.MethodGet, "4.0/session", nil)
+	if req.Header.Get("X-Team") != "data" || req.Header.Get("X-Run") != "" {
+		t.Errorf("headers of the parent changed to %v", req.Header)
+	}
 
-	ctx := context.Background()
-	err := c.SetBaseURL("http://dev.l0c4l.host:3001/api")
-	if err != nil {
-		t.Fatalf("New() unexpected error: %v", err)
+	w := h.WithWorkspace("dev")
+	if w.Workspace() != "dev" || h.Workspace() != "production" || c.Workspace() != "production" {
+		t.Errorf("workspaces %q, %q, %q", w.Workspace(), h.Workspace(), c.Workspace())
+	}
+	if req, _ := w.NewRequest(ctx, http.MethodGet, "4.0/session", nil); req.Header.Get("X-Run") != "42" {
+		t.Errorf("WithWorkspace dropped the headers: %v", req.Header)
 	}
-	err = c.SetOauthCredentials(ctx, "mrClientId", "mrClientSecret")
+}
+
+// TestDo_callbackContext checks that the request callbacks get the context of the caller, e.g. its logger.
+func TestDo_callbackContext(t *testing.T) {
+	type key struct{}
+	caller := context.WithValue(ctx, key{}, "caller")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/4.0/groups/2" {
+			// The connection is lost without a response.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"g"}`)
+	}))
+	defer srv.Close()
+
+	var completed, failed interface{}
+	c, err := New(WithBaseURL(srv.URL+"/api/"), WithLimiter(nil), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithRequestCompletionCallback(func(req *http.Request, resp *http.Response) {
+			completed = req.Context().Value(key{})
+		}),
+		WithRequestErrorCallback(func(req *http.Request, err error) {
+			failed = req.Context().Value(key{})
+		}))
 	if err != nil {
-		t.Fatalf("New() unexpected error: %v", err)
+		t.Fatalf("New returned error: %v", err)
 	}
 
-	type foo struct {
-		A string
+	if _, _, err := c.Groups.Get(caller, "1", nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	if _, _, err := c.Groups.Get(caller, "2", nil); err == nil {
+		t.Fatal("Groups.Get succeeded on a lost connection")
 	}
+	if completed != "caller" || failed != "caller" {
+		t.Errorf("callbacks got contexts with %v and %v, expected the context of the caller", completed, failed)
+	}
+}
 
-	req, _ := c.NewRequest(ctx, http.MethodGet, "/", nil)
-	body := new(foo)
-	_, err = c.Do(context.Background(), req, body)
+// TestClient_concurrent uses a client and the clients derived from it from many goroutines, for go test -race.
+func TestClient_concurrent(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+
+	parent, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret),
+		WithLimiter(NewLimiter(1000, 1000, 8)), WithRequestCompletionCallback(func(*http.Request, *http.Response) {}))
 	if err != nil {
-		t.Fatalf("Do(): %v", err)
+		t.Fatalf("New returned error: %v", err)
+	}
+	token, _, err := parent.Sessions.GetLoginUserToken(ctx, lookertest.AdminUserID)
+	if err != nil {
+		t.Fatalf("GetLoginUserToken returned error: %v", err)
+	}
+	session := NewWorkspaceSession(parent, "dev")
+	defer session.Close(ctx)
+
+	// checkWorkspace checks that the session of c is in workspace.
+	checkWorkspace := func(c *Client, workspace string) {
+		s, _, err := c.Sessions.Get(ctx)
+		if err != nil {
+			t.Errorf("Sessions.Get returned error: %v", err)
+		} else if s.WorkspaceId != workspace {
+			t.Errorf("session in %q, expected %q", s.WorkspaceId, workspace)
+		}
 	}
 
-	devClient := NewClient(nil)
-	_ = devClient.SetBaseURL(c.BaseURL.String())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				checkWorkspace(parent, "production")
+				checkWorkspace(parent.WithHeaders(map[string]string{"X-Worker": fmt.Sprint(i)}), "production")
+				checkWorkspace(parent.WithToken(token.AccessToken), "production")
+
+				labelled := parent.WithWorkspace("dev")
+				if _, _, err := labelled.Groups.Create(ctx, &Group{Name: fmt.Sprintf("group-%d-%d", i, j)}); err != nil {
+					t.Errorf("Groups.Create returned error: %v", err)
+				}
+
+				dev, err := session.Client(ctx)
+				if err != nil {
+					t.Errorf("WorkspaceSession.Client returned error: %v", err)
+					return
+				}
+				checkWorkspace(dev, "dev")
+				_ = parent.GetRate()
+			}
+		}(i)
+	}
+	wg.Wait()
 
-	err = devClient.SetOauthStaticToken(ctx, &oauth2.Token{AccessToken: "very-unique", TokenType: "Bearer"})
+	groups, _, err := parent.Groups.List(ctx, nil)
 	if err != nil {
-		t.Fatalf("Do(): %v", err)
+		t.Fatalf("Groups.List returned error: %v", err)
+	}
+	if created := len(groups) - 1; created != 80 {
+		t.Errorf("%d groups created, expected 80", created)
 	}
+}
 
-	req2, _ := c.NewRequest(ctx, http.MethodGet, "/", nil)
-	body2 := new(foo)
-	_, err = devClient.Do(context.Background(), req2, body2)
+func TestGetOptions_fields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/users/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got, expected := r.URL.Query().Get("fields"), "id,first_name,credentials_email(email,is_disabled)"; got != expected {
+			t.Errorf("fields = %q, expected %q", got, expected)
+		}
+		fmt.Fprint(w, `{"id":"7","first_name":"Jane"}`)
+	})
+
+	user, _, err := client.Users.Get(ctx, "7", &GetOptions{Fields: []string{"id", "first_name", "credentials_email(email,is_disabled)"}})
 	if err != nil {
-		t.Fatalf("Do(): %v", err)
+		t.Fatalf("Users.Get returned error: %v", err)
+	}
+	if user.FirstName != "Jane" {
+		t.Errorf("Users.Get = %+v, expected Jane", user)
 	}
+}
 
+func TestListOptions_fields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var fields []string
+	mux.HandleFunc("/4.0/groups/search/with_hierarchy", func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		fmt.Fprint(w, `[]`)
+	})
+
+	// The fields of the options replace the ones ListByName asks for by default.
+	if _, _, err := client.Groups.ListByName(ctx, "Analysts", nil); err != nil {
+		t.Fatalf("Groups.ListByName returned error: %v", err)
+	}
+	if _, _, err := client.Groups.ListByName(ctx, "Analysts", &ListOptions{Fields: []string{"id", "name"}}); err != nil {
+		t.Fatalf("Groups.ListByName returned error: %v", err)
+	}
+	expected := []string{"id,name,user_count,role_ids,parent_group_ids", "id,name"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("fields = %q, expected %q", fields, expected)
+	}
 }
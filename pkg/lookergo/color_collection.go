@@ -2,11 +2,11 @@ package lookergo
 
 import "context"
 
-const ColorCollectionBasePath = "4.0/color_collections"
+const ColorCollectionBasePath = "color_collections"
 
 // https://developers.looker.com/api/explorer/4.0/types/ColorCollection/ColorCollection
 type ColorCollection struct {
-	Id                  *string              `json:"id,omitempty"`                  // Unique Id
+	Id                  *ID                  `json:"id,omitempty"`                  // Unique Id
 	Label               *string              `json:"label,omitempty"`               // Label of color collection
 	CategoricalPalettes *[]DiscretePalette   `json:"categoricalPalettes,omitempty"` // Array of categorical palette definitions
 	SequentialPalettes  *[]ContinuousPalette `json:"sequentialPalettes,omitempty"`  // Array of discrete palette definitions
@@ -31,10 +31,10 @@ type ContinuousPalette struct {
 
 // https://developers.looker.com/api/explorer/4.0/types/ColorCollection/WriteColorCollection
 type WriteColorCollection struct {
-	Label               *string              `json:"label,omitempty"`               // Label of color collection
-	CategoricalPalettes *[]DiscretePalette   `json:"categoricalPalettes,omitempty"` // Array of categorical palette definitions
-	SequentialPalettes  *[]ContinuousPalette `json:"sequentialPalettes,omitempty"`  // Array of discrete palette definitions
-	DivergingPalettes   *[]ContinuousPalette `json:"divergingPalettes,omitempty"`   // Array of diverging palette definitions
+	Label               *Nullable[string]              `json:"label,omitempty"`               // Label of color collection
+	CategoricalPalettes *Nullable[[]DiscretePalette]   `json:"categoricalPalettes,omitempty"` // Array of categorical palette definitions
+	SequentialPalettes  *Nullable[[]ContinuousPalette] `json:"sequentialPalettes,omitempty"`  // Array of discrete palette definitions
+	DivergingPalettes   *Nullable[[]ContinuousPalette] `json:"divergingPalettes,omitempty"`   // Array of diverging palette definitions
 }
 
 // https://developers.looker.com/api/explorer/4.0/types/ColorCollection/ColorStop
@@ -49,28 +49,33 @@ type ColorCollectionResourceOp struct {
 
 type ColorCollectionResource interface {
 	List(context.Context, *ListOptions) ([]ColorCollection, *Response, error)
-	Get(context.Context, string) (*ColorCollection, *Response, error)
+	Get(context.Context, ID, *GetOptions) (*ColorCollection, *Response, error)
 	Create(context.Context, *WriteColorCollection) (*ColorCollection, *Response, error)
-	Update(context.Context, string, *WriteColorCollection) (*ColorCollection, *Response, error)
-	Delete(context.Context, string) (*Response, error)
+	Update(context.Context, ID, *WriteColorCollection) (*ColorCollection, *Response, error)
+	Delete(context.Context, ID) (*Response, error)
 }
 
 func (s *ColorCollectionResourceOp) List(ctx context.Context, opt *ListOptions) ([]ColorCollection, *Response, error) {
+	ctx = withRoute(ctx, "color_collections")
 	return doList(ctx, s.client, ColorCollectionBasePath, opt, new([]ColorCollection))
 }
 
-func (s *ColorCollectionResourceOp) Get(ctx context.Context, ColorCollectionId string) (*ColorCollection, *Response, error) {
-	return doGetById(ctx, s.client, ColorCollectionBasePath, ColorCollectionId, new(ColorCollection))
+func (s *ColorCollectionResourceOp) Get(ctx context.Context, ColorCollectionId ID, opt *GetOptions) (*ColorCollection, *Response, error) {
+	ctx = withRoute(ctx, "color_collections/{collection_id}")
+	return doGetById(ctx, s.client, ColorCollectionBasePath, ColorCollectionId, opt, new(ColorCollection))
 }
 
 func (s *ColorCollectionResourceOp) Create(ctx context.Context, requestColorCollection *WriteColorCollection) (*ColorCollection, *Response, error) {
+	ctx = withRoute(ctx, "color_collections")
 	return doCreate(ctx, s.client, ColorCollectionBasePath, requestColorCollection, new(ColorCollection))
 }
 
-func (s *ColorCollectionResourceOp) Update(ctx context.Context, ColorCollectionId string, requestColorCollection *WriteColorCollection) (*ColorCollection, *Response, error) {
+func (s *ColorCollectionResourceOp) Update(ctx context.Context, ColorCollectionId ID, requestColorCollection *WriteColorCollection) (*ColorCollection, *Response, error) {
+	ctx = withRoute(ctx, "color_collections/{collection_id}")
 	return doUpdate(ctx, s.client, ColorCollectionBasePath, ColorCollectionId, requestColorCollection, new(ColorCollection))
 }
 
-func (s *ColorCollectionResourceOp) Delete(ctx context.Context, ColorCollectionId string) (*Response, error) {
+func (s *ColorCollectionResourceOp) Delete(ctx context.Context, ColorCollectionId ID) (*Response, error) {
+	ctx = withRoute(ctx, "color_collections/{collection_id}")
 	return doDelete(ctx, s.client, ColorCollectionBasePath, ColorCollectionId)
 }
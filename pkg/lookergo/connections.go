@@ -8,12 +8,12 @@ import (
 	"strings"
 )
 
-const connectionsBasePath = "4.0/connections"
+const connectionsBasePath = "connections"
 
 type ConnectionsResource interface {
-	Get(ctx context.Context, connectionName string) (*DBConnection, *Response, error)
+	Get(ctx context.Context, connectionName string, opt *GetOptions) (*DBConnection, *Response, error)
 	Create(ctx context.Context, connection *DBConnection) (*DBConnection, *Response, error)
-	Update(ctx context.Context, connectionName string, connection *DBConnection) (*DBConnection, *Response, error)
+	Update(ctx context.Context, connectionName string, connection *WriteDBConnection) (*DBConnection, *Response, error)
 	Delete(ctx context.Context, connectionName string) (*Response, error)
 	ValidateConfig(ctx context.Context, connection *DBConnection) ([]DBConnectionValidation, *Response, error)
 	ValidateConnection(ctx context.Context, connectionName string, tests []string) ([]DBConnectionValidation, *Response, error)
@@ -95,8 +95,8 @@ type DBConnection struct {
 	SqlWritingWithInfoSchema *bool `json:"sql_writing_with_info_schema,omitempty"`
 	// SQL statements (semicolon separated) to issue after connecting to the database. Requires `custom_after_connect_statements` license feature
 	AfterConnectStatements string `json:"after_connect_statements,omitempty"`
-	// Not implemented
-	// PdtContextOverride DBConnectionOverride `json:"pdt_context_override,omitempty"`
+	// Connection settings overridden to build PDTs
+	PdtContextOverride *DBConnectionOverride `json:"pdt_context_override,omitempty"`
 	// Is this connection created and managed by Looker
 	Managed *bool `json:"managed,omitempty"`
 	// The Id of the ssh tunnel this connection uses
@@ -115,6 +115,79 @@ type DBConnection struct {
 	PdtApiControlEnabled *bool `json:"pdt_api_control_enabled,omitempty"`
 }
 
+// WriteDBConnection is the body of a connection update. Fields left nil are not changed.
+// Ref: https://developers.looker.com/api/explorer/4.0/types/Connection/WriteDBConnection
+type WriteDBConnection struct {
+	// Name of the connection. Also used as the unique identifier
+	Name *Nullable[string] `json:"name,omitempty"`
+	// True if PDTs are enabled on this connection
+	PdtsEnabled *Nullable[bool] `json:"pdts_enabled,omitempty"`
+	// Host name/address of server
+	Host *Nullable[string] `json:"host,omitempty"`
+	// Port number on server
+	Port *Nullable[string] `json:"port,omitempty"`
+	// Username for server authentication
+	Username *Nullable[string] `json:"username,omitempty"`
+	// (Write-Only) Password for server authentication
+	Password *Nullable[string] `json:"password,omitempty"`
+	// Whether the connection uses OAuth for authentication.
+	UsesOauth *Nullable[bool] `json:"uses_oauth,omitempty"`
+	// (Write-Only) Base64 encoded Certificate body for server authentication (when appropriate for dialect).
+	Certificate *Nullable[string] `json:"certificate,omitempty"`
+	// (Write-Only) Certificate keyfile type - .json or .p12
+	FileType *Nullable[string] `json:"file_type,omitempty"`
+	// Database name
+	Database *Nullable[string] `json:"database,omitempty"`
+	// Time zone of database
+	DbTimezone *Nullable[string] `json:"db_timezone,omitempty"`
+	// Timezone to use in queries
+	QueryTimezone *Nullable[string] `json:"query_timezone,omitempty"`
+	// Scheme name
+	Schema *Nullable[string] `json:"schema,omitempty"`
+	// Maximum number of concurrent connection to use
+	MaxConnections *Nullable[int64] `json:"max_connections,omitempty"`
+	// Maximum size of query in GBs (BigQuery only, can be a user_attribute name)
+	MaxBillingGigabytes *Nullable[string] `json:"max_billing_gigabytes,omitempty"`
+	// Use SSL/TLS when connecting to server
+	Ssl *Nullable[bool] `json:"ssl,omitempty"`
+	// Verify the SSL
+	VerifySsl *Nullable[bool] `json:"verify_ssl,omitempty"`
+	// Name of temporary database (if used)
+	TmpDbName *Nullable[string] `json:"tmp_db_name,omitempty"`
+	// Additional params to add to JDBC connection string
+	JdbcAdditionalParams *Nullable[string] `json:"jdbc_additional_params,omitempty"`
+	// Connection Pool Timeout, in seconds
+	PoolTimeout *Nullable[int64] `json:"pool_timeout,omitempty"`
+	// (Read/Write) SQL Dialect name
+	DialectName *Nullable[string] `json:"dialect_name,omitempty"`
+	// (Limited access feature) Are per user db credentials enabled. Enabling will remove previously set username and password
+	UserDbCredentials *Nullable[bool] `json:"user_db_credentials,omitempty"`
+	// Fields whose values map to user attribute names
+	UserAttributeFields *Nullable[[]string] `json:"user_attribute_fields,omitempty"`
+	// Cron string specifying when maintenance such as PDT trigger checks and drops should be performed
+	MaintenanceCron *Nullable[string] `json:"maintenance_cron,omitempty"`
+	// Precache tables in the SQL Runner
+	SqlRunnerPrecacheTables *Nullable[bool] `json:"sql_runner_precache_tables,omitempty"`
+	// Fetch Information Schema For SQL Writing
+	SqlWritingWithInfoSchema *Nullable[bool] `json:"sql_writing_with_info_schema,omitempty"`
+	// SQL statements (semicolon separated) to issue after connecting to the database. Requires `custom_after_connect_statements` license feature
+	AfterConnectStatements *Nullable[string] `json:"after_connect_statements,omitempty"`
+	// The Id of the ssh tunnel this connection uses
+	TunnelId *Nullable[string] `json:"tunnel_id,omitempty"`
+	// Maximum number of threads to use to build PDTs in parallel
+	PdtConcurrency *Nullable[int64] `json:"pdt_concurrency,omitempty"`
+	// When disable_context_comment is true comment will not be added to SQL
+	DisableContextComment *Nullable[bool] `json:"disable_context_comment,omitempty"`
+	// An External OAuth Application to use for authenticating to the database
+	OauthApplicationId *Nullable[string] `json:"oauth_application_id,omitempty"`
+	// When true, error PDTs will be retried every regenerator cycle
+	AlwaysRetryFailedBuilds *Nullable[bool] `json:"always_retry_failed_builds,omitempty"`
+	// When true, query cost estimate will be displayed in explore.
+	CostEstimateEnabled *Nullable[bool] `json:"cost_estimate_enabled,omitempty"`
+	// PDT builds on this connection can be kicked off and cancelled via API.
+	PdtApiControlEnabled *Nullable[bool] `json:"pdt_api_control_enabled,omitempty"`
+}
+
 type DBDialect struct {
 	// The name of the dialect
 	Name string `json:"name,omitempty"`
@@ -179,27 +252,31 @@ type DBConnectionValidation struct {
 
 // </editor-fold>
 
-func (s ConnectionsResourceOp) Get(ctx context.Context, connectionName string) (*DBConnection, *Response, error) {
-	return doGet(ctx, s.client, connectionsBasePath, new(DBConnection), url.QueryEscape(connectionName))
+func (s ConnectionsResourceOp) Get(ctx context.Context, connectionName string, opt *GetOptions) (*DBConnection, *Response, error) {
+	ctx = withRoute(ctx, "connections/{connection_name}")
+	return doGet(ctx, s.client, connectionsBasePath, opt, new(DBConnection), url.QueryEscape(connectionName))
 }
 
 func (s ConnectionsResourceOp) Create(ctx context.Context, connection *DBConnection) (*DBConnection, *Response, error) {
+	ctx = withRoute(ctx, "connections")
 	return doCreate(ctx, s.client, connectionsBasePath, connection, new(DBConnection))
 }
 
-func (s ConnectionsResourceOp) Update(ctx context.Context, connectionName string, connection *DBConnection) (*DBConnection, *Response, error) {
+func (s ConnectionsResourceOp) Update(ctx context.Context, connectionName string, connection *WriteDBConnection) (*DBConnection, *Response, error) {
+	ctx = withRoute(ctx, "connections/{connection_name}")
 	return doUpdate(ctx, s.client, connectionsBasePath, url.QueryEscape(connectionName), connection, new(DBConnection))
 }
 
 func (s ConnectionsResourceOp) Delete(ctx context.Context, connectionName string) (*Response, error) {
-
+	ctx = withRoute(ctx, "connections/{connection_name}")
 	return doDelete(ctx, s.client, connectionsBasePath, url.QueryEscape(connectionName))
 }
 
 func (s ConnectionsResourceOp) ValidateConfig(ctx context.Context, connection *DBConnection) (dbcv []DBConnectionValidation, resp *Response, err error) {
+	ctx = withRoute(ctx, "connections/test")
 	path := fmt.Sprintf("%s%s", connectionsBasePath, strings.Join(append([]string{""}, "test"), "/"))
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, connection)
+	req, err := s.client.newAPIRequest(ctx, http.MethodPut, path, connection)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -215,6 +292,7 @@ func (s ConnectionsResourceOp) ValidateConfig(ctx context.Context, connection *D
 // ValidateConnection -
 // Possible for most db's: first do connect test; next do kill,query test
 func (s ConnectionsResourceOp) ValidateConnection(ctx context.Context, connectionName string, tests []string) (dbcv []DBConnectionValidation, resp *Response, err error) {
+	ctx = withRoute(ctx, "connections/{connection_name}")
 	if len(tests) == 0 {
 		tests = []string{"connect"}
 	}
@@ -222,7 +300,7 @@ func (s ConnectionsResourceOp) ValidateConnection(ctx context.Context, connectio
 		connectionsBasePath,
 		connectionName, url.QueryEscape(strings.Join(tests, ",")))
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, nil)
+	req, err := s.client.newAPIRequest(ctx, http.MethodPut, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
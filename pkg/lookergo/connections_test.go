@@ -102,16 +102,57 @@ func TestConnectionsResourceOp_Get(t *testing.T) {
 }`)
 	})
 
-	result, resp, err := client.Connections.Get(ctx, "testingpsql")
+	result, resp, err := client.Connections.Get(ctx, "testingpsql", nil)
 	_ = resp
 	if err != nil {
 		t.Errorf("Connections.Get returned error: %v", err)
 	}
 
 	expected := &DBConnection{
-		Name:        "testingpsql",
-		Dialect:     &DBDialect{Name: "postgres"},
-		DialectName: "postgres",
+		Name: "testingpsql",
+		Dialect: &DBDialect{
+			Name:                              "postgres",
+			Label:                             "PostgreSQL 9.5+",
+			SupportsCostEstimate:              boolPtr(true),
+			CostEstimateStyle:                 "configurable",
+			PersistentTableIndexes:            "explicit",
+			SupportsStreaming:                 boolPtr(true),
+			AutomaticallyRunSqlRunnerSnippets: boolPtr(true),
+			ConnectionTests: []string{
+				"connect", "kill", "query", "database_timezone", "database_version", "tmp_db",
+				"cdt", "tmp_db_views",
+			},
+			SupportsInducer:                 boolPtr(false),
+			SupportsMultipleDatabases:       boolPtr(false),
+			SupportsPersistentDerivedTables: boolPtr(true),
+			HasSslSupport:                   boolPtr(true),
+		},
+		Snippets: []Snippet{
+			{Name: "show_processes", Label: "Show Processes", Sql: "SELECT * FROM pg_stat_activity"},
+		},
+		PdtsEnabled:              boolPtr(false),
+		Host:                     "surus.db.elephantsql.com",
+		Port:                     "5432",
+		Username:                 "hegdgxme",
+		UsesOauth:                boolPtr(false),
+		Database:                 "hegdgxme",
+		MaxConnections:           5,
+		Ssl:                      boolPtr(true),
+		VerifySsl:                boolPtr(false),
+		PoolTimeout:              120,
+		DialectName:              "postgres",
+		CreatedAt:                "2022-06-14T12:26:50.000+00:00",
+		UserId:                   "123",
+		Example:                  boolPtr(false),
+		UserAttributeFields:      []string{},
+		LastRegenAt:              "1655254550",
+		SqlRunnerPrecacheTables:  boolPtr(true),
+		SqlWritingWithInfoSchema: boolPtr(false),
+		Managed:                  boolPtr(false),
+		PdtConcurrency:           1,
+		DisableContextComment:    boolPtr(false),
+		CostEstimateEnabled:      boolPtr(false),
+		PdtApiControlEnabled:     boolPtr(false),
 	}
 	if !reflect.DeepEqual(result, expected) {
 		t.Error(errGotWant("ModelSets.List", result, expected))
@@ -0,0 +1,122 @@
+package lookergo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// CredentialProcessTimeout is the time limit of a run of a credential process.
+const CredentialProcessTimeout = time.Minute
+
+// ProcessCredentials is the JSON a credential process writes to its standard output. It holds either an access
+// token, or API client credentials which the client logs in with, e.g.
+//
+//	{"access_token": "abc", "expires_in": 3600}
+//	{"client_id": "abc", "client_secret": "xyz"}
+type ProcessCredentials struct {
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	AccessToken  string `json:"access_token,omitempty"`
+	// Lifetime of AccessToken in seconds, as returned by the login endpoint.
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+	// Expiry of AccessToken, in RFC 3339 format. Without it and ExpiresIn, the token is used until the end.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (pc *ProcessCredentials) validate() error {
+	switch {
+	case pc.AccessToken != "" && (pc.ClientID != "" || pc.ClientSecret != ""):
+		return errors.New("access_token cannot be combined with client_id and client_secret")
+	case pc.AccessToken != "":
+		return nil
+	case pc.ClientID == "" || pc.ClientSecret == "":
+		return errors.New("the output has neither an access_token nor a client_id and a client_secret")
+	}
+	return nil
+}
+
+// CredentialProcessError reports a credential process which failed or printed invalid credentials. Requests failing
+// with it are not retried.
+type CredentialProcessError struct {
+	// Program of the command.
+	Command string
+	Err     error
+}
+
+var _ error = &CredentialProcessError{}
+
+func (e *CredentialProcessError) Error() string {
+	return fmt.Sprintf("credential process %s: %v", e.Command, e.Err)
+}
+
+func (e *CredentialProcessError) Unwrap() error {
+	return e.Err
+}
+
+// setCredentialProcess authenticates the requests of the client with the credentials printed by command, see
+// WithCredentialProcess.
+func (c *Client) setCredentialProcess(ctx context.Context, command []string) {
+	c.setTokenSource(&processTokenSource{client: c, ctx: ctx, command: append([]string(nil), command...)})
+}
+
+// processTokenSource is the oauth2.TokenSource of WithCredentialProcess. Concurrent calls are serialized by the
+// reuseTokenSource wrapping it, see setTokenSource.
+type processTokenSource struct {
+	client  *Client
+	ctx     context.Context
+	command []string
+}
+
+// Token implements oauth2.TokenSource.
+func (s *processTokenSource) Token() (*oauth2.Token, error) {
+	creds, err := s.run()
+	if err != nil {
+		return nil, err
+	}
+
+	if creds.AccessToken == "" {
+		return s.client.loginConfig(creds.ClientID, creds.ClientSecret).Token(s.client.loginContext(s.ctx))
+	}
+	token := &oauth2.Token{AccessToken: creds.AccessToken, TokenType: "Bearer"}
+	switch {
+	case creds.ExpiresAt != nil:
+		token.Expiry = *creds.ExpiresAt
+	case creds.ExpiresIn > 0:
+		token.Expiry = time.Now().Add(time.Duration(creds.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// run runs the command and decodes its output.
+func (s *processTokenSource) run() (*ProcessCredentials, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, CredentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, &CredentialProcessError{Command: s.command[0], Err: err}
+	}
+
+	creds := new(ProcessCredentials)
+	if err := json.Unmarshal(stdout.Bytes(), creds); err != nil {
+		// The output holds secrets: only the error is reported.
+		return nil, &CredentialProcessError{Command: s.command[0], Err: fmt.Errorf("invalid output: %w", err)}
+	}
+	if err := creds.validate(); err != nil {
+		return nil, &CredentialProcessError{Command: s.command[0], Err: err}
+	}
+	return creds, nil
+}
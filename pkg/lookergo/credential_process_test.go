@@ -0,0 +1,133 @@
+package lookergo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestCredentialProcessHelper is the credential process run by the tests: it prints LOOKERGO_TEST_CREDENTIALS, or
+// fails with the message LOOKERGO_TEST_CREDENTIALS_ERROR, and appends a line to LOOKERGO_TEST_CREDENTIALS_RUNS.
+// It does nothing when run as a test.
+func TestCredentialProcessHelper(t *testing.T) {
+	out, ok := os.LookupEnv("LOOKERGO_TEST_CREDENTIALS")
+	if !ok {
+		return
+	}
+	if f, err := os.OpenFile(os.Getenv("LOOKERGO_TEST_CREDENTIALS_RUNS"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600); err == nil {
+		fmt.Fprintln(f, "run")
+		f.Close()
+	}
+	if msg := os.Getenv("LOOKERGO_TEST_CREDENTIALS_ERROR"); msg != "" {
+		fmt.Fprintln(os.Stderr, msg)
+		os.Exit(3)
+	}
+	fmt.Print(out)
+	os.Exit(0)
+}
+
+// setupCredentialProcess makes the helper process print output, and returns a client running it and the number of
+// runs so far.
+func setupCredentialProcess(t *testing.T, output, failure string) (*Client, func() int) {
+	runs := filepath.Join(t.TempDir(), "runs")
+	t.Setenv("LOOKERGO_TEST_CREDENTIALS", output)
+	t.Setenv("LOOKERGO_TEST_CREDENTIALS_ERROR", failure)
+	t.Setenv("LOOKERGO_TEST_CREDENTIALS_RUNS", runs)
+
+	c, err := New(
+		WithBaseURL(server.URL+"/api/"),
+		WithCredentialProcess(os.Args[0], "-test.run=^TestCredentialProcessHelper$"),
+		WithLimiter(nil),
+	)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return c, func() int {
+		b, _ := os.ReadFile(runs)
+		return strings.Count(string(b), "run")
+	}
+}
+
+func TestClient_credentialProcess(t *testing.T) {
+	tests := map[string]struct {
+		output       string
+		expectedRuns int
+	}{
+		"access token":         {`{"access_token":"proc-token","expires_in":3600}`, 1},
+		"expired access token": {`{"access_token":"proc-token","expires_at":"2000-01-01T00:00:00Z"}`, 2},
+		"client credentials":   {`{"client_id":"proc-id","client_secret":"proc-secret"}`, 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			var logins int32
+			mux.HandleFunc("/api/4.0/login", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodPost)
+				_ = r.ParseForm()
+				if r.Form.Get("client_id") != "proc-id" || r.Form.Get("client_secret") != "proc-secret" {
+					t.Errorf("login with %q, expected the credentials printed by the process", r.Form.Get("client_id"))
+				}
+				atomic.AddInt32(&logins, 1)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"access_token":"proc-token","token_type":"Bearer","expires_in":3600}`)
+			})
+			mux.HandleFunc("/api/4.0/user", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer proc-token" {
+					t.Errorf("Authorization = %q, expected the token of the process", got)
+				}
+				fmt.Fprint(w, `{"id":"5"}`)
+			})
+
+			c, runs := setupCredentialProcess(t, tt.output, "")
+			for i := 0; i < 2; i++ {
+				if _, _, err := c.Sessions.GetCurrentUser(ctx); err != nil {
+					t.Fatalf("GetCurrentUser returned error: %v", err)
+				}
+			}
+			if runs() != tt.expectedRuns {
+				t.Errorf("process run %d times, expected %d", runs(), tt.expectedRuns)
+			}
+			if strings.Contains(tt.output, "client_id") && atomic.LoadInt32(&logins) != 1 {
+				t.Errorf("%d logins, expected 1", atomic.LoadInt32(&logins))
+			}
+		})
+	}
+}
+
+func TestClient_credentialProcess_errors(t *testing.T) {
+	tests := map[string]struct {
+		output, failure, expected string
+	}{
+		"failure":        {`{}`, "vault is sealed", "vault is sealed"},
+		"invalid output": {`token`, "", "invalid output"},
+		"no credentials": {`{"client_id":"proc-id"}`, "", "neither an access_token"},
+		"both":           {`{"access_token":"a","client_id":"b","client_secret":"c"}`, "", "cannot be combined"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setup()
+			defer teardown()
+			mux.HandleFunc("/api/4.0/user", func(w http.ResponseWriter, r *http.Request) {
+				t.Error("request sent without credentials")
+			})
+
+			c, runs := setupCredentialProcess(t, tt.output, tt.failure)
+			_, _, err := c.Sessions.GetCurrentUser(ctx)
+			var processErr *CredentialProcessError
+			if !errors.As(err, &processErr) || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("GetCurrentUser returned %v, expected a *CredentialProcessError with %q", err, tt.expected)
+			}
+			// Not retried.
+			if runs() != 1 {
+				t.Errorf("process run %d times, expected 1", runs())
+			}
+		})
+	}
+}
@@ -1,6 +1,13 @@
 package lookergo
 
-import "fmt"
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
 
 // ArgError is an error that represents an error with an input to pkg. It
 // identifies the argument and the cause (if possible).
@@ -22,3 +29,68 @@ func NewArgError(arg, reason string) *ArgError {
 func (e *ArgError) Error() string {
 	return fmt.Sprintf("%s is invalid because %s", e.arg, e.reason)
 }
+
+// IsNotFound reports whether err, or an error it wraps, is an API response with status 404 Not Found.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an API response with status 409 Conflict, e.g. a name already in use.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsValidation reports whether err is an API response rejecting the request body: 400 Bad Request or
+// 422 Unprocessable Entity. ErrorResponse.Errors then tells which fields were rejected.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsUnauthorized reports whether err is an API response with status 401 Unauthorized, or a failure to
+// obtain an access token with the configured credentials.
+func IsUnauthorized(err error) bool {
+	var tokenErr *oauth2.RetrieveError
+	if errors.As(err, &tokenErr) {
+		return tokenErr.Response != nil && tokenErr.Response.StatusCode >= 400 && tokenErr.Response.StatusCode < 500
+	}
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsRateLimited reports whether err is an API response with status 429 Too Many Requests.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// isPermanent reports whether err, returned by an attempt to send a request, would be returned again by the next
+// attempt.
+func isPermanent(err error) bool {
+	// Bad credentials won't get any better.
+	var tokenErr *oauth2.RetrieveError
+	var processErr *CredentialProcessError
+	if errors.As(err, &tokenErr) || errors.As(err, &processErr) {
+		return true
+	}
+	// Nor will a logged out client, or a token of AsUser the API refused: minting it was retried already.
+	var errResp *ErrorResponse
+	if errors.Is(err, ErrLoggedOut) || errors.As(err, &errResp) {
+		return true
+	}
+	// Nor will a certificate the client does not trust.
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostnameErr) || errors.As(err, &invalidCert)
+}
+
+func hasStatus(err error, codes ...int) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+	for _, code := range codes {
+		if errResp.Response.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package lookergo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestErrorClassification(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retryPolicy = RetryPolicy{MaxAttempts: 1}
+
+	mux.HandleFunc("/4.0/groups/404", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found","documentation_url":"https://docs.looker.com/"}`)
+	})
+	mux.HandleFunc("/4.0/groups/409", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	mux.HandleFunc("/4.0/groups/422", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc123")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"Validation Failed","errors":[{"field":"name","code":"missing","message":"This field is required."}]}`)
+	})
+	mux.HandleFunc("/4.0/groups/401", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/4.0/groups/429", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	checks := map[int]func(error) bool{
+		404: IsNotFound,
+		409: IsConflict,
+		422: IsValidation,
+		401: IsUnauthorized,
+		429: IsRateLimited,
+	}
+	for status := range checks {
+		_, _, err := client.Groups.Get(ctx, ID(strconv.Itoa(status)), nil)
+		if err == nil {
+			t.Fatalf("Groups.Get(%d) returned no error", status)
+		}
+		for other, otherCheck := range checks {
+			if got := otherCheck(fmt.Errorf("wrapped: %w", err)); got != (other == status) {
+				t.Errorf("status %d: check for %d = %v", status, other, got)
+			}
+		}
+	}
+
+	_, _, err := client.Groups.Get(ctx, "422", nil)
+	errResp := err.(*ErrorResponse)
+	if errResp.RequestID != "abc123" || len(errResp.Errors) != 1 || errResp.Errors[0].Field != "name" {
+		t.Errorf("unexpected error response %+v", errResp)
+	}
+	if !strings.Contains(err.Error(), "name: This field is required.") || !strings.Contains(err.Error(), "abc123") {
+		t.Errorf("Error() = %q, expected field errors and request id", err.Error())
+	}
+
+	if IsNotFound(nil) || IsNotFound(fmt.Errorf("boom")) {
+		t.Errorf("IsNotFound matched a non-API error")
+	}
+}
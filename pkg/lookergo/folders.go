@@ -7,16 +7,16 @@ import (
 	"time"
 )
 
-const FoldersBasePath = "4.0/folders"
+const FoldersBasePath = "folders"
 
 type FoldersResource interface {
 	List(context.Context, *ListOptions) ([]Folder, *Response, error)
 	ListByName(context.Context, string, *ListOptions) ([]Folder, *Response, error)
-	Get(context.Context, string) (*Folder, *Response, error)
+	Get(context.Context, ID, *GetOptions) (*Folder, *Response, error)
 	//Get(context.Context,*ListOptions, string) ([]Folder, *Response, error)
 	Create(context.Context, *Folder) (*Folder, *Response, error)
-	Update(context.Context, string, *Folder) (*Folder, *Response, error)
-	Delete(context.Context, string) (*Response, error)
+	Update(context.Context, ID, *WriteFolder) (*Folder, *Response, error)
+	Delete(context.Context, ID) (*Response, error)
 }
 
 type FoldersResourceOp struct {
@@ -32,8 +32,8 @@ type LookModel struct {
 
 type FolderBase struct {
 	Name                 string          `json:"name"`                             // Unique Name
-	ParentId             string          `json:"parent_id,omitempty"`              // Id of Parent. If the parent id is null, this is a root-level entry
-	Id                   string          `json:"id,omitempty"`                     // Unique Id
+	ParentId             ID              `json:"parent_id,omitempty"`              // Id of Parent. If the parent id is null, this is a root-level entry
+	Id                   ID              `json:"id,omitempty"`                     // Unique Id
 	ContentMetadataId    string          `json:"content_metadata_id,omitempty"`    // Id of content metadata
 	CreatedAt            time.Time       `json:"created_at,omitempty"`             // Time the folder was created
 	CreatorId            string          `json:"creator_id,omitempty"`             // User Id of Creator
@@ -55,7 +55,7 @@ type DashboardBase struct {
 	ContentMetadataId  string          `json:"content_metadata_id,omitempty"` // Id of content metadata
 	Description        string          `json:"description,omitempty"`         // Description
 	Hidden             bool            `json:"hidden,omitempty"`              // Is Hidden
-	Id                 string          `json:"id,omitempty"`                  // Unique Id
+	Id                 ID              `json:"id,omitempty"`                  // Unique Id
 	Model              *LookModel      `json:"model,omitempty"`
 	QueryTimezone      string          `json:"query_timezone,omitempty"`        // Timezone in which the Dashboard will run by default.
 	Readonly           bool            `json:"readonly,omitempty"`              // Is Read-only
@@ -89,7 +89,7 @@ type Dashboard struct {
 type LookWithDashboards struct {
 	Can                      *map[string]bool `json:"can,omitempty"`                        // Operations the current user is able to perform on this object
 	ContentMetadataId        string           `json:"content_metadata_id,omitempty"`        // Id of content metadata
-	Id                       string           `json:"id,omitempty"`                         // Unique Id
+	Id                       ID               `json:"id,omitempty"`                         // Unique Id
 	Title                    string           `json:"title,omitempty"`                      // Look Title
 	UserId                   string           `json:"user_id,omitempty"`                    // User Id
 	ContentFavoriteId        string           `json:"content_favorite_id,omitempty"`        // Content Favorite Id
@@ -122,8 +122,8 @@ type LookWithDashboards struct {
 
 type Folder struct {
 	Name                 string                `json:"name"`                             // Unique Name
-	ParentId             string                `json:"parent_id,omitempty"`              // Id of Parent. If the parent id is null, this is a root-level entry
-	Id                   string                `json:"id,omitempty"`                     // Unique Id
+	ParentId             ID                    `json:"parent_id,omitempty"`              // Id of Parent. If the parent id is null, this is a root-level entry
+	Id                   ID                    `json:"id,omitempty"`                     // Unique Id
 	ContentMetadataId    string                `json:"content_metadata_id,omitempty"`    // Id of content metadata
 	CreatedAt            *time.Time            `json:"created_at,omitempty"`             // Time the space was created
 	CreatorId            string                `json:"creator_id,omitempty"`             // User Id of Creator
@@ -141,11 +141,19 @@ type Folder struct {
 	Looks                *[]LookWithDashboards `json:"looks,omitempty"`                  // Looks
 }
 
+// WriteFolder is the body of a folder update. Fields left nil are not changed.
+type WriteFolder struct {
+	Name     *Nullable[string] `json:"name,omitempty"`      // Unique Name
+	ParentId *Nullable[ID]     `json:"parent_id,omitempty"` // Id of Parent
+}
+
 func (s *FoldersResourceOp) List(ctx context.Context, opt *ListOptions) ([]Folder, *Response, error) {
+	ctx = withRoute(ctx, "folders")
 	return doList(ctx, s.client, FoldersBasePath, opt, new([]Folder))
 }
 
 func (s *FoldersResourceOp) ListByName(ctx context.Context, name string, opt *ListOptions) ([]Folder, *Response, error) {
+	ctx = withRoute(ctx, "folders/search")
 	if name == "" {
 		return nil, nil, NewArgError("name", "has to be non-empty")
 	}
@@ -158,18 +166,22 @@ func (s *FoldersResourceOp) ListByName(ctx context.Context, name string, opt *Li
 	return doListByX(ctx, s.client, path, opt, new([]Folder), qs)
 }
 
-func (s *FoldersResourceOp) Get(ctx context.Context, FolderId string) (*Folder, *Response, error) {
-	return doGetById(ctx, s.client, FoldersBasePath, FolderId, new(Folder))
+func (s *FoldersResourceOp) Get(ctx context.Context, FolderId ID, opt *GetOptions) (*Folder, *Response, error) {
+	ctx = withRoute(ctx, "folders/{folder_id}")
+	return doGetById(ctx, s.client, FoldersBasePath, FolderId, opt, new(Folder))
 }
 
 func (s *FoldersResourceOp) Create(ctx context.Context, requestFolder *Folder) (*Folder, *Response, error) {
+	ctx = withRoute(ctx, "folders")
 	return doCreate(ctx, s.client, FoldersBasePath, requestFolder, new(Folder))
 }
 
-func (s *FoldersResourceOp) Update(ctx context.Context, FolderId string, requestFolder *Folder) (*Folder, *Response, error) {
+func (s *FoldersResourceOp) Update(ctx context.Context, FolderId ID, requestFolder *WriteFolder) (*Folder, *Response, error) {
+	ctx = withRoute(ctx, "folders/{folder_id}")
 	return doUpdate(ctx, s.client, FoldersBasePath, FolderId, requestFolder, new(Folder))
 }
 
-func (s *FoldersResourceOp) Delete(ctx context.Context, FolderId string) (*Response, error) {
+func (s *FoldersResourceOp) Delete(ctx context.Context, FolderId ID) (*Response, error) {
+	ctx = withRoute(ctx, "folders/{folder_id}")
 	return doDelete(ctx, s.client, FoldersBasePath, FolderId)
 }
@@ -0,0 +1,5 @@
+package lookergo
+
+// The models and services listed in spec/lookergen.json are generated from the specification of the Looker API,
+// see spec/README.md.
+//go:generate go run ./internal/lookergen -spec spec/Looker.4.0.json -config spec/lookergen.json -out .
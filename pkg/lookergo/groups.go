@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
-	"strconv"
+	"strings"
 )
 
-const groupBasePath = "4.0/groups"
+const groupBasePath = "groups"
 
 // GroupsResource is an interface for interfacing with the Group resource endpoints of the API.
 // Ref: https://developers.looker.com/api/explorer/4.0/methods/Group
@@ -15,17 +15,17 @@ const groupBasePath = "4.0/groups"
 type GroupsResource interface {
 	List(context.Context, *ListOptions) ([]Group, *Response, error)
 	ListByName(context.Context, string, *ListOptions) ([]Group, *Response, error)
-	ListById(context.Context, []int, *ListOptions) ([]Group, *Response, error)
-	Get(context.Context, int) (*Group, *Response, error)
+	ListById(context.Context, []ID, *ListOptions) ([]Group, *Response, error)
+	Get(context.Context, ID, *GetOptions) (*Group, *Response, error)
 	Create(context.Context, *Group) (*Group, *Response, error)
-	Update(context.Context, int, *Group) (*Group, *Response, error)
-	Delete(context.Context, int) (*Response, error)
-	ListMemberGroups(context.Context, int, *ListOptions) ([]Group, *Response, error)
-	AddMemberGroup(context.Context, int, int) (*Group, *Response, error)
-	RemoveMemberGroup(context.Context, int, int) (*Response, error)
-	ListMemberUsers(context.Context, int, *ListOptions) ([]User, *Response, error)
-	AddMemberUser(context.Context, int, int) (*User, *Response, error)
-	RemoveMemberUser(context.Context, int, int) (*Response, error)
+	Update(context.Context, ID, *WriteGroup) (*Group, *Response, error)
+	Delete(context.Context, ID) (*Response, error)
+	ListMemberGroups(context.Context, ID, *ListOptions) ([]Group, *Response, error)
+	AddMemberGroup(context.Context, ID, ID) (*Group, *Response, error)
+	RemoveMemberGroup(context.Context, ID, ID) (*Response, error)
+	ListMemberUsers(context.Context, ID, *ListOptions) ([]User, *Response, error)
+	AddMemberUser(context.Context, ID, ID) (*User, *Response, error)
+	RemoveMemberUser(context.Context, ID, ID) (*Response, error)
 }
 
 // GroupsResourceOp handles operations between Group related methods of the API.
@@ -38,30 +38,41 @@ var _ GroupsResource = &GroupsResourceOp{}
 // Group -
 // Ref: https://developers.looker.com/api/explorer/4.0/types/Group/Group
 type Group struct {
-	CanAddToContentMetadata bool            `json:"can_add_to_content_metadata,omitempty"`
-	ExternalGroupId         bool            `json:"external_group_id,omitempty"`
-	Id                      int             `json:"id,string,omitempty"`
-	Name                    string          `json:"name"`
-	UserCount               int             `json:"user_count,omitempty"` // ! not stringified
-	ExternallyManaged       bool            `json:"externally_managed,omitempty"`
-	IncludeByDefault        bool            `json:"include_by_default,omitempty"`
-	ContainsCurrentUser     bool            `json:"contains_current_user,omitempty"`
-	ParentGroupIds          SliceStringInts `json:"parent_group_ids,omitempty"`
-	RoleIds                 SliceStringInts `json:"role_ids,omitempty"`
+	CanAddToContentMetadata bool   `json:"can_add_to_content_metadata,omitempty"`
+	ExternalGroupId         string `json:"external_group_id,omitempty"`
+	Id                      ID     `json:"id,omitempty"`
+	Name                    string `json:"name"`
+	UserCount               int    `json:"user_count,omitempty"` // ! not stringified
+	ExternallyManaged       bool   `json:"externally_managed,omitempty"`
+	IncludeByDefault        bool   `json:"include_by_default,omitempty"`
+	ContainsCurrentUser     bool   `json:"contains_current_user,omitempty"`
+	ParentGroupIds          IDs    `json:"parent_group_ids,omitempty"`
+	RoleIds                 IDs    `json:"role_ids,omitempty"`
+}
+
+// WriteGroup is the body of a group update. Fields left nil are not changed.
+// Ref: https://developers.looker.com/api/explorer/4.0/types/Group/WriteGroup
+type WriteGroup struct {
+	CanAddToContentMetadata *Nullable[bool]   `json:"can_add_to_content_metadata,omitempty"`
+	Name                    *Nullable[string] `json:"name,omitempty"`
 }
 
 // List all groups
 func (s *GroupsResourceOp) List(ctx context.Context, opt *ListOptions) ([]Group, *Response, error) {
+	ctx = withRoute(ctx, "groups")
 	return doList(ctx, s.client, groupBasePath, opt, new([]Group))
 }
 
+// ListByName lists the groups with the given name, with their parent groups. Unless opt.Fields is set, only
+// the fields of Group which identify it and its relations are fetched.
 func (s *GroupsResourceOp) ListByName(ctx context.Context, name string, opt *ListOptions) ([]Group, *Response, error) {
+	ctx = withRoute(ctx, "groups/search/with_hierarchy")
 	if name == "" {
 		return nil, nil, NewArgError("name", "has to be non-empty")
 	}
 
 	qs := url.Values{}
-	qs.Add("fields", "id,name,user_count,role_ids")
+	qs.Add("fields", "id,name,user_count,role_ids,parent_group_ids")
 	qs.Add("name", name)
 
 	path := fmt.Sprintf("%s/search/with_hierarchy", groupBasePath)
@@ -69,21 +80,17 @@ func (s *GroupsResourceOp) ListByName(ctx context.Context, name string, opt *Lis
 	return doListByX(ctx, s.client, path, opt, new([]Group), qs)
 }
 
-func (s *GroupsResourceOp) ListById(ctx context.Context, ids []int, opt *ListOptions) ([]Group, *Response, error) {
+// ListById lists the groups with the given ids, with the same fields as ListByName.
+func (s *GroupsResourceOp) ListById(ctx context.Context, ids []ID, opt *ListOptions) ([]Group, *Response, error) {
+	ctx = withRoute(ctx, "groups/search/with_hierarchy")
 	if len(ids) == 0 {
 		return nil, nil, NewArgError("id", "specify one or more id(s)")
 	}
 
-	var idsQString string
-	for i, id := range ids {
-		idsQString += strconv.Itoa(id)
-		if i+1 < len(ids) {
-			idsQString += ","
-		}
-	}
+	idsQString := strings.Join(IDs(ids).Strings(), ",")
 
 	qs := url.Values{}
-	qs.Add("fields", "id,name,user_count,role_ids,parent_groups")
+	qs.Add("fields", "id,name,user_count,role_ids,parent_group_ids")
 	qs.Add("id", idsQString)
 
 	path := fmt.Sprintf("%s/search/with_hierarchy", groupBasePath)
@@ -92,95 +99,107 @@ func (s *GroupsResourceOp) ListById(ctx context.Context, ids []int, opt *ListOpt
 }
 
 // Get a group by ID.
-func (s *GroupsResourceOp) Get(ctx context.Context, id int) (*Group, *Response, error) {
-	return doGetById(ctx, s.client, groupBasePath, id, new(Group))
+func (s *GroupsResourceOp) Get(ctx context.Context, id ID, opt *GetOptions) (*Group, *Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}")
+	return doGetById(ctx, s.client, groupBasePath, id, opt, new(Group))
 }
 
 // Create a group by ID.
 func (s *GroupsResourceOp) Create(ctx context.Context, createReq *Group) (*Group, *Response, error) {
+	ctx = withRoute(ctx, "groups")
 	return doCreate(ctx, s.client, groupBasePath, createReq, new(Group))
 }
 
 // Update a group by ID.
-func (s *GroupsResourceOp) Update(ctx context.Context, id int, updateReq *Group) (*Group, *Response, error) {
+func (s *GroupsResourceOp) Update(ctx context.Context, id ID, updateReq *WriteGroup) (*Group, *Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}")
 	return doUpdate(ctx, s.client, groupBasePath, id, updateReq, new(Group))
 }
 
 // Delete a group by ID.
-func (s *GroupsResourceOp) Delete(ctx context.Context, id int) (*Response, error) {
+func (s *GroupsResourceOp) Delete(ctx context.Context, id ID) (*Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}")
 	return doDelete(ctx, s.client, groupBasePath, id)
 }
 
 // ListMemberGroups gets all member groups inside a group.
-func (s *GroupsResourceOp) ListMemberGroups(ctx context.Context, id int, opt *ListOptions) ([]Group, *Response, error) {
-	if id < 1 {
-		return nil, nil, NewArgError("id", "cannot be less than 1")
+// The API returns them all at once, ignoring the Limit and Offset of opt: do not page it with ListAll or NewIterator.
+func (s *GroupsResourceOp) ListMemberGroups(ctx context.Context, id ID, opt *ListOptions) ([]Group, *Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}/groups")
+	path, err := idPath(groupBasePath, id, "groups")
+	if err != nil {
+		return nil, nil, err
 	}
 
-	path := fmt.Sprintf("%s/%d/groups", groupBasePath, id)
-
 	return doList(ctx, s.client, path, opt, new([]Group))
 }
 
 type NewGroupMemberGroup struct {
-	GroupID int `json:"group_id"`
+	GroupID ID `json:"group_id"`
 }
 
 // AddMemberGroup -
-func (s *GroupsResourceOp) AddMemberGroup(ctx context.Context, parentID int, memberID int) (*Group, *Response, error) {
-	if parentID < 1 || memberID < 1 {
-		return nil, nil, NewArgError("id", "cannot be less than 1")
+func (s *GroupsResourceOp) AddMemberGroup(ctx context.Context, parentID ID, memberID ID) (*Group, *Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}/groups")
+	if memberID == "" {
+		return nil, nil, NewArgError("memberID", "cannot be empty")
+	}
+	path, err := idPath(groupBasePath, parentID, "groups")
+	if err != nil {
+		return nil, nil, err
 	}
-
-	path := fmt.Sprintf("%s/%d/groups", groupBasePath, parentID)
 
 	return doAddMember(ctx, s.client, path, new(Group), NewGroupMemberGroup{GroupID: memberID})
 }
 
 // RemoveMemberGroup -
-func (s *GroupsResourceOp) RemoveMemberGroup(ctx context.Context, parentID int, memberID int) (*Response, error) {
-	if parentID < 1 || memberID < 1 {
-		return nil, NewArgError("id", "cannot be less than 1")
+func (s *GroupsResourceOp) RemoveMemberGroup(ctx context.Context, parentID ID, memberID ID) (*Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}/groups/{deleting_group_id}")
+	path, err := idPath(groupBasePath, parentID, "groups")
+	if err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("%s/%d/groups", groupBasePath, parentID)
-
 	return doDelete(ctx, s.client, path, memberID)
 }
 
-// ListMemberUsers gets all member groups inside a group.
-func (s *GroupsResourceOp) ListMemberUsers(ctx context.Context, id int, opt *ListOptions) ([]User, *Response, error) {
-	if id < 1 {
-		return nil, nil, NewArgError("id", "cannot be less than 1")
+// ListMemberUsers gets all member users inside a group.
+// The API returns them all at once, ignoring the Limit and Offset of opt: do not page it with ListAll or NewIterator.
+func (s *GroupsResourceOp) ListMemberUsers(ctx context.Context, id ID, opt *ListOptions) ([]User, *Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}/users")
+	path, err := idPath(groupBasePath, id, "users")
+	if err != nil {
+		return nil, nil, err
 	}
 
-	path := fmt.Sprintf("%s/%d/users", groupBasePath, id)
-
 	return doList(ctx, s.client, path, opt, new([]User))
 }
 
 type NewGroupMemberUser struct {
-	UserID int `json:"user_id"`
+	UserID ID `json:"user_id"`
 }
 
 // AddMemberUser -
-func (s *GroupsResourceOp) AddMemberUser(ctx context.Context, parentID int, memberID int) (*User, *Response, error) {
-	if parentID < 1 || memberID < 1 {
-		return nil, nil, NewArgError("id", "cannot be less than 1")
+func (s *GroupsResourceOp) AddMemberUser(ctx context.Context, parentID ID, memberID ID) (*User, *Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}/users")
+	if memberID == "" {
+		return nil, nil, NewArgError("memberID", "cannot be empty")
+	}
+	path, err := idPath(groupBasePath, parentID, "users")
+	if err != nil {
+		return nil, nil, err
 	}
-
-	path := fmt.Sprintf("%s/%d/users", groupBasePath, parentID)
 
 	return doAddMember(ctx, s.client, path, new(User), NewGroupMemberUser{UserID: memberID})
 }
 
 // RemoveMemberUser -
-func (s *GroupsResourceOp) RemoveMemberUser(ctx context.Context, parentID int, memberID int) (*Response, error) {
-	if parentID < 1 || memberID < 1 {
-		return nil, NewArgError("id", "cannot be less than 1")
+func (s *GroupsResourceOp) RemoveMemberUser(ctx context.Context, parentID ID, memberID ID) (*Response, error) {
+	ctx = withRoute(ctx, "groups/{group_id}/users/{user_id}")
+	path, err := idPath(groupBasePath, parentID, "users")
+	if err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("%s/%d/users", groupBasePath, parentID)
-
 	return doDelete(ctx, s.client, path, memberID)
 }
@@ -0,0 +1,83 @@
+package lookergo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ID identifies an object of the API, e.g. a user, a group or a role. Older Looker versions use numeric ids,
+// newer ones opaque strings: ID reads both from JSON, and always sends a string.
+type ID string
+
+// String returns the id as sent to the API.
+func (id ID) String() string {
+	return string(id)
+}
+
+// Int returns the id as a number, for the callers which still need one. It fails on opaque ids.
+func (id ID) Int() (int, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, fmt.Errorf("id %q is not numeric", string(id))
+	}
+	return n, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting strings, numbers and null.
+func (id *ID) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		*id = ""
+		return nil
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*id = ID(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("lookergo: cannot read %s as an id", b)
+	}
+	*id = ID(n.String())
+	return nil
+}
+
+// IDs is a list of ids, e.g. the role_ids of a group.
+type IDs []ID
+
+// Strings returns the ids as strings.
+func (ids IDs) Strings() []string {
+	ret := make([]string, len(ids))
+	for i, id := range ids {
+		ret[i] = string(id)
+	}
+	return ret
+}
+
+// IDsFromStrings returns the ids of s.
+func IDsFromStrings(s []string) IDs {
+	ret := make(IDs, len(s))
+	for i, id := range s {
+		ret[i] = ID(id)
+	}
+	return ret
+}
+
+// idPath returns basePath/id/pathSuffix... It fails on empty ids rather than addressing the collection.
+func idPath[I ~string](basePath string, id I, pathSuffix ...string) (string, error) {
+	if id == "" {
+		return "", NewArgError("id", "cannot be empty")
+	}
+	path := fmt.Sprintf("%s/%s", basePath, string(id))
+	for _, s := range pathSuffix {
+		path += "/" + s
+	}
+	return path, nil
+}
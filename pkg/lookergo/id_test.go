@@ -0,0 +1,93 @@
+package lookergo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestID_unmarshal(t *testing.T) {
+	var g Group
+	if err := json.Unmarshal([]byte(`{"id":12,"parent_group_ids":["3",4,"a1b2"],"role_ids":null}`), &g); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if g.Id != "12" {
+		t.Errorf("Id = %q, expected 12", g.Id)
+	}
+	if expected := (IDs{"3", "4", "a1b2"}); !reflect.DeepEqual(g.ParentGroupIds, expected) {
+		t.Errorf("ParentGroupIds = %v, expected %v", g.ParentGroupIds, expected)
+	}
+	if g.RoleIds != nil {
+		t.Errorf("RoleIds = %v, expected none", g.RoleIds)
+	}
+
+	var id ID
+	if err := json.Unmarshal([]byte(`{}`), &id); err == nil {
+		t.Errorf("Unmarshal({}) = %q, expected an error", id)
+	}
+
+	b, err := json.Marshal(Group{Id: "12", Name: "g"})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if expected := `{"id":"12","name":"g"}`; string(b) != expected {
+		t.Errorf("Marshal = %s, expected %s", b, expected)
+	}
+}
+
+func TestID_Int(t *testing.T) {
+	if n, err := ID("42").Int(); err != nil || n != 42 {
+		t.Errorf("Int() = %v, %v, expected 42", n, err)
+	}
+	if _, err := ID("a1b2").Int(); err == nil {
+		t.Errorf("Int() of an opaque id returned no error")
+	}
+}
+
+func TestID_empty(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/groups/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	var argErr *ArgError
+	if _, _, err := client.Groups.Get(ctx, "", nil); !errors.As(err, &argErr) {
+		t.Errorf("Groups.Get returned %v, expected an ArgError", err)
+	}
+	if _, err := client.Groups.Delete(ctx, ""); !errors.As(err, &argErr) {
+		t.Errorf("Groups.Delete returned %v, expected an ArgError", err)
+	}
+	if _, _, err := client.Roles.RoleGroupsSet(ctx, "", []ID{"1"}); !errors.As(err, &argErr) {
+		t.Errorf("Roles.RoleGroupsSet returned %v, expected an ArgError", err)
+	}
+}
+
+func TestGroupsResourceOp_opaqueID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/groups/a1b2/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["group_id"] != "c3d4" {
+			t.Errorf("group_id = %v, expected c3d4", body["group_id"])
+		}
+		fmt.Fprint(w, `{"id":"c3d4","name":"child"}`)
+	})
+
+	group, _, err := client.Groups.AddMemberGroup(ctx, "a1b2", "c3d4")
+	if err != nil {
+		t.Fatalf("Groups.AddMemberGroup returned error: %v", err)
+	}
+	if group.Id != "c3d4" {
+		t.Errorf("Id = %q, expected c3d4", group.Id)
+	}
+}
@@ -0,0 +1,133 @@
+package lookergo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultIniSection is the section of a looker.ini file read by the Looker SDKs unless told otherwise.
+const DefaultIniSection = "Looker"
+
+// IniConfig holds the settings of a section of a looker.ini file, the configuration file of the Looker SDKs, e.g.
+//
+//	[Looker]
+//	base_url=https://example.cloud.looker.com:19999
+//	client_id=abc
+//	client_secret=xyz
+//	verify_ssl=True
+//	timeout=120
+//
+// Other keys, like the deprecated api_version, are ignored.
+type IniConfig struct {
+	// URL of the API server, without the /api/ path the SDKs add themselves.
+	BaseURL      string
+	ClientID     string
+	ClientSecret string
+	// Whether the certificate of the server is verified, true unless set to a false value.
+	VerifySSL bool
+	// Time limit of a request, zero when not set.
+	Timeout time.Duration
+}
+
+// LoadIniConfig reads the section of the looker.ini file at path.
+func LoadIniConfig(path, section string) (*IniConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg, err := ParseIniConfig(f, section)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseIniConfig reads the section of a looker.ini file. The format is the one of Python's configparser, which the
+// SDKs use: "key=value" or "key: value" lines below a "[section]" header, "#" and ";" comment lines, keys in any case,
+// and values of the DEFAULT section inherited by the other sections. Quotes around values are removed.
+func ParseIniConfig(r io.Reader, section string) (*IniConfig, error) {
+	sections := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header %q", n, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if sections[name] == nil {
+				sections[name] = map[string]string{}
+			}
+			current = sections[name]
+			continue
+		}
+
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d: expected key=value, got %q", n, line)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: %q is outside of a section", n, line)
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:i]))
+		current[key] = unquote(strings.TrimSpace(line[i+1:]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	values, ok := sections[section]
+	if !ok {
+		return nil, fmt.Errorf("section [%s] not found", section)
+	}
+	get := func(key string) string {
+		if v, ok := values[key]; ok {
+			return v
+		}
+		return sections["DEFAULT"][key]
+	}
+
+	cfg := &IniConfig{
+		BaseURL:      get("base_url"),
+		ClientID:     get("client_id"),
+		ClientSecret: get("client_secret"),
+		VerifySSL:    true,
+	}
+	if v := get("verify_ssl"); v != "" {
+		switch strings.ToLower(v) {
+		case "true", "t", "yes", "y", "on", "1":
+		case "false", "f", "no", "n", "off", "0":
+			cfg.VerifySSL = false
+		default:
+			return nil, fmt.Errorf("[%s] verify_ssl: %q is not a boolean", section, v)
+		}
+	}
+	if v := get("timeout"); v != "" {
+		secs, err := strconv.Atoi(v)
+		if err != nil || secs < 0 {
+			return nil, fmt.Errorf("[%s] timeout: %q is not a number of seconds", section, v)
+		}
+		cfg.Timeout = time.Duration(secs) * time.Second
+	}
+	return cfg, nil
+}
+
+// unquote removes the quotes around a value, as the SDKs do.
+func unquote(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
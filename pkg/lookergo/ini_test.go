@@ -0,0 +1,97 @@
+package lookergo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testIni = `
+# Settings of the Python SDK.
+[DEFAULT]
+timeout=120
+
+[Looker]
+base_url=https://example.cloud.looker.com:19999
+client_id = "abc"
+CLIENT_SECRET: 'xyz'
+verify_ssl=True
+api_version=4.0
+
+; Self-hosted staging instance.
+[Staging]
+base_url=https://looker.staging.example.com
+client_id=def
+client_secret=uvw
+verify_ssl=False
+timeout=30
+`
+
+func TestParseIniConfig(t *testing.T) {
+	tests := map[string]IniConfig{
+		"Looker": {
+			BaseURL:      "https://example.cloud.looker.com:19999",
+			ClientID:     "abc",
+			ClientSecret: "xyz",
+			VerifySSL:    true,
+			Timeout:      120 * time.Second,
+		},
+		"Staging": {
+			BaseURL:      "https://looker.staging.example.com",
+			ClientID:     "def",
+			ClientSecret: "uvw",
+			VerifySSL:    false,
+			Timeout:      30 * time.Second,
+		},
+	}
+	for section, expected := range tests {
+		cfg, err := ParseIniConfig(strings.NewReader(testIni), section)
+		if err != nil {
+			t.Fatalf("ParseIniConfig(%s) returned error: %v", section, err)
+		}
+		if !reflect.DeepEqual(*cfg, expected) {
+			t.Errorf("ParseIniConfig(%s) = %+v, expected %+v", section, *cfg, expected)
+		}
+	}
+}
+
+func TestParseIniConfig_errors(t *testing.T) {
+	tests := map[string]string{
+		"missing section":    "[Other]\nbase_url=https://example.com\n",
+		"outside of section": "base_url=https://example.com\n[Looker]\n",
+		"bad header":         "[Looker\n",
+		"no value":           "[Looker]\nbase_url\n",
+		"bad verify_ssl":     "[Looker]\nverify_ssl=maybe\n",
+		"bad timeout":        "[Looker]\ntimeout=2m\n",
+	}
+	for name, ini := range tests {
+		if cfg, err := ParseIniConfig(strings.NewReader(ini), DefaultIniSection); err == nil {
+			t.Errorf("%s: ParseIniConfig = %+v, expected an error", name, cfg)
+		}
+	}
+}
+
+func TestLoadIniConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "looker.ini")
+	if err := os.WriteFile(path, []byte(testIni), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadIniConfig(path, DefaultIniSection)
+	if err != nil {
+		t.Fatalf("LoadIniConfig returned error: %v", err)
+	}
+	if cfg.ClientID != "abc" {
+		t.Errorf("ClientID = %q, expected abc", cfg.ClientID)
+	}
+
+	if _, err := LoadIniConfig(filepath.Join(t.TempDir(), "missing.ini"), DefaultIniSection); !os.IsNotExist(err) {
+		t.Errorf("LoadIniConfig of a missing file returned %v, expected a not exist error", err)
+	}
+	if _, err := LoadIniConfig(path, "Production"); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadIniConfig of a missing section returned %v, expected an error naming the file", err)
+	}
+}
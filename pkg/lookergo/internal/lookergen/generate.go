@@ -0,0 +1,414 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+)
+
+// Config lists the definitions and services lookergen generates.
+type Config struct {
+	Models   []string        `json:"models"`
+	Services []ServiceConfig `json:"services"`
+}
+
+// ServiceConfig maps the methods of a service to the operations of the spec implementing them.
+type ServiceConfig struct {
+	Name       string            `json:"name"`
+	Operations map[string]string `json:"operations"`
+}
+
+const (
+	modelsFile   = "models_gen.go"
+	servicesFile = "services_gen.go"
+)
+
+// methods are the methods a service may have, in the order they are generated.
+var methods = []struct {
+	name   string
+	method string
+	byID   bool
+}{
+	{"List", "get", false},
+	{"Get", "get", true},
+	{"Create", "post", false},
+	{"Update", "patch", true},
+	{"Delete", "delete", true},
+}
+
+var pathParam = regexp.MustCompile(`^(.*)/\{(\w+)\}$`)
+
+type generator struct {
+	spec   *Spec
+	ops    map[string]*Operation
+	source string
+	pkg    string
+}
+
+// Generate returns the files generated for cfg from spec, by name. source is the name of the spec file, and pkg
+// the package of the generated files.
+func Generate(spec *Spec, cfg *Config, source, pkg string) (map[string][]byte, error) {
+	ops, err := spec.operations()
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{spec: spec, ops: ops, source: source, pkg: pkg}
+
+	models := g.header()
+	seen := make(map[string]bool)
+	for _, name := range cfg.Models {
+		if seen[name] {
+			return nil, fmt.Errorf("model %s: listed twice", name)
+		}
+		seen[name] = true
+		if err := g.model(models, name); err != nil {
+			return nil, fmt.Errorf("model %s: %w", name, err)
+		}
+	}
+
+	services := g.header()
+	services.WriteString("import \"context\"\n")
+	for _, svc := range cfg.Services {
+		if err := g.service(services, svc); err != nil {
+			return nil, fmt.Errorf("service %s: %w", svc.Name, err)
+		}
+	}
+
+	files := make(map[string][]byte)
+	for name, buf := range map[string]*bytes.Buffer{modelsFile: models, servicesFile: services} {
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		files[name] = src
+	}
+	return files, nil
+}
+
+func (g *generator) header() *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by lookergen from %s. DO NOT EDIT.\n\npackage %s\n", g.source, g.pkg)
+	return buf
+}
+
+// model writes the struct of the definition name. Definitions named Write* are update bodies, whose fields are
+// all *Nullable[T].
+func (g *generator) model(buf *bytes.Buffer, name string) error {
+	def, ok := g.spec.Definitions[name]
+	if !ok {
+		return fmt.Errorf("no such definition")
+	}
+	write := strings.HasPrefix(name, "Write")
+
+	buf.WriteString("\n")
+	switch {
+	case write:
+		fmt.Fprintf(buf, "// %s is the body of a %s update. Fields left nil are not changed.\n", name, strings.TrimPrefix(name, "Write"))
+	case def.Description != "":
+		fmt.Fprintf(buf, "// %s - %s\n", name, oneLine(def.Description))
+	default:
+		fmt.Fprintf(buf, "// %s -\n", name)
+	}
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, prop := range def.Properties {
+		typ, err := g.goType(prop.Name, prop.Schema)
+		if err != nil {
+			return fmt.Errorf("%s: %w", prop.Name, err)
+		}
+		if write {
+			typ = "*Nullable[" + typ + "]"
+		} else if prop.Schema.Ref != "" {
+			typ = "*" + typ
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s,omitempty\"`", camel(prop.Name), typ, prop.Name)
+		if d := oneLine(prop.Schema.Description); d != "" {
+			fmt.Fprintf(buf, " // %s", d)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// goType returns the Go type of the property prop. References are returned as the name of the definition.
+func (g *generator) goType(prop string, s *Schema) (string, error) {
+	if s.Ref != "" {
+		name, err := refName(s.Ref)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := g.spec.Definitions[name]; !ok {
+			return "", fmt.Errorf("no such definition %s", name)
+		}
+		return name, nil
+	}
+
+	switch s.Type {
+	case "string":
+		if g.isID(prop) {
+			return "ID", nil
+		}
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		if s.Items.Type == "string" && g.isID(prop) {
+			return "IDs", nil
+		}
+		elem, err := g.goType("", s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "object", "":
+		if len(s.Properties) > 0 {
+			return "", fmt.Errorf("inline objects are not supported")
+		}
+		ap, err := s.additional()
+		if err != nil {
+			return "", err
+		}
+		if ap == nil {
+			if s.Type == "" {
+				return "interface{}", nil
+			}
+			return "map[string]interface{}", nil
+		}
+		elem, err := g.goType("", ap)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + elem, nil
+	}
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+// isID reports whether the property or parameter name holds the id of an object of the API: it is named id, or
+// after a definition of the spec, like model_set_id or role_ids.
+func (g *generator) isID(name string) bool {
+	if name == "id" {
+		return true
+	}
+	for _, suffix := range []string{"_id", "_ids"} {
+		if strings.HasSuffix(name, suffix) {
+			_, ok := g.spec.Definitions[camel(strings.TrimSuffix(name, suffix))]
+			return ok
+		}
+	}
+	return false
+}
+
+type method struct {
+	name  string
+	op    *Operation
+	param string // Name of the path parameter, if any.
+	ptype string // Go type of the path parameter.
+	body  string // Definition of the request body, if any.
+	resp  string // Definition of the response, if any.
+}
+
+// service writes the interface of the service, and its implementation.
+func (g *generator) service(buf *bytes.Buffer, svc ServiceConfig) error {
+	var (
+		base   string
+		model  string
+		result []method
+	)
+	for name := range svc.Operations {
+		if !knownMethod(name) {
+			return fmt.Errorf("unknown method %s", name)
+		}
+	}
+	for _, m := range methods {
+		opID, ok := svc.Operations[m.name]
+		if !ok {
+			continue
+		}
+		op, ok := g.ops[opID]
+		if !ok {
+			return fmt.Errorf("%s: no such operation %s", m.name, opID)
+		}
+		if op.Method != m.method {
+			return fmt.Errorf("%s: operation %s is a %s, expected a %s", m.name, opID, strings.ToUpper(op.Method), strings.ToUpper(m.method))
+		}
+		gm := method{name: m.name, op: op}
+
+		path := op.Path
+		if m.byID {
+			match := pathParam.FindStringSubmatch(op.Path)
+			if match == nil {
+				return fmt.Errorf("%s: path %s does not end with a parameter", m.name, op.Path)
+			}
+			path, gm.param = match[1], match[2]
+			gm.ptype = "string"
+			if g.isID(gm.param) {
+				gm.ptype = "ID"
+			}
+		}
+		if strings.Contains(path, "{") {
+			return fmt.Errorf("%s: path %s has unsupported parameters", m.name, op.Path)
+		}
+		if base == "" {
+			base = path
+		} else if base != path {
+			return fmt.Errorf("%s: path %s is not under %s", m.name, op.Path, base)
+		}
+
+		for _, p := range op.Parameters {
+			if p.In == "body" {
+				if p.Schema == nil {
+					return fmt.Errorf("%s: body without schema", m.name)
+				}
+				name, err := refName(p.Schema.Ref)
+				if err != nil {
+					return fmt.Errorf("%s: body: %w", m.name, err)
+				}
+				gm.body = name
+			}
+		}
+		if (m.name == "Create" || m.name == "Update") && gm.body == "" {
+			return fmt.Errorf("%s: operation %s has no body", m.name, opID)
+		}
+
+		if m.name != "Delete" {
+			resp := op.Responses["200"]
+			if resp == nil {
+				resp = op.Responses["201"]
+			}
+			if resp == nil || resp.Schema == nil {
+				return fmt.Errorf("%s: operation %s has no response", m.name, opID)
+			}
+			s := resp.Schema
+			if m.name == "List" {
+				if s.Type != "array" || s.Items == nil {
+					return fmt.Errorf("%s: operation %s does not return an array", m.name, opID)
+				}
+				s = s.Items
+			}
+			name, err := refName(s.Ref)
+			if err != nil {
+				return fmt.Errorf("%s: response: %w", m.name, err)
+			}
+			gm.resp = name
+			if model == "" {
+				model = name
+			}
+		}
+		result = append(result, gm)
+	}
+	if len(result) == 0 {
+		return fmt.Errorf("no operations")
+	}
+	if model == "" {
+		model = strings.TrimSuffix(svc.Name, "s")
+	}
+
+	version, err := g.spec.apiVersion()
+	if err != nil {
+		return err
+	}
+	basePath := lowerFirst(svc.Name) + "BasePath"
+	iface := svc.Name + "Resource"
+	impl := svc.Name + "ResourceOp"
+
+	// Paths are relative to the API version, which the client resolves.
+	fmt.Fprintf(buf, "\nconst %s = %q\n\n", basePath, strings.TrimPrefix(base, "/"))
+	fmt.Fprintf(buf, "// %s is an interface for interfacing with the %s resource endpoints of the API.\n", iface, model)
+	fmt.Fprintf(buf, "type %s interface {\n", iface)
+	for _, m := range result {
+		switch m.name {
+		case "List":
+			fmt.Fprintf(buf, "\tList(context.Context, *ListOptions) ([]%s, *Response, error)\n", m.resp)
+		case "Get":
+			fmt.Fprintf(buf, "\tGet(context.Context, %s, *GetOptions) (*%s, *Response, error)\n", m.ptype, m.resp)
+		case "Create":
+			fmt.Fprintf(buf, "\tCreate(context.Context, *%s) (*%s, *Response, error)\n", m.body, m.resp)
+		case "Update":
+			fmt.Fprintf(buf, "\tUpdate(context.Context, %s, *%s) (*%s, *Response, error)\n", m.ptype, m.body, m.resp)
+		case "Delete":
+			fmt.Fprintf(buf, "\tDelete(context.Context, %s) (*Response, error)\n", m.ptype)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "// %s handles operations between %s related methods of the API.\n", impl, model)
+	fmt.Fprintf(buf, "type %s struct {\n\tclient *Client\n}\n\n", impl)
+	fmt.Fprintf(buf, "var _ %s = &%s{}\n", iface, impl)
+
+	for _, m := range result {
+		id := lowerFirst(camel(m.param))
+		fmt.Fprintf(buf, "\n// %s calls %s: %s.\n", m.name, m.op.OperationID, oneLine(m.op.Summary))
+		// The API explorer files the operations under their tag, e.g. the model sets under Role.
+		if len(m.op.Tags) > 0 {
+			fmt.Fprintf(buf, "// Ref: https://developers.looker.com/api/explorer/%s/methods/%s/%s\n", version, m.op.Tags[0], m.op.OperationID)
+		}
+		// The route of the spans is the path of the operation, see withRoute.
+		route := fmt.Sprintf("\tctx = withRoute(ctx, %q)\n", strings.TrimPrefix(m.op.Path, "/"))
+		switch m.name {
+		case "List":
+			fmt.Fprintf(buf, "func (s *%s) List(ctx context.Context, opt *ListOptions) ([]%s, *Response, error) {\n", impl, m.resp)
+			buf.WriteString(route)
+			fmt.Fprintf(buf, "\treturn doList(ctx, s.client, %s, opt, new([]%s))\n", basePath, m.resp)
+		case "Get":
+			fmt.Fprintf(buf, "func (s *%s) Get(ctx context.Context, %s %s, opt *GetOptions) (*%s, *Response, error) {\n", impl, id, m.ptype, m.resp)
+			buf.WriteString(route)
+			fmt.Fprintf(buf, "\treturn doGetById(ctx, s.client, %s, %s, opt, new(%s))\n", basePath, id, m.resp)
+		case "Create":
+			fmt.Fprintf(buf, "func (s *%s) Create(ctx context.Context, createReq *%s) (*%s, *Response, error) {\n", impl, m.body, m.resp)
+			buf.WriteString(route)
+			fmt.Fprintf(buf, "\treturn doCreate(ctx, s.client, %s, createReq, new(%s))\n", basePath, m.resp)
+		case "Update":
+			fmt.Fprintf(buf, "func (s *%s) Update(ctx context.Context, %s %s, updateReq *%s) (*%s, *Response, error) {\n", impl, id, m.ptype, m.body, m.resp)
+			buf.WriteString(route)
+			fmt.Fprintf(buf, "\treturn doUpdate(ctx, s.client, %s, %s, updateReq, new(%s))\n", basePath, id, m.resp)
+		case "Delete":
+			fmt.Fprintf(buf, "func (s *%s) Delete(ctx context.Context, %s %s) (*Response, error) {\n", impl, id, m.ptype)
+			buf.WriteString(route)
+			fmt.Fprintf(buf, "\treturn doDelete(ctx, s.client, %s, %s)\n", basePath, id)
+		}
+		buf.WriteString("}\n")
+	}
+	return nil
+}
+
+func knownMethod(name string) bool {
+	for _, m := range methods {
+		if m.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// camel returns the Go name of a snake case name of the spec, following the naming of lookergo: model_set_id
+// becomes ModelSetId.
+func camel(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// oneLine returns s on one line, for a comment.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
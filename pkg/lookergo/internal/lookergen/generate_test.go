@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+  "swagger": "2.0",
+  "basePath": "/api/4.0",
+  "paths": {
+    "/things": {
+      "get": {"operationId": "all_things", "summary": "Get All Things", "tags": ["Thing"],
+        "responses": {"200": {"schema": {"type": "array", "items": {"$ref": "#/definitions/Thing"}}}}},
+      "post": {"operationId": "create_thing", "summary": "Create Thing",
+        "parameters": [{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/WriteThing"}}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/Thing"}}}}
+    },
+    "/things/{thing_id}": {
+      "parameters": [],
+      "get": {"operationId": "thing", "summary": "Get Thing",
+        "parameters": [{"name": "thing_id", "in": "path", "type": "string"}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/Thing"}}}},
+      "patch": {"operationId": "update_thing", "summary": "Update Thing",
+        "parameters": [{"name": "thing_id", "in": "path", "type": "string"},
+          {"name": "body", "in": "body", "schema": {"$ref": "#/definitions/WriteThing"}}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/Thing"}}}},
+      "delete": {"operationId": "delete_thing", "summary": "Delete Thing",
+        "parameters": [{"name": "thing_id", "in": "path", "type": "string"}],
+        "responses": {"204": {"schema": {"type": "string"}}}}
+    },
+    "/labels/{label_name}": {
+      "get": {"operationId": "label", "summary": "Get Label",
+        "parameters": [{"name": "label_name", "in": "path", "type": "string"}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/Label"}}}}
+    }
+  },
+  "definitions": {
+    "Thing": {
+      "properties": {
+        "can": {"type": "object", "additionalProperties": {"type": "boolean"}},
+        "id": {"type": "string", "description": "Unique Id"},
+        "label_id": {"type": "string"},
+        "client_id": {"type": "string", "description": "Not the id\nof a Thing"},
+        "thing_ids": {"type": "array", "items": {"type": "string"}},
+        "label": {"$ref": "#/definitions/Label"},
+        "labels": {"type": "array", "items": {"$ref": "#/definitions/Label"}},
+        "size": {"type": "integer", "format": "int64"},
+        "ratio": {"type": "number"},
+        "enabled": {"type": "boolean"}
+      }
+    },
+    "WriteThing": {
+      "properties": {
+        "label_id": {"type": "string"},
+        "label": {"$ref": "#/definitions/Label"},
+        "enabled": {"type": "boolean"}
+      }
+    },
+    "Label": {
+      "description": "A label",
+      "properties": {"name": {"type": "string"}}
+    }
+  }
+}`
+
+func testGenerate(t *testing.T, cfg Config) (map[string][]byte, error) {
+	t.Helper()
+	spec := new(Spec)
+	if err := json.Unmarshal([]byte(testSpec), spec); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	return Generate(spec, &cfg, "test.json", "lookergo")
+}
+
+func TestGenerate_models(t *testing.T) {
+	files, err := testGenerate(t, Config{Models: []string{"Thing", "WriteThing", "Label"}})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	src := string(files[modelsFile])
+
+	for _, expected := range []string{
+		"// Code generated by lookergen from test.json. DO NOT EDIT.",
+		"Can map[string]bool `json:\"can,omitempty\"`",
+		"Id ID `json:\"id,omitempty\"` // Unique Id",
+		"LabelId ID `json:\"label_id,omitempty\"`",
+		"ClientId string `json:\"client_id,omitempty\"` // Not the id of a Thing",
+		"ThingIds IDs `json:\"thing_ids,omitempty\"`",
+		"Label *Label `json:\"label,omitempty\"`",
+		"Labels []Label `json:\"labels,omitempty\"`",
+		"Size int64 `json:\"size,omitempty\"`",
+		"Ratio float64 `json:\"ratio,omitempty\"`",
+		"// WriteThing is the body of a Thing update. Fields left nil are not changed.",
+		"LabelId *Nullable[ID] `json:\"label_id,omitempty\"`",
+		"Label *Nullable[Label] `json:\"label,omitempty\"`",
+		"Enabled *Nullable[bool] `json:\"enabled,omitempty\"`",
+		"// Label - A label",
+	} {
+		if !strings.Contains(squeeze(src), expected) {
+			t.Errorf("generated models lack %q:\n%s", expected, src)
+		}
+	}
+	// The order of the spec is kept.
+	if strings.Index(src, "Can ") > strings.Index(src, "Enabled ") {
+		t.Errorf("fields are not in the order of the spec:\n%s", src)
+	}
+}
+
+func TestGenerate_services(t *testing.T) {
+	files, err := testGenerate(t, Config{Services: []ServiceConfig{
+		{Name: "Things", Operations: map[string]string{
+			"List": "all_things", "Get": "thing", "Create": "create_thing", "Update": "update_thing", "Delete": "delete_thing",
+		}},
+		{Name: "Labels", Operations: map[string]string{"Get": "label"}},
+	}})
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	src := squeeze(string(files[servicesFile]))
+
+	for _, expected := range []string{
+		`const thingsBasePath = "things"`,
+		"// List calls all_things: Get All Things.\n// Ref: https://developers.looker.com/api/explorer/4.0/methods/Thing/all_things\n",
+		"List(context.Context, *ListOptions) ([]Thing, *Response, error)",
+		"Update(context.Context, ID, *WriteThing) (*Thing, *Response, error)",
+		"var _ ThingsResource = &ThingsResourceOp{}",
+		"// Get calls thing: Get Thing.",
+		`ctx = withRoute(ctx, "things")`,
+		`ctx = withRoute(ctx, "things/{thing_id}")`,
+		"return doGetById(ctx, s.client, thingsBasePath, thingId, opt, new(Thing))",
+		"return doCreate(ctx, s.client, thingsBasePath, createReq, new(Thing))",
+		"return doUpdate(ctx, s.client, thingsBasePath, thingId, updateReq, new(Thing))",
+		"return doDelete(ctx, s.client, thingsBasePath, thingId)",
+		// Name-keyed objects keep a string key.
+		"Get(ctx context.Context, labelName string, opt *GetOptions) (*Label, *Response, error)",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Errorf("generated services lack %q:\n%s", expected, src)
+		}
+	}
+	// The links are the ones of the operations, not of their tag.
+	if strings.Contains(src, "/methods/Thing\n") {
+		t.Errorf("generated services link a tag:\n%s", src)
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	tests := map[string]Config{
+		"unknown definition": {Models: []string{"Nope"}},
+		"duplicate model":    {Models: []string{"Label", "Label"}},
+		"unknown operation":  {Services: []ServiceConfig{{Name: "Things", Operations: map[string]string{"Get": "nope"}}}},
+		"unknown method":     {Services: []ServiceConfig{{Name: "Things", Operations: map[string]string{"Patch": "update_thing"}}}},
+		"wrong method":       {Services: []ServiceConfig{{Name: "Things", Operations: map[string]string{"Get": "delete_thing"}}}},
+		"no path parameter":  {Services: []ServiceConfig{{Name: "Things", Operations: map[string]string{"Get": "all_things"}}}},
+		"other base path": {Services: []ServiceConfig{{Name: "Things", Operations: map[string]string{
+			"List": "all_things", "Get": "label",
+		}}}},
+	}
+	for name, cfg := range tests {
+		if _, err := testGenerate(t, cfg); err == nil {
+			t.Errorf("%s: Generate returned no error", name)
+		}
+	}
+}
+
+func TestSpec_apiVersion(t *testing.T) {
+	tests := []struct {
+		spec Spec
+		want string
+	}{
+		{Spec{BasePath: "/api/4.0", Info: Info{Version: "4.0.22.14"}}, "4.0"},
+		{Spec{BasePath: "/api/3.1/", Info: Info{Version: "4.0.22.14"}}, "3.1"},
+		{Spec{Info: Info{Version: "4.0.23.6"}}, "4.0"},
+	}
+	for _, tt := range tests {
+		if got, err := tt.spec.apiVersion(); err != nil || got != tt.want {
+			t.Errorf("apiVersion of %+v = %q, %v, expected %q", tt.spec, got, err, tt.want)
+		}
+	}
+	if _, err := (&Spec{}).apiVersion(); err == nil {
+		t.Errorf("apiVersion of an empty spec returned no error")
+	}
+}
+
+// TestGenerated checks that the generated files of lookergo are up to date with spec/.
+func TestGenerated(t *testing.T) {
+	root := filepath.Join("..", "..")
+	files, err := generateFiles(filepath.Join(root, "spec", "Looker.4.0.json"), filepath.Join(root, "spec", "lookergen.json"), "lookergo")
+	if err != nil {
+		t.Fatalf("generateFiles returned error: %v", err)
+	}
+	for name, src := range files {
+		current, err := ioutil.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if !bytes.Equal(current, src) {
+			t.Errorf("%s is out of date, run go generate ./pkg/lookergo", name)
+		}
+	}
+}
+
+// squeeze collapses the alignment of gofmt, so that generated lines can be matched.
+func squeeze(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.Join(strings.Fields(l), " ")
+	}
+	return strings.Join(lines, "\n")
+}
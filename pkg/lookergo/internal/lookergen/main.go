@@ -0,0 +1,88 @@
+// Command lookergen generates the models and services of lookergo from the swagger specification of the Looker API.
+//
+// It is run by go generate, see generate.go and spec/README.md in lookergo.
+// With -subset, it instead trims the upstream specification to the part it generates, which is vendored by make spec.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "spec/Looker.4.0.json", "swagger 2.0 specification of the Looker API")
+	configPath := flag.String("config", "spec/lookergen.json", "definitions and services to generate")
+	out := flag.String("out", ".", "directory to write the generated files to")
+	pkg := flag.String("package", "lookergo", "package of the generated files")
+	subset := flag.String("subset", "", "instead of generating, write the subset of the spec the config generates to this file")
+	flag.Parse()
+
+	var err error
+	if *subset != "" {
+		err = writeSubset(*specPath, *configPath, *subset)
+	} else {
+		err = run(*specPath, *configPath, *out, *pkg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lookergen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, configPath, out, pkg string) error {
+	files, err := generateFiles(specPath, configPath, pkg)
+	if err != nil {
+		return err
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(out, name), src, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSubset writes the subset of the spec at specPath which the config generates to out.
+func writeSubset(specPath, configPath, out string) error {
+	raw, err := ioutil.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	cfg := new(Config)
+	if err := readJSON(configPath, cfg); err != nil {
+		return err
+	}
+	b, err := Subset(raw, cfg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", specPath, err)
+	}
+	return ioutil.WriteFile(out, b, 0o644)
+}
+
+// generateFiles reads the spec and the config, and returns the generated files by name.
+func generateFiles(specPath, configPath, pkg string) (map[string][]byte, error) {
+	spec := new(Spec)
+	if err := readJSON(specPath, spec); err != nil {
+		return nil, err
+	}
+	cfg := new(Config)
+	if err := readJSON(configPath, cfg); err != nil {
+		return nil, err
+	}
+	return Generate(spec, cfg, filepath.Base(specPath), pkg)
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
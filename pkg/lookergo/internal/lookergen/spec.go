@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Spec is the part of a swagger 2.0 specification lookergen reads.
+type Spec struct {
+	Info        Info                `json:"info"`
+	BasePath    string              `json:"basePath"`
+	Paths       map[string]PathItem `json:"paths"`
+	Definitions map[string]*Schema  `json:"definitions"`
+}
+
+// Info describes the API of a spec.
+type Info struct {
+	// Version of the spec, e.g. 4.0.22.14 for the API 4.0 of Looker 22.14.
+	Version string `json:"version"`
+}
+
+// apiVersion returns the version of the API the spec describes, e.g. 4.0, from the last segment of its base path,
+// or else from the first two components of its version.
+func (s *Spec) apiVersion() (string, error) {
+	if v := path.Base(s.BasePath); s.BasePath != "" && v != "/" {
+		return v, nil
+	}
+	if parts := strings.SplitN(s.Info.Version, ".", 3); len(parts) >= 2 {
+		return parts[0] + "." + parts[1], nil
+	}
+	return "", fmt.Errorf("no API version in basePath %q nor info.version %q", s.BasePath, s.Info.Version)
+}
+
+// PathItem holds the operations of a path, by lower case HTTP method.
+type PathItem map[string]json.RawMessage
+
+// Operation is an operation of the API.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Tags        []string             `json:"tags"`
+	Parameters  []Parameter          `json:"parameters"`
+	Responses   map[string]*Response `json:"responses"`
+
+	// Set by operations.
+	Path   string `json:"-"`
+	Method string `json:"-"`
+}
+
+// Parameter is a parameter of an operation.
+type Parameter struct {
+	Name   string  `json:"name"`
+	In     string  `json:"in"`
+	Type   string  `json:"type"`
+	Schema *Schema `json:"schema"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a definition, or the type of a property.
+type Schema struct {
+	Ref                  string          `json:"$ref"`
+	Type                 string          `json:"type"`
+	Description          string          `json:"description"`
+	Items                *Schema         `json:"items"`
+	AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	Properties           Properties      `json:"properties"`
+}
+
+// Property is a property of a definition.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties are the properties of a definition, in the order of the spec.
+type Properties []Property
+
+// UnmarshalJSON implements json.Unmarshaler, keeping the order of the properties.
+func (p *Properties) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return fmt.Errorf("properties: expected an object")
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		prop := Property{Name: t.(string)}
+		if err := dec.Decode(&prop.Schema); err != nil {
+			return fmt.Errorf("property %s: %w", prop.Name, err)
+		}
+		*p = append(*p, prop)
+	}
+	return nil
+}
+
+// additional returns the schema of the values of a map, or nil if s does not describe one.
+func (s *Schema) additional() (*Schema, error) {
+	if len(s.AdditionalProperties) == 0 || bytes.Equal(s.AdditionalProperties, []byte("false")) {
+		return nil, nil
+	}
+	if bytes.Equal(s.AdditionalProperties, []byte("true")) {
+		return &Schema{}, nil
+	}
+	var ap Schema
+	if err := json.Unmarshal(s.AdditionalProperties, &ap); err != nil {
+		return nil, err
+	}
+	return &ap, nil
+}
+
+// refName returns the name of the definition ref points to.
+func refName(ref string) (string, error) {
+	const prefix = "#/definitions/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported reference %q", ref)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+// operations indexes the operations of the spec by operationId.
+func (s *Spec) operations() (map[string]*Operation, error) {
+	ops := make(map[string]*Operation)
+	for path, item := range s.Paths {
+		for method, raw := range item {
+			switch method {
+			case "get", "put", "post", "patch", "delete":
+			default:
+				continue
+			}
+			op := new(Operation)
+			if err := json.Unmarshal(raw, op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			op.Path, op.Method = path, method
+			ops[op.OperationID] = op
+		}
+	}
+	return ops, nil
+}
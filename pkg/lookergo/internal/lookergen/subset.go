@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// subsetDescription replaces the description of the spec in its subset.
+const subsetDescription = "Subset of the Looker API 4.0 specification, holding the definitions and operations " +
+	"lookergen generates. See README.md."
+
+var refRe = regexp.MustCompile(`"\$ref"\s*:\s*"([^"]*)"`)
+
+// Subset returns the part of the spec raw which cfg generates: the operations of its services, and the definitions
+// of its models and of these operations, with the definitions they reference. The other fields of the spec are kept,
+// its description is replaced by subsetDescription.
+func Subset(raw []byte, cfg *Config) ([]byte, error) {
+	var spec map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, err
+	}
+	var paths map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(spec["paths"], &paths); err != nil {
+		return nil, fmt.Errorf("paths: %w", err)
+	}
+	var defs map[string]json.RawMessage
+	if err := json.Unmarshal(spec["definitions"], &defs); err != nil {
+		return nil, fmt.Errorf("definitions: %w", err)
+	}
+
+	wanted := make(map[string]bool)
+	for _, svc := range cfg.Services {
+		for _, opID := range svc.Operations {
+			wanted[opID] = true
+		}
+	}
+
+	var refs []string
+	subPaths := make(map[string]map[string]json.RawMessage)
+	for path, item := range paths {
+		sub := make(map[string]json.RawMessage)
+		for method, rawOp := range item {
+			switch method {
+			case "get", "put", "post", "patch", "delete":
+			default:
+				continue
+			}
+			var op struct {
+				OperationID string `json:"operationId"`
+			}
+			if err := json.Unmarshal(rawOp, &op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if !wanted[op.OperationID] {
+				continue
+			}
+			delete(wanted, op.OperationID)
+			sub[method] = rawOp
+			refs = append(refs, references(rawOp)...)
+		}
+		if len(sub) == 0 {
+			continue
+		}
+		// The parameters common to the operations of the path.
+		if params, ok := item["parameters"]; ok {
+			sub["parameters"] = params
+			refs = append(refs, references(params)...)
+		}
+		subPaths[path] = sub
+	}
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for opID := range wanted {
+			missing = append(missing, opID)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no such operations %s", strings.Join(missing, ", "))
+	}
+
+	subDefs := make(map[string]json.RawMessage)
+	for queue := append(append([]string(nil), cfg.Models...), refs...); len(queue) > 0; queue = queue[1:] {
+		name := queue[0]
+		if _, ok := subDefs[name]; ok {
+			continue
+		}
+		def, ok := defs[name]
+		if !ok {
+			return nil, fmt.Errorf("no such definition %s", name)
+		}
+		subDefs[name] = def
+		queue = append(queue, references(def)...)
+	}
+
+	info := make(map[string]json.RawMessage)
+	if raw, ok := spec["info"]; ok {
+		if err := json.Unmarshal(raw, &info); err != nil {
+			return nil, fmt.Errorf("info: %w", err)
+		}
+	}
+	info["description"], _ = json.Marshal(subsetDescription)
+
+	out := make(map[string]interface{}, len(spec))
+	for k, v := range spec {
+		out[k] = v
+	}
+	out["info"], out["paths"], out["definitions"] = info, subPaths, subDefs
+	// The descriptions are kept as written, e.g. with their HTML.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// references returns the names of the definitions raw references.
+func references(raw json.RawMessage) []string {
+	var names []string
+	for _, m := range refRe.FindAllSubmatch(raw, -1) {
+		if name, err := refName(string(m[1])); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
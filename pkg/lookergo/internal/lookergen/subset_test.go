@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSubset(t *testing.T) {
+	b, err := Subset([]byte(testSpec), &Config{
+		Models:   []string{"WriteThing"},
+		Services: []ServiceConfig{{Name: "Labels", Operations: map[string]string{"Get": "label"}}},
+	})
+	if err != nil {
+		t.Fatalf("Subset returned error: %v", err)
+	}
+
+	var spec struct {
+		Swagger     string                                `json:"swagger"`
+		Info        map[string]string                     `json:"info"`
+		Paths       map[string]map[string]json.RawMessage `json:"paths"`
+		Definitions map[string]json.RawMessage            `json:"definitions"`
+	}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		t.Fatalf("subset %s is not a spec: %v", b, err)
+	}
+	if spec.Swagger != "2.0" || spec.Info["description"] != subsetDescription {
+		t.Errorf("subset has swagger %q and info %v", spec.Swagger, spec.Info)
+	}
+	if len(spec.Paths) != 1 || len(spec.Paths["/labels/{label_name}"]) != 1 {
+		t.Errorf("subset has paths %v, expected GET /labels/{label_name} only", spec.Paths)
+	}
+	var defs []string
+	for name := range spec.Definitions {
+		defs = append(defs, name)
+	}
+	sort.Strings(defs)
+	// WriteThing references Label, Thing is not needed.
+	if expected := []string{"Label", "WriteThing"}; !reflect.DeepEqual(defs, expected) {
+		t.Errorf("subset has definitions %v, expected %v", defs, expected)
+	}
+}
+
+func TestSubset_errors(t *testing.T) {
+	tests := map[string]Config{
+		"unknown definition": {Models: []string{"Nope"}},
+		"unknown operation":  {Services: []ServiceConfig{{Name: "Things", Operations: map[string]string{"Get": "nope"}}}},
+	}
+	for name, cfg := range tests {
+		if _, err := Subset([]byte(testSpec), &cfg); err == nil {
+			t.Errorf("%s: Subset returned no error", name)
+		}
+	}
+}
+
+// TestSubset_vendored checks that spec/ holds the subset make spec vendors.
+func TestSubset_vendored(t *testing.T) {
+	root := filepath.Join("..", "..")
+	specPath := filepath.Join(root, "spec", "Looker.4.0.json")
+	raw, err := ioutil.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := new(Config)
+	if err := readJSON(filepath.Join(root, "spec", "lookergen.json"), cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Subset(raw, cfg)
+	if err != nil {
+		t.Fatalf("Subset returned error: %v", err)
+	}
+	if !bytes.Equal(b, raw) {
+		t.Errorf("%s is not the subset lookergen.json generates, run make spec", specPath)
+	}
+}
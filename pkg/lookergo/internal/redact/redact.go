@@ -0,0 +1,112 @@
+// Package redact replaces the secrets of HTTP exchanges. It is shared by the logs and the audit log of lookergo,
+// and by the fixtures of its cassette package.
+package redact
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the secrets.
+const Redacted = "REDACTED"
+
+// DefaultHeaders are the headers whose values are always redacted.
+var DefaultHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultFields are the JSON, form and query fields whose values are always redacted.
+var DefaultFields = []string{
+	"access_token", "refresh_token", "client_secret", "password", "git_password", "certificate", "deploy_secret",
+}
+
+// Redactor replaces the values of some headers and fields with Redacted.
+type Redactor struct {
+	headers []string
+	fields  map[string]bool
+}
+
+// New returns a redactor of the given headers and fields. The defaults are not added.
+func New(headers, fields []string) *Redactor {
+	r := &Redactor{headers: append([]string(nil), headers...), fields: make(map[string]bool, len(fields))}
+	for _, f := range fields {
+		r.fields[f] = true
+	}
+	return r
+}
+
+// Header returns a copy of h with the values of the headers redacted, nil if h is empty.
+func (r *Redactor) Header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, k := range r.headers {
+		if _, ok := h[http.CanonicalHeaderKey(k)]; ok {
+			h.Set(k, Redacted)
+		}
+	}
+	return h
+}
+
+// URL returns a copy of u with the values of the fields of its query redacted. The query is only re-encoded when
+// something was redacted.
+func (r *Redactor) URL(u *url.URL) *url.URL {
+	redacted := *u
+	if q := u.Query(); r.Values(q) {
+		redacted.RawQuery = q.Encode()
+	}
+	return &redacted
+}
+
+// Body returns the JSON or form encoded body b with the values of the fields redacted. Other bodies, and bodies
+// without secrets, are returned as is.
+func (r *Redactor) Body(contentType string, b []byte) string {
+	s := string(b)
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if q, err := url.ParseQuery(s); err == nil && r.Values(q) {
+			s = q.Encode()
+		}
+	} else {
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err == nil && r.JSON(v) {
+			if redacted, err := json.Marshal(v); err == nil {
+				s = string(redacted)
+			}
+		}
+	}
+	return s
+}
+
+// Values redacts q in place, and reports whether anything was redacted.
+func (r *Redactor) Values(q url.Values) (changed bool) {
+	for k := range q {
+		if r.fields[k] {
+			q.Set(k, Redacted)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// JSON redacts the decoded JSON v in place, and reports whether anything was redacted.
+func (r *Redactor) JSON(v interface{}) (changed bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if r.fields[k] && e != nil {
+				v[k] = Redacted
+				changed = true
+			} else if r.JSON(e) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if r.JSON(e) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
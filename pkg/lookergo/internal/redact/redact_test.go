@@ -0,0 +1,42 @@
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	r := New(DefaultHeaders, DefaultFields)
+
+	h := r.Header(http.Header{"Authorization": {"token abc"}, "Accept": {"application/json"}})
+	if h.Get("Authorization") != Redacted || h.Get("Accept") != "application/json" {
+		t.Errorf("Header = %v, expected the Authorization redacted only", h)
+	}
+	if h := r.Header(http.Header{}); h != nil {
+		t.Errorf("Header of no headers = %v, expected nil", h)
+	}
+
+	u, _ := url.Parse("https://example.cloud.looker.com/api/4.0/login?client_id=id&client_secret=s3cret")
+	if got, expected := r.URL(u).String(), "https://example.cloud.looker.com/api/4.0/login?client_id=id&client_secret=REDACTED"; got != expected {
+		t.Errorf("URL = %q, expected %q", got, expected)
+	}
+	// Queries without secrets are kept as they were encoded.
+	u, _ = url.Parse("https://example.cloud.looker.com/api/4.0/users?offset=0&limit=5")
+	if got := r.URL(u).String(); got != u.String() {
+		t.Errorf("URL = %q, expected %q", got, u.String())
+	}
+
+	bodies := []struct{ contentType, body, expected string }{
+		{"application/json", `{"access_token":"a","refresh_token":"r","expires_in":3600}`, `{"access_token":"REDACTED","expires_in":3600,"refresh_token":"REDACTED"}`},
+		{"application/json", `[{"credentials_email":{"password":"p"}}]`, `[{"credentials_email":{"password":"REDACTED"}}]`},
+		{"application/json", `{"name":"Analysts", "id": "1"}`, `{"name":"Analysts", "id": "1"}`},
+		{"application/x-www-form-urlencoded", "client_id=id&client_secret=s3cret", "client_id=id&client_secret=REDACTED"},
+		{"text/plain", "password", "password"},
+	}
+	for _, b := range bodies {
+		if got := r.Body(b.contentType, []byte(b.body)); got != b.expected {
+			t.Errorf("Body(%q, %s) = %s, expected %s", b.contentType, b.body, got, b.expected)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package lookergo
+
+import "context"
+
+// Logger receives the diagnostic messages of a Client, such as retried requests.
+// The fields carry structured details, ready for e.g. tflog.
+type Logger interface {
+	Log(ctx context.Context, msg string, fields map[string]interface{})
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(ctx context.Context, msg string, fields map[string]interface{})
+
+// Log calls f(ctx, msg, fields).
+func (f LoggerFunc) Log(ctx context.Context, msg string, fields map[string]interface{}) {
+	f(ctx, msg, fields)
+}
+
+func (c *Client) log(ctx context.Context, msg string, fields map[string]interface{}) {
+	if c.logger != nil {
+		c.logger.Log(ctx, msg, fields)
+	}
+}
@@ -0,0 +1,157 @@
+package lookergo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/internal/redact"
+)
+
+// Redacted replaces the secrets in the records of a LoggingTransport.
+const Redacted = redact.Redacted
+
+// DefaultLogMaxBodySize is the number of bytes of a body logged before it is truncated, unless configured otherwise.
+const DefaultLogMaxBodySize = 4096
+
+// DefaultRedactHeaders are the headers whose values are never logged.
+var DefaultRedactHeaders = redact.DefaultHeaders
+
+// DefaultRedactFields are the JSON, form and query fields whose values are never logged, nor recorded by the
+// cassette package.
+var DefaultRedactFields = redact.DefaultFields
+
+// HTTPLogOptions configures a LoggingTransport.
+type HTTPLogOptions struct {
+	// Logger receives one record per request and one per response. The provider sends them to tflog at TRACE.
+	Logger Logger
+
+	// Extra headers and fields to redact, on top of DefaultRedactHeaders and DefaultRedactFields.
+	RedactHeaders []string
+	RedactFields  []string
+
+	// Number of bytes of a body to log, after redaction. Longer bodies are truncated, and 0 omits them.
+	MaxBodySize int
+
+	// Enabled reports whether the Logger records the exchanges of a request, e.g. whether it is at TRACE level.
+	// When it does not, the requests are sent as is: nothing is logged, and no body is read or redacted.
+	// Defaults to always enabled.
+	Enabled func(ctx context.Context) bool
+}
+
+// LoggingTransport is an http.RoundTripper logging the requests it sends and the responses it receives,
+// with their secrets redacted.
+type LoggingTransport struct {
+	// Base sends the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+
+	opts   HTTPLogOptions
+	redact *redact.Redactor
+}
+
+var _ http.RoundTripper = &LoggingTransport{}
+
+// NewLoggingTransport returns a transport sending the requests through base, and logging them according to opts.
+func NewLoggingTransport(base http.RoundTripper, opts HTTPLogOptions) *LoggingTransport {
+	return &LoggingTransport{
+		Base:   base,
+		opts:   opts,
+		redact: newRedactor(opts.RedactHeaders, opts.RedactFields),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := req.Context()
+	if t.opts.Logger == nil || (t.opts.Enabled != nil && !t.opts.Enabled(ctx)) {
+		return base.RoundTrip(req)
+	}
+
+	fields := map[string]interface{}{
+		"http_method":  req.Method,
+		"http_url":     t.redact.URL(req.URL).String(),
+		"http_headers": flatHeader(t.redact.Header(req.Header)),
+	}
+	if req.Body != nil && req.Body != http.NoBody && t.opts.MaxBodySize > 0 {
+		body, err := ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// The request of the caller must not be modified.
+		req = req.Clone(ctx)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		fields["http_body"] = t.body(req.Header.Get("Content-Type"), body)
+	}
+	t.opts.Logger.Log(ctx, "HTTP request", fields)
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	fields = map[string]interface{}{
+		"http_method":   req.Method,
+		"http_url":      t.redact.URL(req.URL).String(),
+		"http_duration": time.Since(start).String(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		t.opts.Logger.Log(ctx, "HTTP response", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	fields["http_headers"] = flatHeader(t.redact.Header(resp.Header))
+	if resp.Body != nil && resp.Body != http.NoBody && t.opts.MaxBodySize > 0 {
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		var r io.Reader = bytes.NewReader(body)
+		if err != nil {
+			// The caller sees the error when reading the body, as without logging.
+			r = io.MultiReader(r, errReader{err})
+		}
+		resp.Body = ioutil.NopCloser(r)
+		fields["http_body"] = t.body(resp.Header.Get("Content-Type"), body)
+	}
+	t.opts.Logger.Log(ctx, "HTTP response", fields)
+
+	return resp, nil
+}
+
+// errReader fails every read with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// newRedactor returns a redactor of the default headers and fields, and of the given ones.
+func newRedactor(headers, fields []string) *redact.Redactor {
+	return redact.New(
+		append(append([]string(nil), DefaultRedactHeaders...), headers...),
+		append(append([]string(nil), DefaultRedactFields...), fields...),
+	)
+}
+
+// flatHeader returns h as one value per header.
+func flatHeader(h http.Header) map[string]string {
+	m := make(map[string]string, len(h))
+	for k, v := range h {
+		m[k] = strings.Join(v, ", ")
+	}
+	return m
+}
+
+// body redacts a JSON or form encoded body and truncates it. Other bodies are only truncated.
+func (t *LoggingTransport) body(contentType string, b []byte) string {
+	s := t.redact.Body(contentType, b)
+	if len(s) > t.opts.MaxBodySize {
+		return fmt.Sprintf("%s... (%d bytes truncated)", s[:t.opts.MaxBodySize], len(s)-t.opts.MaxBodySize)
+	}
+	return s
+}
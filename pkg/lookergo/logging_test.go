@@ -0,0 +1,138 @@
+package lookergo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type logRecord struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []logRecord
+}
+
+func (l *recordingLogger) Log(_ context.Context, msg string, fields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, logRecord{msg, fields})
+}
+
+func TestNew_httpLogging(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/4.0/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"s3cr3t-token","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/api/4.0/connections", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"warehouse","password":"hunter2","host":"`+strings.Repeat("x", 100)+`"}`)
+	})
+
+	logger := &recordingLogger{}
+	c, err := New(
+		WithBaseURL(server.URL+"/api/"),
+		WithOAuthCredentials("id", "client-s3cr3t"),
+		WithLimiter(nil),
+		WithHTTPLogging(HTTPLogOptions{Logger: logger, MaxBodySize: 64}),
+	)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	conn, _, err := c.Connections.Create(ctx, &DBConnection{Name: "warehouse", Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("Connections.Create returned error: %v", err)
+	}
+	if conn.Password != "hunter2" || len(conn.Host) != 100 {
+		t.Errorf("Connections.Create = %+v, expected the response untouched by the logging", conn)
+	}
+
+	// Login and create, each with a request and a response.
+	if len(logger.records) != 4 {
+		t.Fatalf("got %d records, expected 4: %+v", len(logger.records), logger.records)
+	}
+	all := fmt.Sprint(logger.records)
+	for _, secret := range []string{"client-s3cr3t", "s3cr3t-token", "hunter2"} {
+		if strings.Contains(all, secret) {
+			t.Errorf("records contain %q: %s", secret, all)
+		}
+	}
+
+	req := logger.records[2]
+	if h := req.fields["http_headers"].(map[string]string); h["Authorization"] != Redacted {
+		t.Errorf("Authorization = %q, expected it redacted", h["Authorization"])
+	}
+	resp := logger.records[3]
+	if resp.msg != "HTTP response" || resp.fields["http_status"] != http.StatusOK {
+		t.Errorf("record = %+v, expected a 200 response", resp)
+	}
+	if body := resp.fields["http_body"].(string); !strings.HasPrefix(body, `{"host":"xxx`) || !strings.HasSuffix(body, "bytes truncated)") {
+		t.Errorf("http_body = %q, expected it truncated to 64 bytes", body)
+	}
+}
+
+func TestLoggingTransport_noBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1"}`)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := &http.Client{Transport: NewLoggingTransport(nil, HTTPLogOptions{Logger: logger})}
+	resp, err := client.Post(server.URL+"?client_secret=abc", "application/json", strings.NewReader(`{"name":"a"}`))
+	if err != nil {
+		t.Fatalf("Post returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	for _, r := range logger.records {
+		if _, ok := r.fields["http_body"]; ok {
+			t.Errorf("record %+v has a body, expected none with a MaxBodySize of 0", r)
+		}
+		if u := r.fields["http_url"].(string); strings.Contains(u, "abc") {
+			t.Errorf("http_url = %q, expected the client_secret redacted", u)
+		}
+	}
+}
+
+func TestLoggingTransport_disabled(t *testing.T) {
+	var sent *http.Request
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+
+	logger := &recordingLogger{}
+	transport := NewLoggingTransport(base, HTTPLogOptions{
+		Logger:      logger,
+		MaxBodySize: DefaultLogMaxBodySize,
+		Enabled:     func(context.Context) bool { return false },
+	})
+	req, _ := http.NewRequest(http.MethodPost, "https://looker.example.com/api/4.0/users", strings.NewReader(`{"password":"a"}`))
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+
+	if len(logger.records) != 0 {
+		t.Errorf("logged %+v, expected nothing when disabled", logger.records)
+	}
+	if sent != req {
+		t.Errorf("sent a copy of the request, expected it sent as is, its body unread")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -2,13 +2,13 @@ package lookergo
 
 import "context"
 
-const lookMlModelsBasePath = "4.0/lookml_models"
+const lookMlModelsBasePath = "lookml_models"
 
 type LookMlModelsResource interface {
-	List(ctx context.Context) ([]LookMLModel, *Response, error)
-	Get(ctx context.Context, LookMLModelName string) (*LookMLModel, *Response, error)
+	List(ctx context.Context, opt *ListOptions) ([]LookMLModel, *Response, error)
+	Get(ctx context.Context, LookMLModelName string, opt *GetOptions) (*LookMLModel, *Response, error)
 	Create(ctx context.Context, LookMLModel *LookMLModel) (*LookMLModel, *Response, error)
-	Update(ctx context.Context, LookMLModelName string, LookMLModel *LookMLModel) (*LookMLModel, *Response, error)
+	Update(ctx context.Context, LookMLModelName string, LookMLModel *WriteLookMLModel) (*LookMLModel, *Response, error)
 	Delete(ctx context.Context, LookMLModelName string) (*Response, error)
 }
 
@@ -31,25 +31,36 @@ type LookMLModel struct {
 	UnlimitedDbConnections   bool                     `json:"unlimited_db_connections,omitempty"`    // Is this model allowed to use all current and future connections
 }
 
-func (s LookMlModelsResourceOp) List(ctx context.Context) ([]LookMLModel, *Response, error) {
-	// TODO implement me
-	//return doList(ctx, s.client, lookMlModelsBasePath, )
-	panic("Not implemented")
+// WriteLookMLModel is the body of a LookML model update. Fields left nil are not changed.
+type WriteLookMLModel struct {
+	AllowedDbConnectionNames *Nullable[[]string] `json:"allowed_db_connection_names,omitempty"` // Array of names of connections this model is allowed to use
+	Name                     *Nullable[string]   `json:"name,omitempty"`                        // Name of the model. Also used as the unique identifier
+	ProjectName              *Nullable[string]   `json:"project_name,omitempty"`                // Name of project containing the model
+	UnlimitedDbConnections   *Nullable[bool]     `json:"unlimited_db_connections,omitempty"`    // Is this model allowed to use all current and future connections
 }
 
-func (s LookMlModelsResourceOp) Get(ctx context.Context, LookMLModelName string) (*LookMLModel, *Response, error) {
-	return doGetById(ctx, s.client, lookMlModelsBasePath, LookMLModelName, new(LookMLModel))
+func (s LookMlModelsResourceOp) List(ctx context.Context, opt *ListOptions) ([]LookMLModel, *Response, error) {
+	ctx = withRoute(ctx, "lookml_models")
+	return doList(ctx, s.client, lookMlModelsBasePath, opt, new([]LookMLModel))
+}
+
+func (s LookMlModelsResourceOp) Get(ctx context.Context, LookMLModelName string, opt *GetOptions) (*LookMLModel, *Response, error) {
+	ctx = withRoute(ctx, "lookml_models/{lookml_model_name}")
+	return doGetById(ctx, s.client, lookMlModelsBasePath, LookMLModelName, opt, new(LookMLModel))
 }
 
 func (s LookMlModelsResourceOp) Create(ctx context.Context, requestLookMLModel *LookMLModel) (*LookMLModel, *Response, error) {
+	ctx = withRoute(ctx, "lookml_models")
 	return doCreate(ctx, s.client, lookMlModelsBasePath, requestLookMLModel, new(LookMLModel))
 }
 
-func (s LookMlModelsResourceOp) Update(ctx context.Context, LookMLModelName string, requestLookMLModel *LookMLModel) (*LookMLModel, *Response, error) {
+func (s LookMlModelsResourceOp) Update(ctx context.Context, LookMLModelName string, requestLookMLModel *WriteLookMLModel) (*LookMLModel, *Response, error) {
+	ctx = withRoute(ctx, "lookml_models/{lookml_model_name}")
 	return doUpdate(ctx, s.client, lookMlModelsBasePath, LookMLModelName, requestLookMLModel, new(LookMLModel))
 }
 
 func (s LookMlModelsResourceOp) Delete(ctx context.Context, LookMLModelName string) (*Response, error) {
+	ctx = withRoute(ctx, "lookml_models/{lookml_model_name}")
 	return doDelete(ctx, s.client, lookMlModelsBasePath, LookMLModelName)
 }
 
@@ -1,5 +1,12 @@
 package lookergo
 
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
 /*
 
 ### Get All LookMlModels
@@ -284,3 +291,32 @@ Authorization: token {{token}}
   },`
 
 */
+
+func TestLookMlModelsResourceOp_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/lookml_models", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if limit := r.URL.Query().Get("limit"); limit != "2" {
+			t.Errorf("limit = %q, expected 2", limit)
+		}
+		fmt.Fprint(w, `[
+  { "has_content": false, "label": "Reprise J&J", "name": "Reprise_J&J", "project_name": "us_johnson_johnson", "allowed_db_connection_names": ["data-mesh-googleanalytics-block"] },
+  { "has_content": true, "label": "Cross Channel", "name": "cross_channel", "project_name": "hub", "unlimited_db_connections": true }
+]`)
+	})
+
+	result, _, err := client.LookMLModel.List(ctx, &ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("LookMLModel.List returned error: %v", err)
+	}
+
+	expected := []LookMLModel{
+		{Label: "Reprise J&J", Name: "Reprise_J&J", ProjectName: "us_johnson_johnson", AllowedDbConnectionNames: []string{"data-mesh-googleanalytics-block"}},
+		{HasContent: true, Label: "Cross Channel", Name: "cross_channel", ProjectName: "hub", UnlimitedDbConnections: true},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error(errGotWant("LookMLModel.List", result, expected))
+	}
+}
@@ -0,0 +1,846 @@
+// Package lookertest provides a stateful, in-memory fake of the Looker 4.0 API, so that the lookergo client
+// and the provider can be tested end to end without network.
+//
+//	srv := lookertest.NewServer()
+//	defer srv.Close()
+//
+//	client, err := lookergo.New(
+//		lookergo.WithBaseURL(srv.BaseURL()),
+//		lookergo.WithOAuthCredentials(srv.ClientID, srv.ClientSecret),
+//	)
+//
+// The fake knows versions, login, session, users (with credentials_email), groups (with nesting), roles,
+// permission sets, model sets, lookml_models, connections, folders, projects and color collections.
+// It answers with the status codes of Looker: 401 without a token, 404 for unknown objects,
+// 409 for names already in use and 422 with field-level errors for invalid bodies.
+package lookertest
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Kinds of objects, as used in the paths of the API.
+const (
+	Users            = "users"
+	Groups           = "groups"
+	Roles            = "roles"
+	PermissionSets   = "permission_sets"
+	ModelSets        = "model_sets"
+	LookMLModels     = "lookml_models"
+	Connections      = "connections"
+	Folders          = "folders"
+	Projects         = "projects"
+	ColorCollections = "color_collections"
+)
+
+// Ids of the objects every fresh instance starts with.
+const (
+	// AdminUserID is the user the API credentials belong to.
+	AdminUserID = "1"
+	// AllUsersGroupID is the built-in group every user is part of.
+	AllUsersGroupID = "1"
+	// AdminRoleID is the built-in role granting every permission on every model.
+	AdminRoleID = "2"
+	// AdminPermissionSetID is the built-in permission set with every permission.
+	AdminPermissionSetID = "1"
+	// AllModelSetID is the built-in model set with every model.
+	AllModelSetID = "1"
+	// SharedFolderID is the root folder of shared content.
+	SharedFolderID = "1"
+)
+
+// DefaultRelease is the Looker release a new Server reports.
+const DefaultRelease = "22.14.0"
+
+const docsURL = "https://cloud.google.com/looker/docs/r/api/4.0"
+
+// Server is a fake Looker instance. The API is served below BaseURL.
+type Server struct {
+	// Root URL of the instance, e.g. http://127.0.0.1:54321
+	URL string
+
+	// API credentials of the admin user
+	ClientID     string
+	ClientSecret string
+
+	// Looker release reported by /versions, e.g. "22.14.0". Set it before the first request.
+	Release string
+
+	srv *httptest.Server
+
+	mu         sync.Mutex
+	sessions   map[string]*session
+	nextToken  int
+	store      map[string]*collection
+	emails     map[string]Object // user id -> credentials_email
+	deployKeys map[string]string // project id -> public key
+
+	groupGroups relation // parent group -> member groups
+	groupUsers  relation // group -> member users
+	roleGroups  relation // role -> groups
+	roleUsers   relation // role -> users
+}
+
+type session struct {
+	userID    string
+	workspace string
+}
+
+type fieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer starts a fake instance with the built-in objects of a fresh Looker install.
+// Call Close when done.
+func NewServer() *Server {
+	return newServer(httptest.NewServer)
+}
+
+// NewTLSServer is like NewServer, but serves HTTPS with a self-signed certificate: clients must trust CertificatePEM.
+func NewTLSServer() *Server {
+	return newServer(httptest.NewTLSServer)
+}
+
+func newServer(start func(http.Handler) *httptest.Server) *Server {
+	s := &Server{
+		ClientID:     "lookertest-client-id",
+		ClientSecret: "lookertest-client-secret",
+		Release:      DefaultRelease,
+		sessions:     map[string]*session{},
+		emails:       map[string]Object{},
+		deployKeys:   map[string]string{},
+		groupGroups:  relation{},
+		groupUsers:   relation{},
+		roleGroups:   relation{},
+		roleUsers:    relation{},
+		store: map[string]*collection{
+			Users:            newCollection("user", "id", nil, ""),
+			Groups:           newCollection("group", "id", []string{"name"}, "name"),
+			Roles:            newCollection("role", "id", []string{"name", "permission_set_id", "model_set_id"}, "name"),
+			PermissionSets:   newCollection("permission set", "id", []string{"name", "permissions"}, "name"),
+			ModelSets:        newCollection("model set", "id", []string{"name", "models"}, "name"),
+			LookMLModels:     newCollection("lookml model", "name", []string{"name", "project_name"}, ""),
+			Connections:      newCollection("connection", "name", []string{"name", "dialect_name", "host"}, ""),
+			Folders:          newCollection("folder", "id", []string{"name", "parent_id"}, ""),
+			Projects:         newCollection("project", "id", []string{"name"}, ""),
+			ColorCollections: newCollection("color collection", "id", []string{"label"}, "label"),
+		},
+	}
+
+	s.Put(Users, Object{"id": AdminUserID, "first_name": "API", "last_name": "Admin", "is_disabled": false})
+	s.emails[AdminUserID] = Object{"email": "admin@example.com", "is_disabled": false}
+	s.Put(Groups, Object{"id": AllUsersGroupID, "name": "All Users", "externally_managed": false, "can_add_to_content_metadata": true})
+	s.Put(PermissionSets, Object{"id": AdminPermissionSetID, "name": "Admin", "built_in": true, "all_access": true,
+		"permissions": []interface{}{"access_data", "administer", "see_looks", "see_user_dashboards"}})
+	s.Put(ModelSets, Object{"id": AllModelSetID, "name": "All", "built_in": true, "all_access": true, "models": []interface{}{}})
+	s.Put(Roles, Object{"id": AdminRoleID, "name": "Admin", "permission_set_id": AdminPermissionSetID, "model_set_id": AllModelSetID})
+	s.roleUsers.add(AdminRoleID, AdminUserID)
+	s.Put(Folders, Object{"id": SharedFolderID, "name": "Shared", "parent_id": nil, "is_shared_root": true})
+
+	s.srv = start(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// CertificatePEM returns the certificate of a server started by NewTLSServer in PEM format, e.g. to write a CA
+// bundle, and nil for other servers.
+func (s *Server) CertificatePEM() []byte {
+	cert := s.srv.Certificate()
+	if cert == nil {
+		return nil
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// BaseURL returns the URL to configure clients with, including the /api/ path.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/"
+}
+
+// Put stores obj as is, bypassing validation, and returns its key. Objects without a key get a fresh id.
+func (s *Server) Put(kind string, obj map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.store[kind]
+	o := Object(obj).clone()
+	key := o.str(c.key)
+	if key == "" {
+		key = c.newID()
+		o[c.key] = key
+	}
+	c.items[key] = o
+	return key
+}
+
+// Get returns the stored object, as the API would render it.
+func (s *Server) Get(kind, key string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.store[kind].items[key]
+	if !ok {
+		return nil, false
+	}
+	return s.render(kind, obj), true
+}
+
+// Remove deletes an object behind the back of the clients, e.g. to test drift. It reports whether it existed.
+func (s *Server) Remove(kind, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.store[kind].items[key]; !ok {
+		return false
+	}
+	s.forget(kind, key)
+	return true
+}
+
+// RevokeToken invalidates an access token, as an expired session or an administrator would, and reports whether it
+// was valid. Its client gets a 401 on its next request.
+func (s *Server) RevokeToken(accessToken string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.sessions[accessToken]
+	delete(s.sessions, accessToken)
+	return ok
+}
+
+// NewToken returns a new access token of the admin user, as one obtained outside of the clients would be, e.g. to
+// test static tokens.
+func (s *Server) NewToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.newSession(AdminUserID)["access_token"].(string)
+}
+
+// Tokens returns the number of valid access tokens, e.g. to check that clients log out.
+func (s *Server) Tokens() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// forget deletes an object and its relations.
+func (s *Server) forget(kind, key string) {
+	delete(s.store[kind].items, key)
+	switch kind {
+	case Users:
+		delete(s.emails, key)
+		s.groupUsers.forget(key)
+		s.roleUsers.forget(key)
+	case Groups:
+		s.groupGroups.forget(key)
+		s.groupUsers.forget(key)
+		s.roleGroups.forget(key)
+	case Roles:
+		s.roleGroups.forget(key)
+		s.roleUsers.forget(key)
+	case Projects:
+		delete(s.deployKeys, key)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Like the other information endpoints, versions does not need a token.
+	if p := strings.TrimSuffix(r.URL.Path, "/"); p == "/api/versions" || p == "/api/4.0/versions" {
+		s.versions(w, r)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/4.0/")
+	if rest == r.URL.Path {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	segs := strings.Split(strings.Trim(rest, "/"), "/")
+
+	if segs[0] == "login" {
+		if len(segs) == 1 {
+			s.login(w, r)
+			return
+		}
+	}
+
+	sess := s.authenticate(r)
+	if sess == nil {
+		writeError(w, http.StatusUnauthorized, "Requires authentication.")
+		return
+	}
+
+	switch segs[0] {
+	case "login":
+		s.loginUser(w, r, sess, segs[1:])
+	case "logout":
+		s.logout(w, r)
+	case "session":
+		s.session(w, r, sess)
+	case "user":
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.render(Users, s.store[Users].items[sess.userID]))
+	default:
+		c, ok := s.store[segs[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		s.collection(w, r, sess, segs[0], c, segs[1:])
+	}
+}
+
+func (s *Server) versions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	element := func(version, status string) Object {
+		return Object{"version": version, "full_version": version + ".0", "status": status,
+			"swagger_url": s.URL + "/api/" + version + "/swagger.json"}
+	}
+	writeJSON(w, http.StatusOK, Object{
+		"looker_release_version": s.Release,
+		"current_version":        element("4.0", "current"),
+		"supported_versions":     []interface{}{element("3.1", "legacy"), element("4.0", "current")},
+		"api_server_url":         s.URL,
+		"web_server_url":         s.URL,
+	})
+}
+
+func (s *Server) authenticate(r *http.Request) *session {
+	auth := r.Header.Get("Authorization")
+	for _, prefix := range []string{"Bearer ", "token "} {
+		if strings.HasPrefix(auth, prefix) {
+			return s.sessions[strings.TrimPrefix(auth, prefix)]
+		}
+	}
+	return nil
+}
+
+func (s *Server) newSession(userID string) Object {
+	s.nextToken++
+	token := fmt.Sprintf("lookertest-token-%d", s.nextToken)
+	s.sessions[token] = &session{userID: userID, workspace: "production"}
+	return Object{"access_token": token, "token_type": "Bearer", "expires_in": 3600, "refresh_token": nil}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	_ = r.ParseForm()
+	if r.Form.Get("client_id") != s.ClientID || r.Form.Get("client_secret") != s.ClientSecret {
+		// Looker answers bad credentials with a 404.
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.newSession(AdminUserID))
+}
+
+// loginUser creates a token acting as another user, as POST /login/{user_id} does.
+func (s *Server) loginUser(w http.ResponseWriter, r *http.Request, sess *session, segs []string) {
+	if r.Method != http.MethodPost || len(segs) != 1 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	if !s.isAdmin(sess.userID) {
+		writeError(w, http.StatusForbidden, "Requires the sudo permission.")
+		return
+	}
+	if _, ok := s.store[Users].items[segs[0]]; !ok {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.newSession(segs[0]))
+}
+
+func (s *Server) isAdmin(userID string) bool {
+	for _, roleID := range s.roleUsers.sources(userID) {
+		if s.store[Roles].items[roleID].str("permission_set_id") == AdminPermissionSetID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	auth := r.Header.Get("Authorization")
+	delete(s.sessions, auth[strings.Index(auth, " ")+1:])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) session(w http.ResponseWriter, r *http.Request, sess *session) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPatch:
+		var body Object
+		if !decode(w, r, &body) {
+			return
+		}
+		ws := body.str("workspace_id")
+		if ws != "production" && ws != "dev" {
+			writeValidation(w, []fieldError{{Field: "workspace_id", Code: "invalid", Message: "Must be production or dev."}})
+			return
+		}
+		sess.workspace = ws
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, Object{"workspace_id": sess.workspace, "sudo_user_id": nil})
+}
+
+// collection serves /{kind}, /{kind}/search and /{kind}/{key}, and hands sub-resources to sub.
+func (s *Server) collection(w http.ResponseWriter, r *http.Request, sess *session, kind string, c *collection, segs []string) {
+	switch {
+	case len(segs) == 0 && r.Method == http.MethodGet:
+		s.writeList(w, r, kind, c.list())
+	case len(segs) == 0 && r.Method == http.MethodPost:
+		s.create(w, r, sess, kind, c)
+	case len(segs) >= 1 && segs[0] == "search" && r.Method == http.MethodGet:
+		// /groups/search/with_hierarchy is a search which includes parent_group_ids; ours always does.
+		s.writeList(w, r, kind, s.search(kind, c, r.URL.Query()))
+	case kind == Connections && len(segs) == 1 && segs[0] == "test" && r.Method == http.MethodPut:
+		var obj Object
+		if !decode(w, r, &obj) {
+			return
+		}
+		writeJSON(w, http.StatusOK, connectionTests(obj.str("name"), nil))
+	case len(segs) == 1:
+		obj, ok := c.items[segs[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, filterFields(s.render(kind, obj), r.URL.Query().Get("fields")))
+		case http.MethodPatch:
+			s.update(w, r, sess, kind, c, segs[0], obj)
+		case http.MethodDelete:
+			if obj["built_in"] == true {
+				writeError(w, http.StatusForbidden, fmt.Sprintf("Cannot delete a built-in %s.", c.kind))
+				return
+			}
+			s.forget(kind, segs[0])
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPut:
+			if kind == Connections {
+				writeJSON(w, http.StatusOK, connectionTests(segs[0], strings.Split(r.URL.Query().Get("tests"), ",")))
+				return
+			}
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	default:
+		if _, ok := c.items[segs[0]]; !ok {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		s.sub(w, r, sess, kind, segs[0], segs[1:])
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, sess *session, kind string, c *collection) {
+	var obj Object
+	if !decode(w, r, &obj) {
+		return
+	}
+	if status, msg := s.checkWorkspace(kind, sess); status != 0 {
+		writeError(w, status, msg)
+		return
+	}
+
+	key := obj.str(c.key)
+	if c.key == "id" {
+		key = ""
+		if kind == Projects {
+			key = obj.str("name")
+		}
+	}
+	if key != "" {
+		if _, exists := c.items[key]; exists {
+			writeValidation(w, []fieldError{{Field: c.key, Code: "already_exists", Message: "Value is already used."}})
+			return
+		}
+	}
+
+	errs := append(c.validate(key, obj), s.checkReferences(kind, key, obj)...)
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	if key == "" {
+		key = c.newID()
+	}
+	obj[c.key] = key
+	delete(obj, "built_in")
+	if kind == ColorCollections {
+		identifyPalettes(key, obj)
+	}
+	c.items[key] = obj
+	writeJSON(w, http.StatusOK, s.render(kind, obj))
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, sess *session, kind string, c *collection, key string, obj Object) {
+	var patch Object
+	if !decode(w, r, &patch) {
+		return
+	}
+	if obj["built_in"] == true {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Cannot modify a built-in %s.", c.kind))
+		return
+	}
+	if status, msg := s.checkWorkspace(kind, sess); status != 0 {
+		writeError(w, status, msg)
+		return
+	}
+
+	updated := obj.clone()
+	for k, v := range patch {
+		if k == "id" || k == "built_in" {
+			continue
+		}
+		updated[k] = v
+	}
+
+	// Objects addressed by name are renamed by moving them. The id of a project is its name as well.
+	newKey := key
+	if (c.key == "name" || kind == Projects) && updated.str("name") != key {
+		newKey = updated.str("name")
+		if _, exists := c.items[newKey]; exists {
+			writeValidation(w, []fieldError{{Field: "name", Code: "already_exists", Message: "Value is already used."}})
+			return
+		}
+		if kind == Projects {
+			updated["id"] = newKey
+		}
+	}
+
+	errs := append(c.validate(key, updated), s.checkReferences(kind, key, updated)...)
+	if len(errs) > 0 {
+		writeValidation(w, errs)
+		return
+	}
+
+	if kind == ColorCollections {
+		identifyPalettes(newKey, updated)
+	}
+	delete(c.items, key)
+	c.items[newKey] = updated
+	if kind == Projects && newKey != key {
+		if pub, ok := s.deployKeys[key]; ok {
+			delete(s.deployKeys, key)
+			s.deployKeys[newKey] = pub
+		}
+	}
+	writeJSON(w, http.StatusOK, s.render(kind, updated))
+}
+
+// checkWorkspace enforces that projects are only changed from the dev workspace.
+func (s *Server) checkWorkspace(kind string, sess *session) (int, string) {
+	if kind == Projects && sess.workspace != "dev" {
+		return http.StatusForbidden, "Projects can only be changed in the dev workspace."
+	}
+	return 0, ""
+}
+
+// checkReferences validates the ids obj refers to.
+func (s *Server) checkReferences(kind, key string, obj Object) []fieldError {
+	var errs []fieldError
+	ref := func(field, target string) {
+		if v := obj.str(field); v != "" {
+			if _, ok := s.store[target].items[v]; !ok {
+				errs = append(errs, fieldError{Field: field, Code: "invalid", Message: fmt.Sprintf("%s %s does not exist.", s.store[target].kind, v)})
+			}
+		}
+	}
+
+	switch kind {
+	case Roles:
+		ref("permission_set_id", PermissionSets)
+		ref("model_set_id", ModelSets)
+	case Folders:
+		ref("parent_id", Folders)
+		for k, other := range s.store[Folders].items {
+			if k != key && other.str("parent_id") == obj.str("parent_id") && strings.EqualFold(other.str("name"), obj.str("name")) {
+				errs = append(errs, fieldError{Field: "name", Code: "already_exists", Message: "A folder with this name already exists in the parent folder."})
+			}
+		}
+	case LookMLModels:
+		ref("project_name", Projects)
+	}
+	return errs
+}
+
+// search filters the objects on the query parameters, which match case-insensitively with % as wildcard.
+// Ids may be given as a comma separated list.
+func (s *Server) search(kind string, c *collection, q url.Values) []Object {
+	var found []Object
+	for _, obj := range c.list() {
+		rendered := s.render(kind, obj)
+		match := true
+		for param, values := range q {
+			switch param {
+			case "fields", "limit", "offset", "sorts", "page", "per_page", "filter_or":
+				continue
+			}
+			if param == "id" {
+				if !contains(strings.Split(values[0], ","), rendered.str("id")) {
+					match = false
+				}
+				continue
+			}
+			if !wildcardMatch(values[0], rendered.str(param)) {
+				match = false
+			}
+		}
+		if match {
+			found = append(found, obj)
+		}
+	}
+	return found
+}
+
+// render adds the fields the API computes, such as the members of groups.
+func (s *Server) render(kind string, obj Object) Object {
+	out := obj.clone()
+	switch kind {
+	case Users:
+		id := obj.str("id")
+		out["group_ids"] = toList(s.groupUsers.sources(id))
+		out["role_ids"] = toList(s.roleUsers.sources(id))
+		if email, ok := s.emails[id]; ok {
+			out["email"] = email["email"]
+			out["credentials_email"] = email.clone()
+		} else {
+			out["email"] = nil
+			out["credentials_email"] = nil
+		}
+	case Groups:
+		id := obj.str("id")
+		out["parent_group_ids"] = toList(s.groupGroups.sources(id))
+		out["role_ids"] = toList(s.roleGroups.sources(id))
+		out["user_count"] = len(s.groupUsers.targets(id))
+	case Roles:
+		if ps, ok := s.store[PermissionSets].items[obj.str("permission_set_id")]; ok {
+			out["permission_set"] = ps.clone()
+		}
+		if ms, ok := s.store[ModelSets].items[obj.str("model_set_id")]; ok {
+			out["model_set"] = ms.clone()
+		}
+	}
+	return out
+}
+
+// writeList writes a page of objs, following the limit/offset parameters and the Link/X-Total-Count headers
+// of Looker.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, kind string, objs []Object) {
+	q := r.URL.Query()
+	total := len(objs)
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 0
+	}
+
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	if limit > 0 {
+		link := func(off int, rel string) string {
+			u := *r.URL
+			uq := u.Query()
+			uq.Set("limit", strconv.Itoa(limit))
+			uq.Set("offset", strconv.Itoa(off))
+			u.RawQuery = uq.Encode()
+			return fmt.Sprintf(`<%s%s>; rel="%s"`, s.URL, u.RequestURI(), rel)
+		}
+		links := []string{link(0, "first"), link((maxInt(total-1, 0)/limit)*limit, "last")}
+		if end < total {
+			links = append(links, link(end, "next"))
+		}
+		if offset > 0 {
+			links = append(links, link(maxInt(offset-limit, 0), "prev"))
+		}
+		w.Header().Set("Link", strings.Join(links, ","))
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	}
+
+	page := make([]Object, 0, end-offset)
+	for _, obj := range objs[offset:end] {
+		page = append(page, filterFields(s.render(kind, obj), q.Get("fields")))
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func filterFields(obj Object, fields string) Object {
+	if fields == "" {
+		return obj
+	}
+	out := Object{}
+	for _, f := range splitFields(fields) {
+		// Nested objects are returned whole: "credentials_email(email)" selects credentials_email.
+		if i := strings.Index(f, "("); i >= 0 {
+			f = f[:i]
+		}
+		f = strings.TrimSpace(f)
+		if v, ok := obj[f]; ok {
+			out[f] = v
+		}
+	}
+	return out
+}
+
+// splitFields splits a fields parameter at the commas which are not within parentheses.
+func splitFields(fields string) []string {
+	var out []string
+	depth, start := 0, 0
+	for i, r := range fields {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, fields[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, fields[start:])
+}
+
+func wildcardMatch(pattern, value string) bool {
+	pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	parts := strings.Split(pattern, "%")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// identifyPalettes gives an id to the palettes of a color collection which have none, as Looker does.
+func identifyPalettes(collectionID string, obj Object) {
+	for _, field := range []string{"categoricalPalettes", "sequentialPalettes", "divergingPalettes"} {
+		palettes, _ := obj[field].([]interface{})
+		for i, p := range palettes {
+			if p, ok := p.(map[string]interface{}); ok && p["id"] == nil {
+				p["id"] = fmt.Sprintf("%s-%s-%d", collectionID, strings.TrimSuffix(field, "Palettes"), i)
+			}
+		}
+	}
+}
+
+func connectionTests(name string, tests []string) []Object {
+	if len(tests) == 0 || tests[0] == "" {
+		tests = []string{"connect"}
+	}
+	results := make([]Object, len(tests))
+	for i, t := range tests {
+		results[i] = Object{"name": t, "status": "success", "message": "Can connect", "connection_string": name}
+	}
+	return results
+}
+
+func toList(ids []string) []interface{} {
+	l := make([]interface{}, len(ids))
+	for i, id := range ids {
+		l[i] = id
+	}
+	return l
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if strings.TrimSpace(item) == v {
+			return true
+		}
+	}
+	return false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, Object{"message": msg, "documentation_url": docsURL})
+}
+
+// writeValidation answers 409 when a value is already used, 422 otherwise.
+func writeValidation(w http.ResponseWriter, errs []fieldError) {
+	status, msg := http.StatusUnprocessableEntity, "Validation Failed"
+	for _, e := range errs {
+		if e.Code == "already_exists" {
+			status, msg = http.StatusConflict, "Resource Already Exists"
+		}
+	}
+	writeJSON(w, status, map[string]interface{}{"message": msg, "errors": errs, "documentation_url": docsURL})
+}
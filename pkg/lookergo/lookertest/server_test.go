@@ -0,0 +1,190 @@
+package lookertest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+var ctx = context.Background()
+
+func newClient(t *testing.T) (*lookertest.Server, *lookergo.Client) {
+	t.Helper()
+
+	srv := lookertest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := lookergo.New(
+		lookergo.WithBaseURL(srv.BaseURL()),
+		lookergo.WithOAuthCredentials(srv.ClientID, srv.ClientSecret),
+		lookergo.WithLimiter(nil),
+	)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return srv, c
+}
+
+func TestServer_unauthorized(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+
+	c, err := lookergo.New(lookergo.WithBaseURL(srv.BaseURL()), lookergo.WithStaticToken("unknown"))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if _, _, err := c.Groups.List(ctx, nil); !lookergo.IsUnauthorized(err) {
+		t.Errorf("Groups.List with an unknown token = %v, expected 401", err)
+	}
+
+	c, _ = lookergo.New(lookergo.WithBaseURL(srv.BaseURL()), lookergo.WithOAuthCredentials(srv.ClientID, "wrong"))
+	if _, _, err := c.Groups.List(ctx, nil); !lookergo.IsUnauthorized(err) {
+		t.Errorf("Groups.List with wrong credentials = %v, expected an unauthorized error", err)
+	}
+}
+
+func TestServer_groups(t *testing.T) {
+	_, c := newClient(t)
+
+	parent, _, err := c.Groups.Create(ctx, &lookergo.Group{Name: "Analysts"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	child, _, err := c.Groups.Create(ctx, &lookergo.Group{Name: "Interns"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+
+	if _, _, err := c.Groups.Create(ctx, &lookergo.Group{Name: "analysts"}); !lookergo.IsConflict(err) {
+		t.Errorf("Groups.Create with a used name = %v, expected 409", err)
+	}
+	_, _, err = c.Groups.Create(ctx, &lookergo.Group{})
+	var errResp *lookergo.ErrorResponse
+	if !lookergo.IsValidation(err) || !errors.As(err, &errResp) || len(errResp.Errors) != 1 || errResp.Errors[0].Field != "name" {
+		t.Errorf("Groups.Create without name = %v, expected a validation error on name", err)
+	}
+
+	if _, _, err := c.Groups.AddMemberGroup(ctx, parent.Id, child.Id); err != nil {
+		t.Fatalf("Groups.AddMemberGroup returned error: %v", err)
+	}
+	if _, _, err := c.Groups.AddMemberGroup(ctx, child.Id, parent.Id); !lookergo.IsValidation(err) {
+		t.Errorf("Groups.AddMemberGroup with a cycle = %v, expected 422", err)
+	}
+
+	got, _, err := c.Groups.Get(ctx, child.Id, nil)
+	if err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	if len(got.ParentGroupIds) != 1 || got.ParentGroupIds[0] != parent.Id {
+		t.Errorf("ParentGroupIds = %v, expected [%s]", got.ParentGroupIds, parent.Id)
+	}
+
+	if _, err := c.Groups.Delete(ctx, parent.Id); err != nil {
+		t.Fatalf("Groups.Delete returned error: %v", err)
+	}
+	if _, _, err := c.Groups.Get(ctx, parent.Id, nil); !lookergo.IsNotFound(err) {
+		t.Errorf("Groups.Get after delete = %v, expected 404", err)
+	}
+	got, _, _ = c.Groups.Get(ctx, child.Id, nil)
+	if len(got.ParentGroupIds) != 0 {
+		t.Errorf("ParentGroupIds after deleting the parent = %v, expected none", got.ParentGroupIds)
+	}
+}
+
+func TestServer_pagination(t *testing.T) {
+	srv, c := newClient(t)
+
+	for i := 0; i < 7; i++ {
+		srv.Put(lookertest.Users, map[string]interface{}{"first_name": "User"})
+	}
+
+	users, _, err := lookergo.ListAll(ctx, &lookergo.ListOptions{Limit: 3}, c.Users.List)
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(users) != 8 {
+		t.Errorf("ListAll returned %d users, expected 8", len(users))
+	}
+}
+
+func TestServer_linkedUnpaged(t *testing.T) {
+	_, c := newClient(t)
+
+	parent, _, err := c.Groups.Create(ctx, &lookergo.Group{Name: "Analysts"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	for _, name := range []string{"Interns", "Seniors", "Managers"} {
+		child, _, err := c.Groups.Create(ctx, &lookergo.Group{Name: name})
+		if err != nil {
+			t.Fatalf("Groups.Create returned error: %v", err)
+		}
+		if _, _, err := c.Groups.AddMemberGroup(ctx, parent.Id, child.Id); err != nil {
+			t.Fatalf("AddMemberGroup returned error: %v", err)
+		}
+	}
+
+	// As Looker does, the members are all returned whatever the limit and offset.
+	groups, resp, err := c.Groups.ListMemberGroups(ctx, parent.Id, &lookergo.ListOptions{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("ListMemberGroups returned error: %v", err)
+	}
+	if len(groups) != 3 {
+		t.Errorf("ListMemberGroups returned %d groups, expected 3", len(groups))
+	}
+	if resp.Pages != nil {
+		t.Errorf("ListMemberGroups returned pages %+v, expected none", resp.Pages)
+	}
+}
+
+func TestServer_credentialsEmail(t *testing.T) {
+	_, c := newClient(t)
+
+	user, _, err := c.Users.Create(ctx, &lookergo.User{FirstName: "Jane", LastName: "Doe"})
+	if err != nil {
+		t.Fatalf("Users.Create returned error: %v", err)
+	}
+	if _, _, err := c.Users.GetEmail(ctx, user.Id); !lookergo.IsNotFound(err) {
+		t.Errorf("Users.GetEmail without credentials = %v, expected 404", err)
+	}
+	if _, _, err := c.Users.CreateEmail(ctx, user.Id, &lookergo.CredentialsEmail{Email: "admin@example.com"}); !lookergo.IsConflict(err) {
+		t.Errorf("Users.CreateEmail with a used email = %v, expected 409", err)
+	}
+	if _, _, err := c.Users.CreateEmail(ctx, user.Id, &lookergo.CredentialsEmail{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("Users.CreateEmail returned error: %v", err)
+	}
+
+	reset, _, err := c.Users.CreatePasswordReset(ctx, user.Id)
+	if err != nil {
+		t.Fatalf("Users.CreatePasswordReset returned error: %v", err)
+	}
+	if reset.Email != "jane@example.com" || reset.PasswordResetUrl == "" {
+		t.Errorf("Users.CreatePasswordReset = %+v, expected the email and a reset URL", reset)
+	}
+}
+
+func TestServer_roles(t *testing.T) {
+	_, c := newClient(t)
+
+	roles, _, err := c.Users.GetRoles(ctx, lookertest.AdminUserID)
+	if err != nil {
+		t.Fatalf("Users.GetRoles returned error: %v", err)
+	}
+	if len(roles) != 1 || roles[0].Name != "Admin" {
+		t.Errorf("Users.GetRoles = %+v, expected the Admin role", roles)
+	}
+
+	if _, _, err := c.Roles.RoleGroupsSet(ctx, "2", []lookergo.ID{"1", "42"}); !lookergo.IsValidation(err) {
+		t.Errorf("Roles.RoleGroupsSet with an unknown group = %v, expected 422", err)
+	}
+	groups, _, err := c.Roles.RoleGroupsSet(ctx, "2", []lookergo.ID{lookertest.AllUsersGroupID})
+	if err != nil {
+		t.Fatalf("Roles.RoleGroupsSet returned error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "All Users" {
+		t.Errorf("Roles.RoleGroupsSet = %+v, expected All Users", groups)
+	}
+}
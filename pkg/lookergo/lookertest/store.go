@@ -0,0 +1,169 @@
+package lookertest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Object is a stored API object, as it would be sent over the wire.
+type Object map[string]interface{}
+
+func (o Object) str(field string) string {
+	switch v := o[field].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (o Object) clone() Object {
+	c := make(Object, len(o))
+	for k, v := range o {
+		c[k] = v
+	}
+	return c
+}
+
+// collection holds the objects of one kind, keyed by their id (or name, for kinds addressed by name).
+type collection struct {
+	// Singular name used in messages, e.g. "group"
+	kind string
+	// Field identifying the objects in paths: "id" or "name"
+	key string
+	// Fields which must be set on create
+	required []string
+	// Field which must be unique, if any
+	unique string
+
+	nextID int
+	items  map[string]Object
+}
+
+func newCollection(kind, key string, required []string, unique string) *collection {
+	return &collection{kind: kind, key: key, required: required, unique: unique, nextID: 1, items: map[string]Object{}}
+}
+
+// newID returns the next free numeric id.
+func (c *collection) newID() string {
+	for {
+		id := strconv.Itoa(c.nextID)
+		c.nextID++
+		if _, ok := c.items[id]; !ok {
+			return id
+		}
+	}
+}
+
+// list returns the objects sorted by key, numerically when possible.
+func (c *collection) list() []Object {
+	keys := make([]string, 0, len(c.items))
+	for k := range c.items {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+
+	objs := make([]Object, len(keys))
+	for i, k := range keys {
+		objs[i] = c.items[k]
+	}
+	return objs
+}
+
+// validate checks the required and unique fields of obj, which is stored (or about to be) under key.
+func (c *collection) validate(key string, obj Object) []fieldError {
+	var errs []fieldError
+	for _, f := range c.required {
+		if strings.TrimSpace(obj.str(f)) == "" && !isNonEmptyList(obj[f]) {
+			errs = append(errs, fieldError{Field: f, Code: "missing", Message: "This field is required."})
+		}
+	}
+	if c.unique != "" && obj.str(c.unique) != "" {
+		for k, other := range c.items {
+			if k != key && strings.EqualFold(other.str(c.unique), obj.str(c.unique)) {
+				errs = append(errs, fieldError{Field: c.unique, Code: "already_exists", Message: "Value is already used."})
+			}
+		}
+	}
+	return errs
+}
+
+func isNonEmptyList(v interface{}) bool {
+	l, ok := v.([]interface{})
+	return ok && len(l) > 0
+}
+
+// relation is a many-to-many link, e.g. the member groups of groups.
+type relation map[string]map[string]bool
+
+func (r relation) add(from, to string) {
+	if r[from] == nil {
+		r[from] = map[string]bool{}
+	}
+	r[from][to] = true
+}
+
+func (r relation) remove(from, to string) {
+	delete(r[from], to)
+}
+
+func (r relation) set(from string, to []string) {
+	r[from] = map[string]bool{}
+	for _, t := range to {
+		r[from][t] = true
+	}
+}
+
+// targets returns the sorted ids linked from from.
+func (r relation) targets(from string) []string {
+	var ids []string
+	for id := range r[from] {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+	return ids
+}
+
+// sources returns the sorted ids linking to to.
+func (r relation) sources(to string) []string {
+	var ids []string
+	for from, tos := range r {
+		if tos[to] {
+			ids = append(ids, from)
+		}
+	}
+	sortIDs(ids)
+	return ids
+}
+
+// forget drops every link from or to id.
+func (r relation) forget(id string) {
+	delete(r, id)
+	for _, tos := range r {
+		delete(tos, id)
+	}
+}
+
+func sortIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		if a != b {
+			return a < b
+		}
+		return ids[i] < ids[j]
+	})
+}
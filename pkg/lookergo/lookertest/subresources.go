@@ -0,0 +1,257 @@
+package lookertest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sub serves the endpoints below an existing object, e.g. /groups/{id}/users.
+func (s *Server) sub(w http.ResponseWriter, r *http.Request, sess *session, kind, key string, segs []string) {
+	switch {
+	case kind == Users && segs[0] == "credentials_email":
+		s.credentialsEmail(w, r, key, segs[1:])
+	case kind == Users && segs[0] == "roles" && len(segs) == 1:
+		s.links(w, r, Roles, key, s.roleUsers, true)
+	case kind == Groups && segs[0] == "groups" && len(segs) <= 2:
+		s.members(w, r, Groups, key, s.groupGroups, segs[1:])
+	case kind == Groups && segs[0] == "users" && len(segs) <= 2:
+		s.members(w, r, Users, key, s.groupUsers, segs[1:])
+	case kind == Roles && segs[0] == "groups" && len(segs) == 1:
+		s.links(w, r, Groups, key, s.roleGroups, false)
+	case kind == Roles && segs[0] == "users" && len(segs) == 1:
+		s.links(w, r, Users, key, s.roleUsers, false)
+	case kind == Projects && len(segs) == 2 && segs[0] == "git" && segs[1] == "deploy_key":
+		s.deployKey(w, r, sess, key)
+	case kind == Projects && len(segs) == 1 && (segs[0] == "deploy_to_production" || segs[0] == "deploy_ref_to_production"):
+		s.deploy(w, r, key)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// links serves a list of linked objects which is replaced as a whole with PUT, e.g. /roles/{id}/groups.
+// reverse tells whether key is on the target side of rel, as for /users/{id}/roles.
+func (s *Server) links(w http.ResponseWriter, r *http.Request, targetKind, key string, rel relation, reverse bool) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var ids []interface{}
+		if !decode(w, r, &ids) {
+			return
+		}
+		var targets []string
+		var errs []fieldError
+		for _, raw := range ids {
+			id := Object{"id": raw}.str("id")
+			if _, ok := s.store[targetKind].items[id]; !ok {
+				errs = append(errs, fieldError{Field: "id", Code: "invalid", Message: fmt.Sprintf("%s %s does not exist.", s.store[targetKind].kind, id)})
+			}
+			targets = append(targets, id)
+		}
+		if len(errs) > 0 {
+			writeValidation(w, errs)
+			return
+		}
+
+		if reverse {
+			for _, from := range rel.sources(key) {
+				rel.remove(from, key)
+			}
+			for _, from := range targets {
+				rel.add(from, key)
+			}
+		} else {
+			rel.set(key, targets)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	ids := rel.targets(key)
+	if reverse {
+		ids = rel.sources(key)
+	}
+	s.writeLinked(w, r, targetKind, ids)
+}
+
+// members serves the members of a group: list, add with POST {"group_id"} or {"user_id"}, remove with DELETE.
+func (s *Server) members(w http.ResponseWriter, r *http.Request, memberKind, groupID string, rel relation, segs []string) {
+	field := "user_id"
+	if memberKind == Groups {
+		field = "group_id"
+	}
+
+	switch {
+	case len(segs) == 0 && r.Method == http.MethodGet:
+		s.writeLinked(w, r, memberKind, rel.targets(groupID))
+	case len(segs) == 0 && r.Method == http.MethodPost:
+		var body Object
+		if !decode(w, r, &body) {
+			return
+		}
+		memberID := body.str(field)
+		member, ok := s.store[memberKind].items[memberID]
+		if !ok {
+			writeValidation(w, []fieldError{{Field: field, Code: "invalid", Message: fmt.Sprintf("%s %s does not exist.", s.store[memberKind].kind, memberID)}})
+			return
+		}
+		if memberKind == Groups && (memberID == groupID || s.nestedIn(groupID, memberID)) {
+			writeValidation(w, []fieldError{{Field: field, Code: "invalid", Message: "A group cannot be nested in itself."}})
+			return
+		}
+		rel.add(groupID, memberID)
+		writeJSON(w, http.StatusOK, s.render(memberKind, member))
+	case len(segs) == 1 && r.Method == http.MethodDelete:
+		if !rel[groupID][segs[0]] {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		rel.remove(groupID, segs[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// nestedIn reports whether group is, directly or not, a member of ancestor.
+func (s *Server) nestedIn(group, ancestor string) bool {
+	for _, member := range s.groupGroups.targets(ancestor) {
+		if member == group || s.nestedIn(group, member) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeLinked writes all the objects of ids at once. Looker does not page the linked sub-collections: limit and
+// offset are ignored, and no Link or X-Total-Count header is set.
+func (s *Server) writeLinked(w http.ResponseWriter, r *http.Request, kind string, ids []string) {
+	fields := r.URL.Query().Get("fields")
+	objs := make([]Object, 0, len(ids))
+	for _, id := range ids {
+		if obj, ok := s.store[kind].items[id]; ok {
+			objs = append(objs, filterFields(s.render(kind, obj), fields))
+		}
+	}
+	writeJSON(w, http.StatusOK, objs)
+}
+
+func (s *Server) credentialsEmail(w http.ResponseWriter, r *http.Request, userID string, segs []string) {
+	email, exists := s.emails[userID]
+
+	if len(segs) == 1 && (segs[0] == "password_reset" || segs[0] == "send_password_reset") && r.Method == http.MethodPost {
+		if !exists {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		email["password_reset_url"] = fmt.Sprintf("%s/password/reset/%s", s.URL, userID)
+		writeJSON(w, http.StatusOK, email)
+		return
+	}
+	if len(segs) != 0 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, email)
+	case http.MethodPost, http.MethodPatch:
+		if r.Method == http.MethodPost && exists {
+			writeValidation(w, []fieldError{{Field: "email", Code: "already_exists", Message: "User already has email credentials."}})
+			return
+		}
+		if r.Method == http.MethodPatch && !exists {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		var body Object
+		if !decode(w, r, &body) {
+			return
+		}
+		updated := Object{}
+		for k, v := range email {
+			updated[k] = v
+		}
+		for k, v := range body {
+			updated[k] = v
+		}
+		if updated.str("email") == "" {
+			writeValidation(w, []fieldError{{Field: "email", Code: "missing", Message: "This field is required."}})
+			return
+		}
+		for other, e := range s.emails {
+			if other != userID && e.str("email") == updated.str("email") {
+				writeValidation(w, []fieldError{{Field: "email", Code: "already_exists", Message: "Email is already used by another user."}})
+				return
+			}
+		}
+		s.emails[userID] = updated
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		if !exists {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		delete(s.emails, userID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// deployKey serves the public key Looker uses to access the git repository of a project.
+func (s *Server) deployKey(w http.ResponseWriter, r *http.Request, sess *session, projectID string) {
+	switch r.Method {
+	case http.MethodGet:
+		key, ok := s.deployKeys[projectID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, key)
+	case http.MethodPost:
+		if status, msg := s.checkWorkspace(Projects, sess); status != 0 {
+			writeError(w, status, msg)
+			return
+		}
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sshPub, err := ssh.NewPublicKey(pub)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		s.deployKeys[projectID] = string(ssh.MarshalAuthorizedKey(sshPub))
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, s.deployKeys[projectID])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// deploy accepts a deployment of the project to production, which needs a git repository.
+func (s *Server) deploy(w http.ResponseWriter, r *http.Request, projectID string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if s.store[Projects].items[projectID].str("git_service_name") == "" {
+		writeError(w, http.StatusBadRequest, "Project has no git repository.")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -20,22 +20,25 @@ func TestRolesResourceOp_ModelSetsList(t *testing.T) {
 ]`)
 	})
 
-	result, resp, err := client.ModelSets.List(ctx)
+	result, resp, err := client.ModelSets.List(ctx, nil)
 	_ = resp
 	if err != nil {
 		t.Errorf("Projects.Get returned error: %v", err)
 	}
 
+	can := map[string]bool{"show": true, "index": true, "update": true}
 	expected := []ModelSet{
 		{
+			Can:       can,
 			BuiltIn:   true,
 			Id:        "1",
 			AllAccess: true,
 			Models:    []string{"another_dink", "dink_th_test"},
 			Name:      "All",
+			Url:       "https://localhost:19999/api/4.0/model_sets/1",
 		},
-		{Id: "2", Models: []string{"accounts", "documents"}, Name: "Model Set"},
-		{Id: "5", Models: []string{"dink_th_test"}, Name: "Dink TH"},
+		{Can: can, Id: "2", Models: []string{"accounts", "documents"}, Name: "Model Set", Url: "https://localhost:19999/api/4.0/model_sets/2"},
+		{Can: can, Id: "5", Models: []string{"dink_th_test"}, Name: "Dink TH", Url: "https://localhost:19999/api/4.0/model_sets/5"},
 	}
 	if !reflect.DeepEqual(result, expected) {
 		t.Error(errGotWant("ModelSets.List", result, expected))
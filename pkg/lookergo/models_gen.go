@@ -0,0 +1,125 @@
+// Code generated by lookergen from Looker.4.0.json. DO NOT EDIT.
+
+package lookergo
+
+// ModelSet -
+type ModelSet struct {
+	Can       map[string]bool `json:"can,omitempty"` // Operations the current user is able to perform on this object
+	AllAccess bool            `json:"all_access,omitempty"`
+	BuiltIn   bool            `json:"built_in,omitempty"`
+	Id        ID              `json:"id,omitempty"` // Unique Id
+	Models    []string        `json:"models,omitempty"`
+	Name      string          `json:"name,omitempty"` // Name of ModelSet
+	Url       string          `json:"url,omitempty"`  // Link to get this item
+}
+
+// WriteModelSet is the body of a ModelSet update. Fields left nil are not changed.
+type WriteModelSet struct {
+	Models *Nullable[[]string] `json:"models,omitempty"`
+	Name   *Nullable[string]   `json:"name,omitempty"` // Name of ModelSet
+}
+
+// DBConnectionOverride -
+type DBConnectionOverride struct {
+	Context                string `json:"context,omitempty"`                  // Context in which to override (`pdt` is the only allowed value)
+	Host                   string `json:"host,omitempty"`                     // Host name/address of server
+	Port                   string `json:"port,omitempty"`                     // Port number on server
+	Username               string `json:"username,omitempty"`                 // Username for server authentication
+	Password               string `json:"password,omitempty"`                 // (Write-Only) Password for server authentication
+	HasPassword            bool   `json:"has_password,omitempty"`             // Whether or not the password is overridden in this context
+	Certificate            string `json:"certificate,omitempty"`              // (Write-Only) Base64 encoded Certificate body for server authentication (when appropriate for dialect).
+	FileType               string `json:"file_type,omitempty"`                // (Write-Only) Certificate keyfile type - .json or .p12
+	Database               string `json:"database,omitempty"`                 // Database name
+	Schema                 string `json:"schema,omitempty"`                   // Scheme name
+	JdbcAdditionalParams   string `json:"jdbc_additional_params,omitempty"`   // Additional params to add to JDBC connection string
+	AfterConnectStatements string `json:"after_connect_statements,omitempty"` // SQL statements (semicolon separated) to issue after connecting to the database. Requires `custom_after_connect_statements` license feature
+}
+
+// CredentialsApi3 -
+type CredentialsApi3 struct {
+	Can        map[string]bool `json:"can,omitempty"`         // Operations the current user is able to perform on this object
+	Id         ID              `json:"id,omitempty"`          // Unique Id
+	ClientId   string          `json:"client_id,omitempty"`   // API key client_id
+	CreatedAt  string          `json:"created_at,omitempty"`  // Timestamp for the creation of this credential
+	IsDisabled bool            `json:"is_disabled,omitempty"` // Has this credential been disabled?
+	Type       string          `json:"type,omitempty"`        // Short name for the type of this kind of credential
+	Url        string          `json:"url,omitempty"`         // Link to get this item
+}
+
+// CredentialsGoogle -
+type CredentialsGoogle struct {
+	Can          map[string]bool `json:"can,omitempty"`            // Operations the current user is able to perform on this object
+	CreatedAt    string          `json:"created_at,omitempty"`     // Timestamp for the creation of this credential
+	Domain       string          `json:"domain,omitempty"`         // Google domain
+	Email        string          `json:"email,omitempty"`          // EMail address
+	GoogleUserId string          `json:"google_user_id,omitempty"` // Google's Unique ID for this user
+	IsDisabled   bool            `json:"is_disabled,omitempty"`    // Has this credential been disabled?
+	LoggedInAt   string          `json:"logged_in_at,omitempty"`   // Timestamp for most recent login using credential
+	Type         string          `json:"type,omitempty"`           // Short name for the type of this kind of credential
+	Url          string          `json:"url,omitempty"`            // Link to get this item
+}
+
+// CredentialsLDAP -
+type CredentialsLDAP struct {
+	Can        map[string]bool `json:"can,omitempty"`          // Operations the current user is able to perform on this object
+	CreatedAt  string          `json:"created_at,omitempty"`   // Timestamp for the creation of this credential
+	Email      string          `json:"email,omitempty"`        // EMail address
+	IsDisabled bool            `json:"is_disabled,omitempty"`  // Has this credential been disabled?
+	LdapDn     string          `json:"ldap_dn,omitempty"`      // LDAP Distinguished name for this user (as-of the last login)
+	LdapId     string          `json:"ldap_id,omitempty"`      // LDAP Unique ID for this user
+	LoggedInAt string          `json:"logged_in_at,omitempty"` // Timestamp for most recent login using credential
+	Type       string          `json:"type,omitempty"`         // Short name for the type of this kind of credential
+	Url        string          `json:"url,omitempty"`          // Link to get this item
+}
+
+// CredentialsLookerOpenid -
+type CredentialsLookerOpenid struct {
+	Can        map[string]bool `json:"can,omitempty"`          // Operations the current user is able to perform on this object
+	CreatedAt  string          `json:"created_at,omitempty"`   // Timestamp for the creation of this credential
+	Email      string          `json:"email,omitempty"`        // EMail address used for user login
+	IsDisabled bool            `json:"is_disabled,omitempty"`  // Has this credential been disabled?
+	LoggedInAt string          `json:"logged_in_at,omitempty"` // Timestamp for most recent login using credential
+	LoggedInIp string          `json:"logged_in_ip,omitempty"` // IP address of client for most recent login using credential
+	Type       string          `json:"type,omitempty"`         // Short name for the type of this kind of credential
+	Url        string          `json:"url,omitempty"`          // Link to get this item
+	UserUrl    string          `json:"user_url,omitempty"`     // Link to get this user
+}
+
+// CredentialsOIDC -
+type CredentialsOIDC struct {
+	Can        map[string]bool `json:"can,omitempty"`          // Operations the current user is able to perform on this object
+	CreatedAt  string          `json:"created_at,omitempty"`   // Timestamp for the creation of this credential
+	Email      string          `json:"email,omitempty"`        // EMail address
+	IsDisabled bool            `json:"is_disabled,omitempty"`  // Has this credential been disabled?
+	LoggedInAt string          `json:"logged_in_at,omitempty"` // Timestamp for most recent login using credential
+	OidcUserId string          `json:"oidc_user_id,omitempty"` // OIDC OP's Unique ID for this user
+	Type       string          `json:"type,omitempty"`         // Short name for the type of this kind of credential
+	Url        string          `json:"url,omitempty"`          // Link to get this item
+}
+
+// CredentialsTotp -
+type CredentialsTotp struct {
+	Can        map[string]bool `json:"can,omitempty"`         // Operations the current user is able to perform on this object
+	CreatedAt  string          `json:"created_at,omitempty"`  // Timestamp for the creation of this credential
+	IsDisabled bool            `json:"is_disabled,omitempty"` // Has this credential been disabled?
+	Type       string          `json:"type,omitempty"`        // Short name for the type of this kind of credential
+	Verified   bool            `json:"verified,omitempty"`    // User has verified
+	Url        string          `json:"url,omitempty"`         // Link to get this item
+}
+
+// ApiVersion -
+type ApiVersion struct {
+	LookerReleaseVersion string              `json:"looker_release_version,omitempty"` // Current Looker release version number
+	CurrentVersion       *ApiVersionElement  `json:"current_version,omitempty"`        // Dynamic writeable type for ApiVersionElement removes: version, full_version, status, swagger_url
+	SupportedVersions    []ApiVersionElement `json:"supported_versions,omitempty"`     // Array of versions supported by this Looker instance
+	ApiServerUrl         string              `json:"api_server_url,omitempty"`         // API server base url
+	WebServerUrl         string              `json:"web_server_url,omitempty"`         // Web server base url
+}
+
+// ApiVersionElement -
+type ApiVersionElement struct {
+	Version     string `json:"version,omitempty"`      // Version number as it appears in '/api/xxx/' urls
+	FullVersion string `json:"full_version,omitempty"` // Full version number including minor version
+	Status      string `json:"status,omitempty"`       // Status of this version
+	SwaggerUrl  string `json:"swagger_url,omitempty"`  // Url for swagger.json for this version
+}
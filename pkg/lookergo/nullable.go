@@ -0,0 +1,68 @@
+package lookergo
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a field of an update request, which tells apart a field left out, a field set to null and a
+// field set to its zero value. The Write* structs sent by Update hold *Nullable fields:
+//
+//	nil            the field is left out of the PATCH and keeps its value
+//	Null[T]()      the field is sent as null, which clears it
+//	Value(v)       the field is sent as v, even if v is false, 0 or ""
+type Nullable[T any] struct {
+	value T
+	null  bool
+}
+
+// Value returns a field set to v.
+func Value[T any](v T) *Nullable[T] {
+	return &Nullable[T]{value: v}
+}
+
+// Null returns a field set to null.
+func Null[T any]() *Nullable[T] {
+	return &Nullable[T]{null: true}
+}
+
+// Get returns the value of the field, and false if the field is nil or null.
+func (n *Nullable[T]) Get() (T, bool) {
+	if n == nil || n.null {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+// IsNull reports whether the field is set to null.
+func (n *Nullable[T]) IsNull() bool {
+	return n != nil && n.null
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if n.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Nullable[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		*n = Nullable[T]{null: true}
+		return nil
+	}
+	*n = Nullable[T]{}
+	return json.Unmarshal(b, &n.value)
+}
+
+// NullableString returns a field set to s, or to null if s is empty. It suits optional strings for which the API
+// treats null as unset.
+func NullableString(s string) *Nullable[string] {
+	if s == "" {
+		return Null[string]()
+	}
+	return Value(s)
+}
@@ -0,0 +1,57 @@
+package lookergo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullable_marshal(t *testing.T) {
+	tests := map[string]struct {
+		update   WriteUser
+		expected string
+	}{
+		"unset": {WriteUser{}, `{}`},
+		"null":  {WriteUser{Locale: Null[string]()}, `{"locale":null}`},
+		"zero":  {WriteUser{IsDisabled: Value(false), FirstName: Value("")}, `{"first_name":"","is_disabled":false}`},
+		"value": {WriteUser{LastName: Value("Doe")}, `{"last_name":"Doe"}`},
+	}
+	for name, tt := range tests {
+		b, err := json.Marshal(tt.update)
+		if err != nil {
+			t.Fatalf("%s: Marshal returned error: %v", name, err)
+		}
+		if string(b) != tt.expected {
+			t.Errorf("%s: Marshal = %s, expected %s", name, b, tt.expected)
+		}
+	}
+}
+
+func TestNullable_unmarshal(t *testing.T) {
+	var n struct {
+		A *Nullable[bool]   `json:"a"`
+		B *Nullable[string] `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":false}`), &n); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if v, ok := n.A.Get(); !ok || v {
+		t.Errorf("a = %v, %v, expected false, true", v, ok)
+	}
+	if n.B != nil || n.B.IsNull() {
+		t.Errorf("b = %v, expected it unset", n.B)
+	}
+
+	var m Nullable[string]
+	if err := json.Unmarshal([]byte(`null`), &m); err != nil || !m.IsNull() {
+		t.Errorf("Unmarshal(null) = %+v, %v, expected null", m, err)
+	}
+}
+
+func TestNullableString(t *testing.T) {
+	if !NullableString("").IsNull() {
+		t.Errorf("NullableString(\"\") is not null")
+	}
+	if v, ok := NullableString("x").Get(); !ok || v != "x" {
+		t.Errorf("NullableString(\"x\") = %v, %v", v, ok)
+	}
+}
@@ -0,0 +1,228 @@
+package lookergo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// clientOptions collects the options given to New, so they can be validated and applied in a fixed order.
+type clientOptions struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	staticToken  string
+	credProcess  []string
+	transport    http.RoundTripper
+	timeout      time.Duration
+	headers      map[string]string
+	userAgent    string
+	limiter      *Limiter
+	limiterSet   bool
+	retryPolicy  RetryPolicy
+	logger       Logger
+	apiVersion   string
+	callback     RequestCompletionCallback
+	errCallback  RequestErrorCallback
+	httpLog      *HTTPLogOptions
+	readOnly     bool
+
+	tracerProvider trace.TracerProvider
+}
+
+// WithBaseURL sets the URL of the API, including the /api/ path, e.g. https://example.cloud.looker.com/api/.
+func WithBaseURL(bu string) ClientOpt {
+	return func(o *clientOptions) error {
+		o.baseURL = bu
+		return nil
+	}
+}
+
+// WithOAuthCredentials authenticates with API client credentials, refreshing the access token as needed.
+func WithOAuthCredentials(clientID, clientSecret string) ClientOpt {
+	return func(o *clientOptions) error {
+		o.clientID = strings.Trim(strings.TrimSpace(clientID), "'")
+		o.clientSecret = strings.Trim(strings.TrimSpace(clientSecret), "'")
+		return nil
+	}
+}
+
+// WithStaticToken authenticates with an existing access token, which is never refreshed.
+func WithStaticToken(accessToken string) ClientOpt {
+	return func(o *clientOptions) error {
+		o.staticToken = strings.TrimSpace(accessToken)
+		return nil
+	}
+}
+
+// WithCredentialProcess authenticates with the credentials printed by a local command, e.g. one reading them from a
+// secrets manager, in the ProcessCredentials format. command is the program followed by its arguments. The command is
+// run again for each new token: when the access token it printed expires, or when the token obtained with the client
+// credentials it printed does.
+func WithCredentialProcess(command ...string) ClientOpt {
+	return func(o *clientOptions) error {
+		o.credProcess = append([]string{}, command...)
+		return nil
+	}
+}
+
+// WithTransport sets the transport under the authentication layer, e.g. for proxies or recording.
+func WithTransport(rt http.RoundTripper) ClientOpt {
+	return func(o *clientOptions) error {
+		o.transport = rt
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of a single attempt of a request, including reading the response body.
+func WithTimeout(d time.Duration) ClientOpt {
+	return func(o *clientOptions) error {
+		o.timeout = d
+		return nil
+	}
+}
+
+// WithHeaders sets extra HTTP headers sent on every request.
+func WithHeaders(headers map[string]string) ClientOpt {
+	return func(o *clientOptions) error {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+		return nil
+	}
+}
+
+// WithUserAgent prepends ua to the user agent of the library.
+func WithUserAgent(ua string) ClientOpt {
+	return func(o *clientOptions) error {
+		o.userAgent = ua
+		return nil
+	}
+}
+
+// WithLimiter sets the limiter throttling the requests. A nil limiter disables throttling.
+func WithLimiter(l *Limiter) ClientOpt {
+	return func(o *clientOptions) error {
+		o.limiter, o.limiterSet = l, true
+		return nil
+	}
+}
+
+// WithRetryPolicy sets how transient failures are retried.
+func WithRetryPolicy(p RetryPolicy) ClientOpt {
+	return func(o *clientOptions) error {
+		o.retryPolicy = p
+		return nil
+	}
+}
+
+// WithLogger sets the logger receiving the diagnostic messages of the client.
+func WithLogger(l Logger) ClientOpt {
+	return func(o *clientOptions) error {
+		o.logger = l
+		return nil
+	}
+}
+
+// WithAPIVersion sets the version of the API, e.g. "4.0".
+func WithAPIVersion(v string) ClientOpt {
+	return func(o *clientOptions) error {
+		o.apiVersion = v
+		return nil
+	}
+}
+
+// WithRequestCompletionCallback sets the function called after every request made to the API, once its retries are
+// over.
+func WithRequestCompletionCallback(rc RequestCompletionCallback) ClientOpt {
+	return func(o *clientOptions) error {
+		o.callback = rc
+		return nil
+	}
+}
+
+// WithRequestErrorCallback sets the function called after every request which got no response from the API, once
+// its retries are over.
+func WithRequestErrorCallback(rc RequestErrorCallback) ClientOpt {
+	return func(o *clientOptions) error {
+		o.errCallback = rc
+		return nil
+	}
+}
+
+// WithHTTPLogging logs every request and response of the client, including the login, with their secrets redacted.
+func WithHTTPLogging(opts HTTPLogOptions) ClientOpt {
+	return func(o *clientOptions) error {
+		o.httpLog = &opts
+		return nil
+	}
+}
+
+// WithReadOnly makes the client refuse, with ErrReadOnly, the requests that could change the instance: all but GET and
+// HEAD, except the logins, logouts and workspace switches, which only change the session of the client. The clients
+// derived from it, see AsUser and WorkspaceSession, are read-only as well.
+func WithReadOnly(readOnly bool) ClientOpt {
+	return func(o *clientOptions) error {
+		o.readOnly = readOnly
+		return nil
+	}
+}
+
+// WithTracerProvider traces the calls of Do with tp, see TracerName: a span per call, child of the span of its
+// context, recording the route, status, retries and limiter wait of the request, and a span per token fetched. The
+// clients derived from it, see AsUser and WorkspaceSession, are traced as well. A nil tp, the default, disables tracing.
+func WithTracerProvider(tp trace.TracerProvider) ClientOpt {
+	return func(o *clientOptions) error {
+		o.tracerProvider = tp
+		return nil
+	}
+}
+
+var apiVersionRe = regexp.MustCompile(`^\d+\.\d+$`)
+
+func (o *clientOptions) validate() error {
+	if o.baseURL == "" {
+		return NewArgError("base URL", "it is required")
+	}
+	u, err := url.Parse(o.baseURL)
+	if err != nil {
+		return NewArgError("base URL", err.Error())
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return NewArgError("base URL", fmt.Sprintf("%q is not an absolute http(s) URL", o.baseURL))
+	}
+
+	if (o.clientID == "") != (o.clientSecret == "") {
+		return NewArgError("OAuth credentials", "both client id and client secret must be set")
+	}
+	if o.clientID != "" && o.staticToken != "" {
+		return NewArgError("static token", "it cannot be combined with OAuth credentials")
+	}
+	if o.credProcess != nil {
+		if len(o.credProcess) == 0 || o.credProcess[0] == "" {
+			return NewArgError("credential process", "the command is empty")
+		}
+		if o.clientID != "" || o.staticToken != "" {
+			return NewArgError("credential process", "it cannot be combined with OAuth credentials or a static token")
+		}
+	}
+	if o.httpLog != nil && o.httpLog.MaxBodySize < 0 {
+		return NewArgError("log max body size", "it cannot be negative")
+	}
+	if o.timeout < 0 {
+		return NewArgError("timeout", "it cannot be negative")
+	}
+	if !apiVersionRe.MatchString(o.apiVersion) {
+		return NewArgError("API version", fmt.Sprintf("%q is not of the form 4.0", o.apiVersion))
+	}
+
+	return o.retryPolicy.Validate()
+}
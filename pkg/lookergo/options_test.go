@@ -0,0 +1,148 @@
+package lookergo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNew_validation(t *testing.T) {
+	tests := map[string][]ClientOpt{
+		"no base url":          {},
+		"relative base url":    {WithBaseURL("example.com/api/")},
+		"unsupported scheme":   {WithBaseURL("ftp://example.com/api/")},
+		"missing secret":       {WithBaseURL("https://example.com/api/"), WithOAuthCredentials("id", "")},
+		"token and creds":      {WithBaseURL("https://example.com/api/"), WithOAuthCredentials("id", "secret"), WithStaticToken("tok")},
+		"process and token":    {WithBaseURL("https://example.com/api/"), WithStaticToken("tok"), WithCredentialProcess("creds")},
+		"empty process":        {WithBaseURL("https://example.com/api/"), WithCredentialProcess()},
+		"negative timeout":     {WithBaseURL("https://example.com/api/"), WithTimeout(-1)},
+		"bad api version":      {WithBaseURL("https://example.com/api/"), WithAPIVersion("latest")},
+		"invalid retry policy": {WithBaseURL("https://example.com/api/"), WithRetryPolicy(RetryPolicy{})},
+		"negative log body":    {WithBaseURL("https://example.com/api/"), WithHTTPLogging(HTTPLogOptions{MaxBodySize: -1})},
+	}
+	for name, opts := range tests {
+		if c, err := New(opts...); err == nil {
+			t.Errorf("%s: New() = %v, expected an error", name, c)
+		}
+	}
+}
+
+type countingTransport struct {
+	calls int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNew_staticToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/4.0/session", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("Authorization = %q, expected %q", got, "Bearer tok")
+		}
+		if got := r.Header.Get("X-Team"); got != "data" {
+			t.Errorf("X-Team = %q, expected %q", got, "data")
+		}
+		if got := r.Header.Get("User-Agent"); !strings.HasPrefix(got, "terraform ") {
+			t.Errorf("User-Agent = %q, expected the terraform prefix", got)
+		}
+		fmt.Fprint(w, `{"workspace_id":"production"}`)
+	})
+
+	transport := &countingTransport{}
+	c, err := New(
+		WithBaseURL(server.URL+"/api"),
+		WithStaticToken("tok"),
+		WithTransport(transport),
+		WithHeaders(map[string]string{"X-Team": "data"}),
+		WithUserAgent("terraform"),
+		WithLimiter(nil),
+	)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	session, _, err := c.Sessions.Get(ctx)
+	if err != nil {
+		t.Fatalf("Sessions.Get returned error: %v", err)
+	}
+	if session.WorkspaceId != "production" {
+		t.Errorf("WorkspaceId = %q, expected production", session.WorkspaceId)
+	}
+	if transport.calls != 1 {
+		t.Errorf("custom transport was used %d times, expected 1", transport.calls)
+	}
+}
+
+func TestNew_oauthCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/4.0/login", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if err := r.ParseForm(); err != nil || r.Form.Get("client_id") != "id" || r.Form.Get("client_secret") != "secret" {
+			t.Errorf("login form = %v, %v", r.Form, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"fresh","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/api/4.0/session", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer fresh" {
+			t.Errorf("Authorization = %q, expected %q", got, "Bearer fresh")
+		}
+		fmt.Fprint(w, `{"workspace_id":"dev"}`)
+	})
+
+	transport := &countingTransport{}
+	c, err := New(WithBaseURL(server.URL+"/api/"), WithOAuthCredentials("id", "secret"), WithTransport(transport))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if _, _, err := c.Sessions.Get(ctx); err != nil {
+		t.Fatalf("Sessions.Get returned error: %v", err)
+	}
+	if transport.calls != 2 {
+		t.Errorf("custom transport was used %d times, expected 2 (login and session)", transport.calls)
+	}
+}
+
+func TestNew_apiVersion(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/4.1/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"fresh","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/api/4.1/groups/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"7","name":"Admins"}`)
+	})
+	mux.HandleFunc("/api/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"supported_versions":[{"version":"4.0"},{"version":"4.1"}]}`)
+	})
+
+	c, err := New(WithBaseURL(server.URL+"/api/"), WithOAuthCredentials("id", "secret"), WithAPIVersion("4.1"))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if c.APIVersion() != "4.1" {
+		t.Errorf("APIVersion = %q, expected 4.1", c.APIVersion())
+	}
+	if _, _, err := c.Groups.Get(ctx, "7", nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	// The versions endpoint is not below the API version.
+	versions, _, err := c.Versions.Get(ctx)
+	if err != nil {
+		t.Fatalf("Versions.Get returned error: %v", err)
+	}
+	if !versions.Supports("4.1") {
+		t.Errorf("Supports(4.1) = false for %v", versions.SupportedVersionNames())
+	}
+}
@@ -0,0 +1,216 @@
+package lookergo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultPageSize is the limit used by ListAll and Iterator when the caller did not set one.
+const defaultPageSize = 100
+
+// maxUnpagedPages bounds the full pages read without pagination headers. An endpoint that ignores limit and
+// offset serves the same full page forever, and its rows cannot be told apart from a long list of equal rows.
+const maxUnpagedPages = 1000
+
+// Pages contains the pagination information Looker returns on list and search endpoints.
+//
+//	GET {{endpoint}}/4.0/users?limit=5
+//	(H) X-Total-Count: 107
+//	(H) Link: <https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=0>; rel="first",
+//	          <https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=105>; rel="last",
+//	          <https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=5>; rel="next"
+type Pages struct {
+	// Total number of items as reported by the X-Total-Count header, -1 if the header was absent.
+	TotalCount int
+	// Page size of the links, as found in the Link header.
+	Limit int
+	// Offsets of the first, previous, next and last page. Only meaningful when the matching Has* is set.
+	FirstOffset int
+	PrevOffset  int
+	NextOffset  int
+	LastOffset  int
+	HasFirst    bool
+	HasPrev     bool
+	HasNext     bool
+	HasLast     bool
+}
+
+var linkRe = regexp.MustCompile(`<([^>]*)>\s*;\s*rel="?([a-zA-Z]+)"?`)
+
+// parsePages reads the X-Total-Count and Link headers. It returns nil when neither is present.
+func parsePages(h http.Header) *Pages {
+	total := h.Get("X-Total-Count")
+	link := h.Get("Link")
+	if total == "" && link == "" {
+		return nil
+	}
+
+	p := &Pages{TotalCount: -1}
+	if n, err := strconv.Atoi(strings.TrimSpace(total)); err == nil {
+		p.TotalCount = n
+	}
+
+	for _, m := range linkRe.FindAllStringSubmatch(link, -1) {
+		u, err := url.Parse(m[1])
+		if err != nil {
+			continue
+		}
+		q := u.Query()
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		if limit, err := strconv.Atoi(q.Get("limit")); err == nil {
+			p.Limit = limit
+		}
+
+		switch m[2] {
+		case "first":
+			p.FirstOffset, p.HasFirst = offset, true
+		case "prev":
+			p.PrevOffset, p.HasPrev = offset, true
+		case "next":
+			p.NextOffset, p.HasNext = offset, true
+		case "last":
+			p.LastOffset, p.HasLast = offset, true
+		}
+	}
+
+	return p
+}
+
+// ListFunc is the shape of every paginated List or search method, once the non-paging arguments are bound.
+type ListFunc[T any] func(context.Context, *ListOptions) ([]T, *Response, error)
+
+// Iterator walks all pages of a List or search endpoint.
+//
+//	it := NewIterator(nil, client.Users.List)
+//	for it.Next(ctx) {
+//		user := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	list  ListFunc[T]
+	opt   ListOptions
+	page  []T
+	idx   int
+	resp  *Response
+	err   error
+	done  bool
+	begun bool
+	// Number of full pages read without pagination headers.
+	unpaged int
+}
+
+// NewIterator returns an iterator over all items of list. The Limit of opt is used as page size, and the
+// Offset as starting point.
+func NewIterator[T any](opt *ListOptions, list ListFunc[T]) *Iterator[T] {
+	it := &Iterator[T]{list: list}
+	if opt != nil {
+		it.opt = *opt
+	}
+	if it.opt.Limit < 1 {
+		it.opt.Limit = defaultPageSize
+	}
+
+	return it
+}
+
+// Next advances to the next item, fetching a new page when needed. It returns false when all items have been
+// read or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.begun && it.idx+1 < len(it.page) {
+		it.idx++
+		return true
+	}
+
+	for !it.done {
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		opt := it.opt
+		page, resp, err := it.list(ctx, &opt)
+		it.resp = resp
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.begun = true
+		it.page, it.idx = page, 0
+		it.advance(resp, len(page))
+
+		if len(page) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// advance moves the options to the next page, or marks the iterator as done.
+func (it *Iterator[T]) advance(resp *Response, n int) {
+	var pages *Pages
+	if resp != nil {
+		pages = resp.Pages
+	}
+
+	switch {
+	case pages != nil && pages.HasNext && pages.NextOffset > it.opt.Offset:
+		it.opt.Offset = pages.NextOffset
+	case pages != nil && (pages.HasLast || pages.HasNext):
+		// The server told us where the pages are, and there is none after this one.
+		it.done = true
+	case pages != nil && pages.TotalCount >= 0:
+		it.opt.Offset += n
+		it.done = n == 0 || it.opt.Offset >= pages.TotalCount
+	default:
+		// No pagination headers, a short (or oversized, unpaginated) page is the last one.
+		it.opt.Offset += n
+		it.done = n != it.opt.Limit
+		if !it.done {
+			it.unpaged++
+		}
+		if it.unpaged >= maxUnpagedPages {
+			it.err = fmt.Errorf("lookergo: %d full pages without pagination headers, the endpoint may ignore limit and offset", it.unpaged)
+			it.done = true
+		}
+	}
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.page[it.idx]
+}
+
+// Response returns the response of the last fetched page.
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// ListAll fetches every page of list and returns the concatenated result.
+func ListAll[T any](ctx context.Context, opt *ListOptions, list ListFunc[T]) ([]T, *Response, error) {
+	var all []T
+
+	it := NewIterator(opt, list)
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, it.Response(), err
+	}
+
+	return all, it.Response(), nil
+}
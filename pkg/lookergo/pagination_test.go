@@ -0,0 +1,157 @@
+package lookergo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParsePages(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Total-Count", "107")
+	h.Set("Link", `<https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=0>; rel="first",`+
+		`<https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=105>; rel="last",`+
+		`<https://x.cloud.looker.com:19999/api/4.0/users?limit=5&offset=5>; rel="next"`)
+
+	got := parsePages(h)
+	expected := &Pages{
+		TotalCount:  107,
+		Limit:       5,
+		FirstOffset: 0, HasFirst: true,
+		NextOffset: 5, HasNext: true,
+		LastOffset: 105, HasLast: true,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Error(errGotWant("parsePages", got, expected))
+	}
+
+	if p := parsePages(http.Header{}); p != nil {
+		t.Errorf("parsePages without headers = %v, expected nil", p)
+	}
+}
+
+// paginatedUsers serves total users, following the Looker Link header format.
+func paginatedUsers(t *testing.T, total int, withLinks bool) {
+	mux.HandleFunc("/4.0/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit == 0 {
+			t.Fatalf("expected a limit to be sent")
+		}
+
+		if withLinks {
+			w.Header().Set("X-Total-Count", strconv.Itoa(total))
+			link := fmt.Sprintf(`<%s/4.0/users?limit=%d&offset=0>; rel="first",<%[1]s/4.0/users?limit=%d&offset=%d>; rel="last"`,
+				server.URL, limit, limit, (total-1)/limit*limit)
+			if offset+limit < total {
+				link += fmt.Sprintf(`,<%s/4.0/users?limit=%d&offset=%d>; rel="next"`, server.URL, limit, offset+limit)
+			}
+			w.Header().Set("Link", link)
+		}
+
+		fmt.Fprint(w, "[")
+		for i := offset; i < offset+limit && i < total; i++ {
+			if i > offset {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":"%d"}`, i+1)
+		}
+		fmt.Fprint(w, "]")
+	})
+}
+
+func TestListAll(t *testing.T) {
+	for _, withLinks := range []bool{true, false} {
+		t.Run(fmt.Sprintf("links=%v", withLinks), func(t *testing.T) {
+			setup()
+			defer teardown()
+			paginatedUsers(t, 23, withLinks)
+
+			users, resp, err := ListAll(ctx, &ListOptions{Limit: 5}, client.Users.List)
+			if err != nil {
+				t.Fatalf("ListAll returned error: %v", err)
+			}
+			if len(users) != 23 {
+				t.Fatalf("ListAll returned %d users, expected 23", len(users))
+			}
+			for i, user := range users {
+				if user.Id != ID(strconv.Itoa(i+1)) {
+					t.Errorf("users[%d].Id = %v, expected %v", i, user.Id, i+1)
+				}
+			}
+			if withLinks && (resp.Pages == nil || resp.Pages.TotalCount != 23 || resp.Pages.HasNext) {
+				t.Errorf("last page response has pages %+v", resp.Pages)
+			}
+		})
+	}
+}
+
+func TestIterator_error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message":"boom"}`)
+	})
+
+	it := NewIterator(nil, client.Groups.List)
+	if it.Next(ctx) {
+		t.Errorf("Next() = true, expected false")
+	}
+	if it.Err() == nil {
+		t.Errorf("Err() = nil, expected error")
+	}
+}
+
+func TestListAll_repeatedRows(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Identical rows, on an endpoint without pagination headers: only the short page ends the listing.
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		n := 2
+		if offset >= 4 {
+			n = 1
+		}
+		rows := make([]string, n)
+		for i := range rows {
+			rows[i] = `{"name":"Analysts"}`
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(rows, ","))
+	})
+
+	groups, _, err := ListAll(ctx, &ListOptions{Limit: 2}, client.Groups.List)
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(groups) != 5 {
+		t.Errorf("ListAll returned %d groups, expected 5", len(groups))
+	}
+}
+
+func TestListAll_offsetIgnored(t *testing.T) {
+	setup()
+	defer teardown()
+	client.limiter = nil
+
+	// Exactly limit rows, whatever the offset, and no pagination headers.
+	requests := 0
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `[{"id":"1"},{"id":"2"}]`)
+	})
+
+	groups, _, err := ListAll(ctx, &ListOptions{Limit: 2}, client.Groups.List)
+	if err == nil {
+		t.Fatalf("ListAll returned %d groups, expected error", len(groups))
+	}
+	if requests != maxUnpagedPages {
+		t.Errorf("ListAll made %d requests, expected %d", requests, maxUnpagedPages)
+	}
+}
@@ -6,15 +6,15 @@ import (
 	"net/url"
 )
 
-const permissionSetBasePath = "4.0/permission_sets"
+const permissionSetBasePath = "permission_sets"
 
 type PermissionSetResource interface {
 	List(context.Context, *ListOptions) ([]PermissionSet, *Response, error)
-	Get(ctx context.Context, PermissionSetId string) (*PermissionSet, *Response, error)
+	Get(ctx context.Context, PermissionSetId ID, opt *GetOptions) (*PermissionSet, *Response, error)
 	GetByName(ctx context.Context, PermissionSetName string, opt *ListOptions) ([]PermissionSet, *Response, error)
 	Create(ctx context.Context, PermissionSet *PermissionSet) (*PermissionSet, *Response, error)
-	Update(ctx context.Context, PermissionSetId string, PermissionSet *PermissionSet) (*PermissionSet, *Response, error)
-	Delete(ctx context.Context, PermissionSetId string) (*Response, error)
+	Update(ctx context.Context, PermissionSetId ID, PermissionSet *WritePermissionSet) (*PermissionSet, *Response, error)
+	Delete(ctx context.Context, PermissionSetId ID) (*Response, error)
 }
 
 type PermissionSetResourceOp struct {
@@ -27,22 +27,31 @@ type PermissionSet struct {
 	Can         map[string]bool `json:"can,omitempty"` // Operations the current user is able to perform on this object
 	AllAccess   bool            `json:"all_access,omitempty"`
 	BuiltIn     bool            `json:"built_in,omitempty"`
-	Id          string          `json:"id,omitempty"`   // Unique Id
+	Id          ID              `json:"id,omitempty"`   // Unique Id
 	Name        string          `json:"name,omitempty"` // Name of PermissionSet
 	Permissions []string        `json:"permissions,omitempty"`
 	Url         string          `json:"url,omitempty"` // Link to get this item
 }
 
+// WritePermissionSet is the body of a permission set update. Fields left nil are not changed.
+type WritePermissionSet struct {
+	Name        *Nullable[string]   `json:"name,omitempty"` // Name of PermissionSet
+	Permissions *Nullable[[]string] `json:"permissions,omitempty"`
+}
+
 // List -
 func (s *PermissionSetResourceOp) List(ctx context.Context, opt *ListOptions) ([]PermissionSet, *Response, error) {
+	ctx = withRoute(ctx, "permission_sets")
 	return doList(ctx, s.client, permissionSetBasePath, opt, new([]PermissionSet))
 }
 
-func (s *PermissionSetResourceOp) Get(ctx context.Context, PermissionSetId string) (*PermissionSet, *Response, error) {
-	return doGetById(ctx, s.client, permissionSetBasePath, PermissionSetId, new(PermissionSet))
+func (s *PermissionSetResourceOp) Get(ctx context.Context, PermissionSetId ID, opt *GetOptions) (*PermissionSet, *Response, error) {
+	ctx = withRoute(ctx, "permission_sets/{permission_set_id}")
+	return doGetById(ctx, s.client, permissionSetBasePath, PermissionSetId, opt, new(PermissionSet))
 }
 
 func (s *PermissionSetResourceOp) GetByName(ctx context.Context, PermissionSetName string, opt *ListOptions) ([]PermissionSet, *Response, error) {
+	ctx = withRoute(ctx, "permission_sets/search")
 	if PermissionSetName == "" {
 		return nil, nil, NewArgError("name", "has to be non-empty")
 	}
@@ -54,13 +63,16 @@ func (s *PermissionSetResourceOp) GetByName(ctx context.Context, PermissionSetNa
 }
 
 func (s *PermissionSetResourceOp) Create(ctx context.Context, permissionSet *PermissionSet) (*PermissionSet, *Response, error) {
+	ctx = withRoute(ctx, "permission_sets")
 	return doCreate(ctx, s.client, permissionSetBasePath, permissionSet, new(PermissionSet))
 }
 
-func (s *PermissionSetResourceOp) Update(ctx context.Context, PermissionSetId string, permissionSet *PermissionSet) (*PermissionSet, *Response, error) {
+func (s *PermissionSetResourceOp) Update(ctx context.Context, PermissionSetId ID, permissionSet *WritePermissionSet) (*PermissionSet, *Response, error) {
+	ctx = withRoute(ctx, "permission_sets/{permission_set_id}")
 	return doUpdate(ctx, s.client, permissionSetBasePath, PermissionSetId, permissionSet, new(PermissionSet))
 }
 
-func (s *PermissionSetResourceOp) Delete(ctx context.Context, PermissionSetId string) (*Response, error) {
+func (s *PermissionSetResourceOp) Delete(ctx context.Context, PermissionSetId ID) (*Response, error) {
+	ctx = withRoute(ctx, "permission_sets/{permission_set_id}")
 	return doDelete(ctx, s.client, permissionSetBasePath, PermissionSetId)
 }
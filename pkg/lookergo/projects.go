@@ -3,35 +3,32 @@ package lookergo
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 
 	"golang.org/x/crypto/ssh"
 )
 
-const api_version = "4.0"
-const projectsBasePath = api_version + "/projects"
+const projectsBasePath = "projects"
 
 // Ref: https://developers.looker.com/api/explorer/4.0/types/Project
 
 type ProjectsResource interface {
-	Get(ctx context.Context, projectName string) (*Project, *Response, error)
+	Get(ctx context.Context, projectName string, opt *GetOptions) (*Project, *Response, error)
 	// !!! NOT rest compliant !!!
 	// name is required. git_remote_url is not allowed.
 	// To configure Git for the newly created project, follow the instructions in update_project.
 	Create(ctx context.Context, proj *Project) (*Project, *Response, error)
-	Update(ctx context.Context, projectName string, proj *Project) (*Project, *Response, error)
+	Update(ctx context.Context, projectName string, proj *WriteProject) (*Project, *Response, error)
 	Delete(ctx context.Context, projectName string) (*Response, error)
 	AllowWarnings(ctx context.Context, projectName string, value bool) (*Response, error)
 	DeleteGitRepo(ctx context.Context, projectName string) (*Response, error)
 	GitBranchesList(ctx context.Context, projectName string, opt *ListOptions) ([]GitBranch, *Response, error)
 	GitBranchActiveGet(ctx context.Context, projectName string) (*GitBranch, *Response, error)
 	GitBranchCheckout(ctx context.Context, projectName string, gbr *GitBranchRef) (*GitBranch, *Response, error)
-	GitBranchUpdate(ctx context.Context, projectName string, gbr *GitBranchRef) (*GitBranch, *Response, error)
+	GitBranchUpdate(ctx context.Context, projectName string, gbr *WriteGitBranch) (*GitBranch, *Response, error)
 	GitBranchListByName(ctx context.Context, projectName string, branchName string) (*GitBranch, *Response, error)
 	GitBranchDelete(ctx context.Context, projectName string, branchName string) (*Response, error)
 	GitBranchDeployToProduction(ctx context.Context, projectName string, branch string) (*string, *Response, error)
@@ -94,6 +91,47 @@ type Project struct {
 	DependencyStatus string `json:"dependency_status,omitempty"`
 }
 
+// WriteProject is the body of a project update. Fields left nil are not changed.
+// Ref: https://developers.looker.com/api/explorer/4.0/types/Project/WriteProject
+type WriteProject struct {
+	// Project display name
+	Name *Nullable[string] `json:"name,omitempty"`
+	// Git remote repository url. Null turns the repository into a bare one, along with a git_service_name of "bare".
+	GitRemoteUrl *Nullable[string] `json:"git_remote_url,omitempty"`
+	// Git username for HTTPS authentication
+	GitUsername *Nullable[string] `json:"git_username,omitempty"`
+	// (Write-Only) Git password for HTTPS authentication
+	GitPassword *Nullable[string] `json:"git_password,omitempty"`
+	// Git production branch name
+	GitProductionBranchName *Nullable[string] `json:"git_production_branch_name,omitempty"`
+	// If true, the project uses a git cookie for authentication
+	UseGitCookieAuth *Nullable[bool] `json:"use_git_cookie_auth,omitempty"`
+	// User attribute name for username in per-user HTTPS authentication
+	GitUsernameUserAttribute *Nullable[string] `json:"git_username_user_attribute,omitempty"`
+	// User attribute name for password in per-user HTTPS authentication
+	GitPasswordUserAttribute *Nullable[string] `json:"git_password_user_attribute,omitempty"`
+	// Name of the git service provider
+	GitServiceName *Nullable[string] `json:"git_service_name,omitempty"`
+	// Port that HTTP(S) application server is running on (for PRs, file browsing, etc.)
+	GitApplicationServerHttpPort *Nullable[int64] `json:"git_application_server_http_port,omitempty"`
+	// Scheme that is running on application server (for PRs, file browsing, etc.)
+	GitApplicationServerHttpScheme *Nullable[string] `json:"git_application_server_http_scheme,omitempty"`
+	// (Write-Only) Optional secret token with which to authenticate requests to the webhook deploy endpoint
+	DeploySecret *Nullable[string] `json:"deploy_secret,omitempty"`
+	// (Write-Only) When true, unsets the deploy secret to allow unauthenticated access to the webhook deploy endpoint
+	UnsetDeploySecret *Nullable[bool] `json:"unset_deploy_secret,omitempty"`
+	// The git pull request policy for this project
+	PullRequestMode *Nullable[string] `json:"pull_request_mode,omitempty"`
+	// Validation policy: If true, the project must pass validation checks before project changes can be committed
+	ValidationRequired *Nullable[bool] `json:"validation_required,omitempty"`
+	// If true, advanced git release management is enabled for this project
+	GitReleaseMgmtEnabled *Nullable[bool] `json:"git_release_mgmt_enabled,omitempty"`
+	// Validation policy: If true, the project can be committed with warnings when `validation_required` is true
+	AllowWarnings *Nullable[bool] `json:"allow_warnings,omitempty"`
+	// If true the project is an example project and cannot be modified
+	IsExample *Nullable[bool] `json:"is_example,omitempty"`
+}
+
 // GitBranch struct for GitBranch
 type GitBranch struct {
 	// The short name on the local. Updating `name` results in `git checkout <new_name>`
@@ -136,8 +174,17 @@ type GitBranchRef struct {
 	Ref  string `json:"ref"`
 }
 
-func (s *ProjectsResourceOp) Get(ctx context.Context, projectName string) (*Project, *Response, error) {
-	return doGet(ctx, s.client, projectsBasePath, new(Project), projectName)
+// WriteGitBranch is the body of a git branch update. Fields left nil are not changed.
+type WriteGitBranch struct {
+	// The short name on the local. Updating `name` results in `git checkout <new_name>`
+	Name *Nullable[string] `json:"name,omitempty"`
+	// The resolved ref of this branch. Updating `ref` results in `git reset --hard <new_ref>`
+	Ref *Nullable[string] `json:"ref,omitempty"`
+}
+
+func (s *ProjectsResourceOp) Get(ctx context.Context, projectName string, opt *GetOptions) (*Project, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}")
+	return doGet(ctx, s.client, projectsBasePath, opt, new(Project), projectName)
 }
 
 /*
@@ -151,6 +198,7 @@ func (s *ProjectsResourceOp) Get(ctx context.Context, projectName string) (*Proj
 	name is required. git_remote_url is not allowed. To configure Git for the newly created project, follow the instructions in update_project.
 */
 func (s *ProjectsResourceOp) Create(ctx context.Context, proj *Project) (*Project, *Response, error) {
+	ctx = withRoute(ctx, "projects")
 	return doCreate(ctx, s.client, projectsBasePath, proj, new(Project))
 }
 
@@ -177,39 +225,49 @@ func (s *ProjectsResourceOp) Create(ctx context.Context, proj *Project) (*Projec
 	Call update_session to select the 'dev' workspace.
 	Call update_project setting git_remote_url to null and git_service_name to "bare".
 */
-func (s *ProjectsResourceOp) Update(ctx context.Context, projectName string, proj *Project) (*Project, *Response, error) {
+func (s *ProjectsResourceOp) Update(ctx context.Context, projectName string, proj *WriteProject) (*Project, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}")
 	return doUpdate(ctx, s.client, projectsBasePath, projectName, proj, new(Project))
 }
 
 func (s *ProjectsResourceOp) Delete(ctx context.Context, projectName string) (*Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}")
 	return doDelete(ctx, s.client, projectsBasePath, projectName)
 }
 
 func (s *ProjectsResourceOp) GitBranchesList(ctx context.Context, projectName string, opt *ListOptions) ([]GitBranch, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/git_branches")
 	return doList(ctx, s.client, projectsBasePath, opt, new([]GitBranch), projectName, "git_branches")
 }
 
 func (s *ProjectsResourceOp) GitBranchActiveGet(ctx context.Context, projectName string) (*GitBranch, *Response, error) {
-	return doGet(ctx, s.client, projectsBasePath, new(GitBranch), projectName, "git_branch")
+	ctx = withRoute(ctx, "projects/{project_id}/git_branch")
+	return doGet(ctx, s.client, projectsBasePath, nil, new(GitBranch), projectName, "git_branch")
 }
 
 func (s *ProjectsResourceOp) GitBranchCheckout(ctx context.Context, projectName string, gbr *GitBranchRef) (*GitBranch, *Response, error) {
-	return doCreate(ctx, s.client, projectsBasePath, new(GitBranchRef), new(GitBranch), projectName, "git_branch")
+	ctx = withRoute(ctx, "projects/{project_id}/git_branch")
+	return doCreate(ctx, s.client, projectsBasePath, gbr, new(GitBranch), projectName, "git_branch")
 }
 
-func (s *ProjectsResourceOp) GitBranchUpdate(ctx context.Context, projectName string, gbr *GitBranchRef) (*GitBranch, *Response, error) {
-	return doUpdate(ctx, s.client, projectsBasePath, projectName, gbr, new(GitBranch), projectName, "git_branch")
+func (s *ProjectsResourceOp) GitBranchUpdate(ctx context.Context, projectName string, gbr *WriteGitBranch) (*GitBranch, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/git_branch")
+	return doUpdate(ctx, s.client, projectsBasePath, projectName, gbr, new(GitBranch), "git_branch")
 }
 
+// GitBranchListByName returns the branch branchName of the project, e.g. feature/new-dashboard.
 func (s *ProjectsResourceOp) GitBranchListByName(ctx context.Context, projectName string, branchName string) (*GitBranch, *Response, error) {
-	panic("Not implemented")
+	ctx = withRoute(ctx, "projects/{project_id}/git_branch/{branch_name}")
+	return doGet(ctx, s.client, projectsBasePath, nil, new(GitBranch), projectName, "git_branch", url.PathEscape(branchName))
 }
 
 func (s *ProjectsResourceOp) GitBranchDelete(ctx context.Context, projectName string, branchName string) (*Response, error) {
-	return doDelete(ctx, s.client, projectsBasePath, projectName, "git_branch", branchName)
+	ctx = withRoute(ctx, "projects/{project_id}/git_branch/{branch_name}")
+	return doDelete(ctx, s.client, projectsBasePath, projectName, "git_branch", url.PathEscape(branchName))
 }
 
 func (s *ProjectsResourceOp) GitBranchDeployToProduction(ctx context.Context, projectName string, branch string) (*string, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/deploy_ref_to_production")
 	qs := url.Values{}
 	qs.Add("branch", branch)
 
@@ -217,6 +275,7 @@ func (s *ProjectsResourceOp) GitBranchDeployToProduction(ctx context.Context, pr
 }
 
 func (s *ProjectsResourceOp) GitRefDeployToProduction(ctx context.Context, projectName string, ref string) (*string, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/deploy_ref_to_production")
 	qs := url.Values{}
 	qs.Add("ref", ref)
 
@@ -224,14 +283,16 @@ func (s *ProjectsResourceOp) GitRefDeployToProduction(ctx context.Context, proje
 }
 
 func (s *ProjectsResourceOp) DeployToProduction(ctx context.Context, projectName string) (*string, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/deploy_to_production")
 	return doCreateX(ctx, s.client, projectsBasePath, new(string), nil, projectName, "deploy_to_production")
 }
 
 func (s *ProjectsResourceOp) GitDeployKeyGet(ctx context.Context, projectName string) (*string, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/git/deploy_key")
 	path := fmt.Sprintf("%s/%s/%s", projectsBasePath, projectName, "git/deploy_key")
 	var gitPubKey string
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.newAPIRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -259,10 +320,11 @@ func (s *ProjectsResourceOp) GitDeployKeyGet(ctx context.Context, projectName st
 }
 
 func (s *ProjectsResourceOp) GitDeployKeyCreate(ctx context.Context, projectName string) (*string, *Response, error) {
+	ctx = withRoute(ctx, "projects/{project_id}/git/deploy_key")
 	path := fmt.Sprintf("%s/%s/%s", projectsBasePath, projectName, "git/deploy_key")
 	var gitPubKey string
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	req, err := s.client.newAPIRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return &gitPubKey, nil, err
 	}
@@ -290,55 +352,17 @@ func (s *ProjectsResourceOp) GitDeployKeyCreate(ctx context.Context, projectName
 
 }
 
+// DeleteGitRepo turns the git repository of the project back into a bare repository on the Looker server.
 func (s *ProjectsResourceOp) DeleteGitRepo(ctx context.Context, projectName string) (*Response, error) {
-	path := fmt.Sprintf("%s/%s/", projectsBasePath, projectName)
-	log.Printf("[DEBUG] Trying to delete git repo for project %s to path %s", projectName, path)
-	gsn := "bare"
-	b := map[string]*string{
-		"git_remote_url":   nil,
-		"git_service_name": &gsn,
-	}
-	buf := new(bytes.Buffer)
-	json.NewEncoder(buf).Encode(b)
-	log.Printf("[DEBUG] Adding body %s", buf)
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, b)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.Do(ctx, req, nil)
-	if err != nil {
-		return resp, err
-	}
-
-	return nil, err
+	_, resp, err := s.Update(ctx, projectName, &WriteProject{
+		GitRemoteUrl:   Null[string](),
+		GitServiceName: Value("bare"),
+	})
+	return resp, err
 }
 
 func (s *ProjectsResourceOp) AllowWarnings(ctx context.Context, projectName string, value bool) (*Response, error) {
-	path := fmt.Sprintf("%s/%s", projectsBasePath, projectName)
-
-	b := map[string]bool{
-		"allow_warnings": value,
-	}
-
-	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, b)
-	if err != nil {
-		return nil, err
-	}
-
-	var buf bytes.Buffer
-	resp, err := s.client.Do(ctx, req, &buf)
-	if err != nil {
-		return resp, err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		_, err := io.ReadAll(&buf)
-		if err != nil {
-			return resp, err
-		}
-	}
-
+	_, resp, err := s.Update(ctx, projectName, &WriteProject{AllowWarnings: Value(value)})
 	return resp, err
 }
 
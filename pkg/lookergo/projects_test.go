@@ -2,8 +2,10 @@ package lookergo
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -47,7 +49,7 @@ func TestProjectsResourceOp_Get(t *testing.T) {
 }`)
 	})
 
-	result, resp, err := client.Projects.Get(ctx, "sandbox-with-sand")
+	result, resp, err := client.Projects.Get(ctx, "sandbox-with-sand", nil)
 	_ = resp
 	if err != nil {
 		t.Errorf("Projects.Get returned error: %v", err)
@@ -57,15 +59,121 @@ func TestProjectsResourceOp_Get(t *testing.T) {
 		Id:                      "sandbox-with-sand",
 		Name:                    "sandbox-with-sand",
 		UsesGit:                 boolPtr(true),
+		UseGitCookieAuth:        boolPtr(false),
 		GitRemoteUrl:            "git@github.com:Sandbox/sandbox-with-sand.git",
 		GitProductionBranchName: "main",
 		GitServiceName:          "github",
 		PullRequestMode:         "off",
 		ValidationRequired:      boolPtr(true),
+		GitReleaseMgmtEnabled:   boolPtr(false),
 		AllowWarnings:           boolPtr(true),
+		IsExample:               boolPtr(false),
 		DependencyStatus:        "install_none",
 	}
 	if !reflect.DeepEqual(result, expected) {
 		t.Error(errGotWant("Projects.Get", result, expected))
 	}
 }
+
+func TestProjectsResourceOp_DeleteGitRepo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/projects/sandbox", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		body, _ := io.ReadAll(r.Body)
+		if got, expected := strings.TrimSpace(string(body)), `{"git_remote_url":null,"git_service_name":"bare"}`; got != expected {
+			t.Errorf("Request body = %s, expected %s", got, expected)
+		}
+		fmt.Fprint(w, `{"id":"sandbox","name":"sandbox","git_service_name":"bare"}`)
+	})
+
+	if _, err := client.Projects.DeleteGitRepo(ctx, "sandbox"); err != nil {
+		t.Errorf("Projects.DeleteGitRepo returned error: %v", err)
+	}
+}
+
+func TestProjectsResourceOp_GitBranchCheckout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/projects/sandbox/git_branch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		body, _ := io.ReadAll(r.Body)
+		if got, expected := strings.TrimSpace(string(body)), `{"name":"feature/new-dashboard","ref":"origin/main"}`; got != expected {
+			t.Errorf("Request body = %s, expected %s", got, expected)
+		}
+		fmt.Fprint(w, `{"name": "feature/new-dashboard", "remote": "origin", "is_local": true, "ref": "4f1c2a"}`)
+	})
+
+	branch, _, err := client.Projects.GitBranchCheckout(ctx, "sandbox", &GitBranchRef{Name: "feature/new-dashboard", Ref: "origin/main"})
+	if err != nil {
+		t.Fatalf("Projects.GitBranchCheckout returned error: %v", err)
+	}
+	if branch.Name != "feature/new-dashboard" || branch.Ref != "4f1c2a" {
+		t.Errorf("Projects.GitBranchCheckout returned %+v", branch)
+	}
+}
+
+func TestProjectsResourceOp_GitBranchUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/projects/sandbox/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		if got, want := r.URL.Path, "/4.0/projects/sandbox/git_branch"; got != want {
+			t.Errorf("path = %q, expected %q", got, want)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if got, expected := strings.TrimSpace(string(body)), `{"ref":"4f1c2a"}`; got != expected {
+			t.Errorf("Request body = %s, expected %s", got, expected)
+		}
+		fmt.Fprint(w, `{"name": "dev-sandbox", "remote": "origin", "is_local": true, "ref": "4f1c2a"}`)
+	})
+
+	branch, _, err := client.Projects.GitBranchUpdate(ctx, "sandbox", &WriteGitBranch{Ref: Value("4f1c2a")})
+	if err != nil {
+		t.Fatalf("Projects.GitBranchUpdate returned error: %v", err)
+	}
+	if branch.Name != "dev-sandbox" || branch.Ref != "4f1c2a" {
+		t.Errorf("Projects.GitBranchUpdate returned %+v", branch)
+	}
+}
+
+func TestProjectsResourceOp_GitBranchListByName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/projects/sandbox/git_branch/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got, want := r.URL.EscapedPath(), "/4.0/projects/sandbox/git_branch/feature%2Fnew-dashboard"; got != want {
+			t.Errorf("path = %q, expected %q", got, want)
+		}
+		fmt.Fprint(w, `{"name": "feature/new-dashboard", "remote": "origin", "is_local": true, "ref": "4f1c2a"}`)
+	})
+
+	branch, _, err := client.Projects.GitBranchListByName(ctx, "sandbox", "feature/new-dashboard")
+	if err != nil {
+		t.Fatalf("Projects.GitBranchListByName returned error: %v", err)
+	}
+	if branch.Name != "feature/new-dashboard" || branch.Ref != "4f1c2a" {
+		t.Errorf("Projects.GitBranchListByName returned %+v", branch)
+	}
+}
+
+func TestProjectsResourceOp_GitBranchDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/projects/sandbox/git_branch/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		if got, want := r.URL.EscapedPath(), "/4.0/projects/sandbox/git_branch/feature%2Fnew-dashboard"; got != want {
+			t.Errorf("path = %q, expected %q", got, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Projects.GitBranchDelete(ctx, "sandbox", "feature/new-dashboard"); err != nil {
+		t.Errorf("Projects.GitBranchDelete returned error: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package lookergo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRequestsPerSecond is the sustained request rate of clients created through NewClient.
+	DefaultRequestsPerSecond = 10
+	// DefaultMaxConcurrentRequests is the number of requests a client created through NewClient runs in parallel.
+	DefaultMaxConcurrentRequests = 10
+
+	// lowQuotaRatio is the share of the quota below which a limiter spreads the remaining requests until the reset.
+	lowQuotaRatio = 0.1
+)
+
+// Limiter throttles the requests of one or more clients: a token bucket caps the request rate,
+// and a semaphore caps the number of requests in flight.
+//
+// The limiter also adapts to the quota the API reports: as the remaining requests run low, it spreads them
+// until the quota resets, and once the quota is exhausted it holds requests until then.
+//
+// Share a single Limiter between clients talking to the same Looker instance.
+type Limiter struct {
+	bucket *rate.Limiter
+	slots  chan struct{}
+
+	mu sync.Mutex
+	// Requests are held until notBefore, and each one pushes it back by spacing.
+	notBefore time.Time
+	spacing   time.Duration
+}
+
+// NewLimiter returns a limiter allowing rps requests per second with bursts of up to burst requests,
+// and no more than maxConcurrent requests in flight.
+// A rps or maxConcurrent of 0 or less removes the matching limit. A burst below 1 defaults to 1.
+func NewLimiter(rps float64, burst, maxConcurrent int) *Limiter {
+	l := &Limiter{}
+
+	if rps > 0 {
+		if burst < 1 {
+			burst = 1
+		}
+		l.bucket = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return l
+}
+
+// Wait blocks until a request may be sent, or ctx is done. On success the returned function must be called
+// once the request has completed, to free its slot.
+func (l *Limiter) Wait(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release = func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	if wait := l.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			release()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return release, nil
+}
+
+// reserve returns how long the next request must wait for the quota.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	at := l.notBefore
+	if at.Before(now) {
+		at = now
+	}
+	if l.spacing > 0 {
+		l.notBefore = at.Add(l.spacing)
+	}
+	return at.Sub(now)
+}
+
+// Observe adapts the limiter to the quota reported by the API. Clients call it after every response
+// carrying rate limit headers.
+func (l *Limiter) Observe(r Rate) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	untilReset := time.Until(r.Reset.Time)
+	switch {
+	case r.Reset.IsZero() || untilReset <= 0:
+		l.notBefore, l.spacing = time.Time{}, 0
+	case r.Remaining <= 0:
+		l.notBefore = r.Reset.Time
+		l.spacing = 0
+	case r.Limit > 0 && float64(r.Remaining) < lowQuotaRatio*float64(r.Limit):
+		l.spacing = untilReset / time.Duration(r.Remaining)
+	default:
+		l.notBefore, l.spacing = time.Time{}, 0
+	}
+}
+
+// parseRate reads the rate limit headers of a response, in either their X-RateLimit-* form, whose reset is a
+// Unix time, or their RateLimit-* form, whose reset is a number of seconds. It reports false when the response
+// has none.
+func parseRate(h http.Header) (Rate, bool) {
+	var r Rate
+	found := false
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, err := strconv.Atoi(h.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		found = true
+		r.Remaining = remaining
+		if limit, err := strconv.Atoi(firstItem(h.Get(prefix + "Limit"))); err == nil {
+			r.Limit = limit
+		}
+		if reset, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil {
+			if prefix == "X-RateLimit-" {
+				r.Reset = Timestamp{time.Unix(reset, 0)}
+			} else {
+				r.Reset = Timestamp{time.Now().Add(time.Duration(reset) * time.Second).Truncate(time.Second)}
+			}
+		}
+		break
+	}
+	return r, found
+}
+
+// firstItem returns the first item of a list header, e.g. "100" for a RateLimit-Limit of "100, 100;w=60".
+func firstItem(v string) string {
+	for i, c := range v {
+		if c == ',' || c == ';' {
+			return v[:i]
+		}
+	}
+	return v
+}
@@ -0,0 +1,201 @@
+package lookergo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_waitRespectsContext(t *testing.T) {
+	l := NewLimiter(0.001, 1, 0)
+
+	release, err := l.Wait(ctx)
+	if err != nil {
+		t.Fatalf("first Wait returned error: %v", err)
+	}
+	release()
+
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := l.Wait(cctx); err == nil {
+		t.Fatalf("expected Wait to fail once the context is done")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait took %v to notice the context", elapsed)
+	}
+}
+
+func TestLimiter_concurrency(t *testing.T) {
+	l := NewLimiter(0, 0, 1)
+
+	release, err := l.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(cctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait with a busy slot = %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	release()
+	release, err = l.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait after release returned error: %v", err)
+	}
+	release()
+}
+
+func TestDo_maxConcurrentRequests(t *testing.T) {
+	setup()
+	defer teardown()
+	client.limiter = NewLimiter(0, 0, 2)
+
+	var inFlight, peak int32
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, `[]`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Groups.List(ctx, nil); err != nil {
+				t.Errorf("Groups.List returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("%d requests were in flight, expected at most 2", peak)
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "100")
+	h.Set("X-RateLimit-Remaining", "42")
+	h.Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+	r, ok := parseRate(h)
+	if !ok || r.Limit != 100 || r.Remaining != 42 || !r.Reset.Time.Equal(reset) {
+		t.Errorf("parseRate(X-RateLimit-*) = %v, %v", r, ok)
+	}
+
+	h = http.Header{}
+	h.Set("RateLimit-Limit", "100, 100;w=60")
+	h.Set("RateLimit-Remaining", "7")
+	h.Set("RateLimit-Reset", "60")
+	r, ok = parseRate(h)
+	if !ok || r.Limit != 100 || r.Remaining != 7 {
+		t.Errorf("parseRate(RateLimit-*) = %v, %v", r, ok)
+	}
+	if d := time.Until(r.Reset.Time); d < 58*time.Second || d > time.Minute {
+		t.Errorf("parseRate(RateLimit-*) reset in %v, expected a minute", d)
+	}
+
+	if r, ok := parseRate(http.Header{}); ok {
+		t.Errorf("parseRate without headers = %v, true", r)
+	}
+}
+
+func TestDo_rate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	remaining := 10
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("X-Total-Count", "0")
+		remaining--
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/4.0/roles", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	_, resp, err := client.Groups.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Groups.List returned error: %v", err)
+	}
+	if resp.Remaining != 10 || resp.RequestID != "req-1" || resp.TotalCount != 0 {
+		t.Errorf("Response = %+v, expected 10 remaining, request req-1 and no items", resp)
+	}
+	if _, _, err := client.Groups.List(ctx, nil); err != nil {
+		t.Fatalf("Groups.List returned error: %v", err)
+	}
+	if r := client.GetRate(); r.Limit != 10 || r.Remaining != 9 {
+		t.Errorf("GetRate = %v, expected 9 of 10 remaining", r)
+	}
+
+	// Responses without rate limit headers keep the last known rate.
+	_, resp, err = client.Roles.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Roles.List returned error: %v", err)
+	}
+	if resp.Rate != (Rate{}) || resp.TotalCount != -1 {
+		t.Errorf("Response = %+v, expected no rate nor total count", resp)
+	}
+	if r := client.GetRate(); r.Remaining != 9 {
+		t.Errorf("GetRate = %v, expected 9 remaining", r)
+	}
+}
+
+func TestLimiter_observe(t *testing.T) {
+	l := NewLimiter(0, 0, 0)
+
+	// An exhausted quota holds requests until the reset.
+	reset := time.Now().Add(50 * time.Millisecond)
+	l.Observe(Rate{Limit: 100, Remaining: 0, Reset: Timestamp{reset}})
+	release, err := l.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	release()
+	if time.Now().Before(reset) {
+		t.Errorf("Wait returned before the quota reset")
+	}
+
+	// A low quota is spread until the reset.
+	l.Observe(Rate{Limit: 100, Remaining: 2, Reset: Timestamp{time.Now().Add(100 * time.Millisecond)}})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := l.Wait(ctx)
+		if err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("3 requests with 2 remaining took %v, expected them spread over 100ms", elapsed)
+	}
+
+	// A healthy quota does not slow anything down.
+	l.Observe(Rate{Limit: 100, Remaining: 50, Reset: Timestamp{time.Now().Add(time.Hour)}})
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(cctx); err != nil {
+		t.Errorf("Wait with a healthy quota returned error: %v", err)
+	}
+}
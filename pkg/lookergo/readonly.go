@@ -0,0 +1,39 @@
+package lookergo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly is returned for the requests a read-only client refuses to send, see WithReadOnly.
+var ErrReadOnly = errors.New("lookergo: client is read-only")
+
+type sessionRequestCtxKey struct{}
+
+// sessionRequest marks the requests made with ctx as changing nothing but the session of the client, e.g. a login or
+// a workspace switch. Read-only clients send them.
+func sessionRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionRequestCtxKey{}, true)
+}
+
+// ReadOnly reports whether the client refuses the requests that could change the instance.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly returns an error wrapping ErrReadOnly if the client is read-only and req may change the instance.
+func (c *Client) checkReadOnly(ctx context.Context, req *http.Request) error {
+	if !c.readOnly {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return nil
+	}
+	if ok, _ := ctx.Value(sessionRequestCtxKey{}).(bool); ok {
+		return nil
+	}
+	return fmt.Errorf("%w, refusing %s %s", ErrReadOnly, req.Method, req.URL.Path)
+}
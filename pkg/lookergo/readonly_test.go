@@ -0,0 +1,60 @@
+package lookergo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+func TestClient_readOnly(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+
+	c, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret),
+		WithLimiter(nil), WithReadOnly(true))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if !c.ReadOnly() {
+		t.Error("ReadOnly = false")
+	}
+
+	// The login of the client itself does not go through Do.
+	if _, _, err := c.Groups.Get(ctx, lookertest.AllUsersGroupID, nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	_, _, err = c.Groups.Create(ctx, &Group{Name: "Analysts"})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("Groups.Create returned %v, expected ErrReadOnly", err)
+	}
+	if !strings.Contains(err.Error(), "POST /api/4.0/groups") {
+		t.Errorf("error %q does not tell the refused request", err)
+	}
+	if _, err := c.Groups.Delete(ctx, lookertest.AllUsersGroupID); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Groups.Delete returned %v, expected ErrReadOnly", err)
+	}
+	if _, ok := srv.Get(lookertest.Groups, lookertest.AllUsersGroupID); !ok {
+		t.Error("the group was deleted")
+	}
+
+	// The dev workspace session logs in as the user and switches its workspace, but changes nothing else.
+	session := NewWorkspaceSession(c, "dev")
+	dev, err := session.Client(ctx)
+	if err != nil {
+		t.Fatalf("WorkspaceSession.Client returned error: %v", err)
+	}
+	if !dev.ReadOnly() {
+		t.Error("the client of the workspace session is not read-only")
+	}
+	if _, _, err := dev.Projects.Create(ctx, &Project{Name: "marketing"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Projects.Create returned %v, expected ErrReadOnly", err)
+	}
+	if err := session.Close(ctx); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+	if srv.Tokens() != 1 {
+		t.Errorf("%d tokens after Close, expected the one of the client", srv.Tokens())
+	}
+}
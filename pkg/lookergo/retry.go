@@ -0,0 +1,186 @@
+package lookergo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries requests that failed with a transient error:
+// a dropped connection, 429 Too Many Requests, 502 Bad Gateway, 503 Service Unavailable or 504 Gateway Timeout.
+//
+// Only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried, unless the request context was
+// marked with WithRetryable.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. 1 disables retries.
+	MaxAttempts int
+
+	// Wait before the first retry. The wait doubles on every next attempt, with jitter.
+	MinWait time.Duration
+
+	// Upper bound of the wait between attempts. When the API asks, through Retry-After, to wait longer than
+	// this, the request is not retried.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy is the policy used by clients created through NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinWait:     500 * time.Millisecond,
+	MaxWait:     30 * time.Second,
+}
+
+// Validate reports whether the policy can be used.
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 {
+		return NewArgError("MaxAttempts", "cannot be less than 1")
+	}
+	if p.MinWait < 0 {
+		return NewArgError("MinWait", "cannot be negative")
+	}
+	if p.MaxWait < p.MinWait {
+		return NewArgError("MaxWait", fmt.Sprintf("cannot be less than MinWait (%v)", p.MinWait))
+	}
+	return nil
+}
+
+// backoff returns the jittered wait before the given retry (1 for the first retry).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.MinWait
+	for i := 1; i < retry && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Pick a wait between half and the full backoff, so parallel clients spread out.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+type retryableCtxKey struct{}
+
+// WithRetryable returns a context which marks the requests made with it as safe to retry,
+// even when their method is not idempotent.
+func WithRetryable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableCtxKey{}, true)
+}
+
+func isRetryable(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	retryable, _ := ctx.Value(retryableCtxKey{}).(bool)
+	return retryable
+}
+
+// retryWait decides whether the outcome of an attempt should be retried, and how long to wait before doing so.
+func (p RetryPolicy) retryWait(resp *http.Response, err error, retry int) (time.Duration, bool) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isPermanent(err) {
+			return 0, false
+		}
+		// Dropped connections, resets, DNS hiccups: all worth another try.
+		return p.backoff(retry), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	wait := p.backoff(retry)
+	if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if after > p.MaxWait {
+			return 0, false
+		}
+		if after > wait {
+			wait = after
+		}
+	}
+
+	return wait, true
+}
+
+// parseRetryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sendFunc sends a request once, or through several attempts.
+type sendFunc func(context.Context, *http.Request) (*http.Response, error)
+
+// doWithRetry sends req with send, retrying transient failures according to the retry policy of the client.
+// The returned response is the one of the last attempt.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request, send sendFunc) (*http.Response, error) {
+	retryable := isRetryable(ctx, req.Method) && (req.Body == nil || req.GetBody != nil)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := send(ctx, req)
+		if !retryable || attempt >= c.retryPolicy.MaxAttempts {
+			return resp, err
+		}
+		wait, retry := c.retryPolicy.retryWait(resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		fields := map[string]interface{}{"method": req.Method, "url": req.URL.String(), "attempt": attempt, "wait": wait.String()}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.Status
+		}
+		c.log(ctx, "Retrying request", fields)
+
+		if resp != nil {
+			// Drain the body so the connection can be reused by the next attempt.
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
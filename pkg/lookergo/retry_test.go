@@ -0,0 +1,171 @@
+package lookergo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var fastRetries = RetryPolicy{MaxAttempts: 3, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}
+
+func TestDo_retryTransient(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retryPolicy = fastRetries
+
+	calls := 0
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `[{"id":"1","name":"g"}]`)
+	})
+
+	groups, _, err := client.Groups.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Groups.List returned error: %v", err)
+	}
+	if calls != 3 || len(groups) != 1 {
+		t.Errorf("got %d calls and %d groups, expected 3 calls and 1 group", calls, len(groups))
+	}
+}
+
+func TestDo_retryCompletionCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/api/4.0/groups/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"g"}`)
+	})
+	var statuses []int
+	c, err := New(WithBaseURL(server.URL+"/api/"), WithRetryPolicy(fastRetries),
+		WithRequestCompletionCallback(func(req *http.Request, resp *http.Response) {
+			statuses = append(statuses, resp.StatusCode)
+		}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if _, _, err := c.Groups.Get(ctx, "1", nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	if calls != 3 || len(statuses) != 1 || statuses[0] != http.StatusOK {
+		t.Errorf("got %d calls and callbacks with %v, expected 3 calls and a single callback with 200", calls, statuses)
+	}
+}
+
+func TestDo_retryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retryPolicy = fastRetries
+
+	calls := 0
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, resp, err := client.Groups.List(ctx, nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if calls != fastRetries.MaxAttempts || resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("got %d calls and status %d, expected %d calls and 502", calls, resp.StatusCode, fastRetries.MaxAttempts)
+	}
+}
+
+func TestDo_retryNonIdempotent(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retryPolicy = fastRetries
+
+	calls := 0
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"name":"g"`) {
+			t.Errorf("attempt %d sent body %q", calls, body)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"g"}`)
+	})
+
+	if _, _, err := client.Groups.Create(ctx, &Group{Name: "g"}); err == nil || calls != 1 {
+		t.Fatalf("POST was retried: %d calls, error %v", calls, err)
+	}
+
+	calls = 0
+	group, _, err := client.Groups.Create(WithRetryable(ctx), &Group{Name: "g"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	if calls != 2 || group.Name != "g" {
+		t.Errorf("got %d calls and group %+v, expected 2 calls", calls, group)
+	}
+}
+
+func TestDo_retryAfter(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retryPolicy = fastRetries
+
+	calls := 0
+	mux.HandleFunc("/4.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	// Retry-After exceeds MaxWait, so there is no point in retrying.
+	if _, _, err := client.Groups.List(ctx, nil); err == nil || calls != 1 {
+		t.Errorf("got %d calls and error %v, expected 1 call and an error", calls, err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, true},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, expected %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	if err := DefaultRetryPolicy.Validate(); err != nil {
+		t.Errorf("DefaultRetryPolicy is invalid: %v", err)
+	}
+	if err := (RetryPolicy{MaxAttempts: 0}).Validate(); err == nil {
+		t.Errorf("expected MaxAttempts 0 to be rejected")
+	}
+	if err := (RetryPolicy{MaxAttempts: 2, MinWait: time.Second, MaxWait: time.Millisecond}).Validate(); err == nil {
+		t.Errorf("expected MaxWait < MinWait to be rejected")
+	}
+}
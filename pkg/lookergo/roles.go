@@ -2,10 +2,9 @@ package lookergo
 
 import (
 	"context"
-	"strconv"
 )
 
-const roleBasePath = "4.0/roles"
+const roleBasePath = "roles"
 
 type Permission struct {
 	Permission  string      `json:"permission"`
@@ -14,12 +13,19 @@ type Permission struct {
 }
 
 type Role struct {
-	Id              int           `json:"id,string,omitempty"`
+	Id              ID            `json:"id,omitempty"`
 	Name            string        `json:"name,omitempty"`
 	PermissionSet   PermissionSet `json:"permission_set,omitempty"`
-	PermissionSetID string        `json:"permission_set_id,omitempty"`
+	PermissionSetID ID            `json:"permission_set_id,omitempty"`
 	ModelSet        ModelSet      `json:"model_set,omitempty"`
-	ModelSetID      string        `json:"model_set_id,omitempty"`
+	ModelSetID      ID            `json:"model_set_id,omitempty"`
+}
+
+// WriteRole is the body of a role update. Fields left nil are not changed.
+type WriteRole struct {
+	Name            *Nullable[string] `json:"name,omitempty"`
+	PermissionSetID *Nullable[ID]     `json:"permission_set_id,omitempty"`
+	ModelSetID      *Nullable[ID]     `json:"model_set_id,omitempty"`
 }
 
 type PermissionSetSlice []PermissionSet
@@ -28,14 +34,14 @@ type PermissionSetSlice []PermissionSet
 // Ref: https://developers.looker.com/api/explorer/4.0/methods/Role
 type RolesResource interface {
 	List(context.Context, *ListOptions) ([]Role, *Response, error)
-	Get(context.Context, int) (*Role, *Response, error)
+	Get(context.Context, ID, *GetOptions) (*Role, *Response, error)
 	Create(context.Context, *Role) (*Role, *Response, error)
-	Update(context.Context, int, *Role) (*Role, *Response, error)
-	Delete(context.Context, int) (*Response, error)
-	RoleGroupsList(context.Context, int, *ListOptions) ([]Group, *Response, error)
-	RoleGroupsSet(context.Context, int, []string) ([]Group, *Response, error)
-	RoleUsersList(context.Context, int, *ListOptions) ([]User, *Response, error)
-	RoleUsersSet(context.Context, int, []string) ([]User, *Response, error)
+	Update(context.Context, ID, *WriteRole) (*Role, *Response, error)
+	Delete(context.Context, ID) (*Response, error)
+	RoleGroupsList(context.Context, ID, *ListOptions) ([]Group, *Response, error)
+	RoleGroupsSet(context.Context, ID, []ID) ([]Group, *Response, error)
+	RoleUsersList(context.Context, ID, *ListOptions) ([]User, *Response, error)
+	RoleUsersSet(context.Context, ID, []ID) ([]User, *Response, error)
 }
 
 // RolesResourceOp handles operations between Role related methods of the API.
@@ -47,45 +53,78 @@ var _ RolesResource = &RolesResourceOp{}
 
 // List -
 func (s *RolesResourceOp) List(ctx context.Context, opt *ListOptions) ([]Role, *Response, error) {
+	ctx = withRoute(ctx, "roles")
 	return doList(ctx, s.client, roleBasePath, opt, new([]Role))
 }
 
 // Get -
-func (s *RolesResourceOp) Get(ctx context.Context, id int) (*Role, *Response, error) {
-	return doGetById(ctx, s.client, roleBasePath, id, new(Role))
+func (s *RolesResourceOp) Get(ctx context.Context, id ID, opt *GetOptions) (*Role, *Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}")
+	return doGetById(ctx, s.client, roleBasePath, id, opt, new(Role))
 }
 
 // Create -
 func (s *RolesResourceOp) Create(ctx context.Context, createReq *Role) (*Role, *Response, error) {
+	ctx = withRoute(ctx, "roles")
 	return doCreate(ctx, s.client, roleBasePath, createReq, new(Role))
 }
 
 // Update -
-func (s *RolesResourceOp) Update(ctx context.Context, id int, updateReq *Role) (*Role, *Response, error) {
+func (s *RolesResourceOp) Update(ctx context.Context, id ID, updateReq *WriteRole) (*Role, *Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}")
 	return doUpdate(ctx, s.client, roleBasePath, id, updateReq, new(Role))
 }
 
 // Delete -
-func (s *RolesResourceOp) Delete(ctx context.Context, id int) (*Response, error) {
+func (s *RolesResourceOp) Delete(ctx context.Context, id ID) (*Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}")
 	return doDelete(ctx, s.client, roleBasePath, id)
 }
 
-// RoleGroupsList -
-func (s *RolesResourceOp) RoleGroupsList(ctx context.Context, id int, opt *ListOptions) ([]Group, *Response, error) {
-	return doList(ctx, s.client, roleBasePath, opt, new([]Group), strconv.Itoa(id), "groups")
+// RoleGroupsList gets all groups the role is assigned to.
+// The API returns them all at once, ignoring the Limit and Offset of opt: do not page it with ListAll or NewIterator.
+func (s *RolesResourceOp) RoleGroupsList(ctx context.Context, id ID, opt *ListOptions) ([]Group, *Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}/groups")
+	path, err := idPath(roleBasePath, id, "groups")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doList(ctx, s.client, path, opt, new([]Group))
 }
 
-// RoleGroupsSet -
-func (s *RolesResourceOp) RoleGroupsSet(ctx context.Context, id int, groupIds []string) ([]Group, *Response, error) {
-	return doSet(ctx, s.client, roleBasePath, groupIds, new([]Group), strconv.Itoa(id), "groups")
+// RoleGroupsSet assigns the role to exactly the groups groupIds. An empty groupIds removes the role from all groups.
+func (s *RolesResourceOp) RoleGroupsSet(ctx context.Context, id ID, groupIds []ID) ([]Group, *Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}/groups")
+	path, err := idPath(roleBasePath, id, "groups")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(groupIds) == 0 {
+		return doClear(ctx, s.client, path, new([]Group))
+	}
+	return doSet(ctx, s.client, path, groupIds, new([]Group))
 }
 
 // RoleUsersList -
-func (s *RolesResourceOp) RoleUsersList(ctx context.Context, id int, opt *ListOptions) ([]User, *Response, error) {
-	return doList(ctx, s.client, roleBasePath, opt, new([]User), strconv.Itoa(id), "users")
+func (s *RolesResourceOp) RoleUsersList(ctx context.Context, id ID, opt *ListOptions) ([]User, *Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}/users")
+	path, err := idPath(roleBasePath, id, "users")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doList(ctx, s.client, path, opt, new([]User))
 }
 
 // RoleUsersSet -
-func (s *RolesResourceOp) RoleUsersSet(ctx context.Context, id int, userIds []string) ([]User, *Response, error) {
-	return doSet(ctx, s.client, roleBasePath, userIds, new([]User), strconv.Itoa(id), "users")
+func (s *RolesResourceOp) RoleUsersSet(ctx context.Context, id ID, userIds []ID) ([]User, *Response, error) {
+	ctx = withRoute(ctx, "roles/{role_id}/users")
+	path, err := idPath(roleBasePath, id, "users")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doSet(ctx, s.client, path, userIds, new([]User))
 }
@@ -2,8 +2,10 @@ package lookergo
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -122,16 +124,44 @@ func TestRolesResourceOp_PermissionSetsList(t *testing.T) {
 ]`)
 	})
 
-	permSets, resp, err := client.Roles.PermissionSetsList(ctx, nil)
+	permSets, resp, err := client.PermissionSets.List(ctx, nil)
 	_ = resp
 	if err != nil {
-		t.Errorf("Roles.PermissionSetsList returned error: %v", err)
+		t.Errorf("PermissionSets.List returned error: %v", err)
 	}
 
+	var gotPermSets []PermissionSet
+	for _, ps := range permSets {
+		if ps.Id == "8" || ps.Id == "7" {
+			gotPermSets = append(gotPermSets, PermissionSet{Id: ps.Id, Name: ps.Name})
+		}
+	}
 	expectedPermSets := []PermissionSet{
-		{Id: 8, Name: "Client Admin"},
-		{Id: 7, Name: "Permission set"}}
-	if !reflect.DeepEqual(permSets, expectedPermSets) {
-		t.Error(errGotWant("groups.List", permSets, expectedPermSets))
+		{Id: "7", Name: "Permission Set"},
+		{Id: "8", Name: "Client Admin"}}
+	if !reflect.DeepEqual(gotPermSets, expectedPermSets) {
+		t.Error(errGotWant("PermissionSets.List", gotPermSets, expectedPermSets))
+	}
+}
+
+func TestRolesResourceOp_RoleGroupsSet_none(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/4.0/roles/7/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		body, _ := io.ReadAll(r.Body)
+		if got, expected := strings.TrimSpace(string(body)), `[]`; got != expected {
+			t.Errorf("Request body = %s, expected %s", got, expected)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	groups, _, err := client.Roles.RoleGroupsSet(ctx, "7", nil)
+	if err != nil {
+		t.Fatalf("Roles.RoleGroupsSet returned error: %v", err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("Roles.RoleGroupsSet returned %+v, expected no groups", groups)
 	}
 }
@@ -0,0 +1,58 @@
+// Code generated by lookergen from Looker.4.0.json. DO NOT EDIT.
+
+package lookergo
+
+import "context"
+
+const modelSetsBasePath = "model_sets"
+
+// ModelSetsResource is an interface for interfacing with the ModelSet resource endpoints of the API.
+type ModelSetsResource interface {
+	List(context.Context, *ListOptions) ([]ModelSet, *Response, error)
+	Get(context.Context, ID, *GetOptions) (*ModelSet, *Response, error)
+	Create(context.Context, *WriteModelSet) (*ModelSet, *Response, error)
+	Update(context.Context, ID, *WriteModelSet) (*ModelSet, *Response, error)
+	Delete(context.Context, ID) (*Response, error)
+}
+
+// ModelSetsResourceOp handles operations between ModelSet related methods of the API.
+type ModelSetsResourceOp struct {
+	client *Client
+}
+
+var _ ModelSetsResource = &ModelSetsResourceOp{}
+
+// List calls all_model_sets: Get All Model Sets.
+// Ref: https://developers.looker.com/api/explorer/4.0/methods/Role/all_model_sets
+func (s *ModelSetsResourceOp) List(ctx context.Context, opt *ListOptions) ([]ModelSet, *Response, error) {
+	ctx = withRoute(ctx, "model_sets")
+	return doList(ctx, s.client, modelSetsBasePath, opt, new([]ModelSet))
+}
+
+// Get calls model_set: Get Model Set.
+// Ref: https://developers.looker.com/api/explorer/4.0/methods/Role/model_set
+func (s *ModelSetsResourceOp) Get(ctx context.Context, modelSetId ID, opt *GetOptions) (*ModelSet, *Response, error) {
+	ctx = withRoute(ctx, "model_sets/{model_set_id}")
+	return doGetById(ctx, s.client, modelSetsBasePath, modelSetId, opt, new(ModelSet))
+}
+
+// Create calls create_model_set: Create Model Set.
+// Ref: https://developers.looker.com/api/explorer/4.0/methods/Role/create_model_set
+func (s *ModelSetsResourceOp) Create(ctx context.Context, createReq *WriteModelSet) (*ModelSet, *Response, error) {
+	ctx = withRoute(ctx, "model_sets")
+	return doCreate(ctx, s.client, modelSetsBasePath, createReq, new(ModelSet))
+}
+
+// Update calls update_model_set: Update Model Set.
+// Ref: https://developers.looker.com/api/explorer/4.0/methods/Role/update_model_set
+func (s *ModelSetsResourceOp) Update(ctx context.Context, modelSetId ID, updateReq *WriteModelSet) (*ModelSet, *Response, error) {
+	ctx = withRoute(ctx, "model_sets/{model_set_id}")
+	return doUpdate(ctx, s.client, modelSetsBasePath, modelSetId, updateReq, new(ModelSet))
+}
+
+// Delete calls delete_model_set: Delete Model Set.
+// Ref: https://developers.looker.com/api/explorer/4.0/methods/Role/delete_model_set
+func (s *ModelSetsResourceOp) Delete(ctx context.Context, modelSetId ID) (*Response, error) {
+	ctx = withRoute(ctx, "model_sets/{model_set_id}")
+	return doDelete(ctx, s.client, modelSetsBasePath, modelSetId)
+}
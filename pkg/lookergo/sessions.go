@@ -2,19 +2,19 @@ package lookergo
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"time"
 
 	"golang.org/x/oauth2"
 )
 
-const sessionBasePath = "4.0/session"
+const sessionBasePath = "session"
 
 type SessionsResource interface {
 	Get(ctx context.Context) (*Session, *Response, error)
 	SetWorkspaceId(ctx context.Context, workspaceId string) (*Session, *Response, error)
 	GetCurrentUser(ctx context.Context) (*User, *Response, error)
-	GetLoginUserToken(ctx context.Context, userId string) (*oauth2.Token, *Response, error)
+	GetLoginUserToken(ctx context.Context, userId ID) (*oauth2.Token, *Response, error)
 }
 
 type SessionsResourceOp struct {
@@ -25,23 +25,25 @@ var _ SessionsResource = &SessionsResourceOp{}
 
 type Session struct {
 	WorkspaceId string `json:"workspace_id"`
-	SudoUserId  *int   `json:"sudo_user_id,string,omitempty"`
+	SudoUserId  *ID    `json:"sudo_user_id,omitempty"`
 }
 
 // Get -
 func (s *SessionsResourceOp) Get(ctx context.Context) (*Session, *Response, error) {
-	return doGet(ctx, s.client, sessionBasePath, new(Session))
+	ctx = withRoute(ctx, "session")
+	return doGet(ctx, s.client, sessionBasePath, nil, new(Session))
 }
 
 // SetWorkspaceId -
 func (s *SessionsResourceOp) SetWorkspaceId(ctx context.Context, workspaceId string) (session *Session, resp *Response, err error) {
+	ctx = withRoute(ctx, "session")
 	updateReq := Session{WorkspaceId: workspaceId}
-	req, err := s.client.NewRequest(ctx, http.MethodPatch, sessionBasePath, updateReq)
+	req, err := s.client.newAPIRequest(ctx, http.MethodPatch, sessionBasePath, updateReq)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resp, err = s.client.Do(ctx, req, &session)
+	resp, err = s.client.Do(sessionRequest(ctx), req, &session)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -51,23 +53,28 @@ func (s *SessionsResourceOp) SetWorkspaceId(ctx context.Context, workspaceId str
 
 // GetCurrentUser -
 func (s *SessionsResourceOp) GetCurrentUser(ctx context.Context) (*User, *Response, error) {
-	return doGet(ctx, s.client, "4.0/user", new(User))
+	ctx = withRoute(ctx, "user")
+	return doGet(ctx, s.client, "user", nil, new(User))
 }
 
 // GetLoginUserToken -
-func (s *SessionsResourceOp) GetLoginUserToken(ctx context.Context, userId string) (*oauth2.Token, *Response, error) {
-	path := fmt.Sprintf("4.0/login/%s", userId)
+func (s *SessionsResourceOp) GetLoginUserToken(ctx context.Context, userId ID) (*oauth2.Token, *Response, error) {
+	ctx = withRoute(ctx, "login/{user_id}")
+	path, err := idPath("login", userId)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
+	req, err := s.client.newAPIRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	token := new(oauth2.Token)
-	resp, err := s.client.Do(ctx, req, &token)
+	authToken := new(AuthToken)
+	resp, err := s.client.Do(sessionRequest(ctx), req, authToken)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return token, nil, nil
+	return authToken.oauth2Token(time.Now()), resp, nil
 }
@@ -0,0 +1,189 @@
+package lookergo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrLoggedOut is returned by the requests of a client returned by AsUser after its Logout.
+var ErrLoggedOut = errors.New("lookergo: client is logged out")
+
+// AsUser returns a client acting as the user userID, e.g. to manage the personal folder or the dev branches of that
+// user. Its tokens are minted through login/{user_id} with the credentials of c, which needs the sudo permission, and
+// renewed when they expire. The first token is fetched right away, so that an unknown user or a missing permission is
+// reported here. A request the API rejects with a 401 is sent again once, with a new token.
+//
+// The client shares the settings and the limiter of c. Call Logout when done with it.
+func (c *Client) AsUser(ctx context.Context, userID ID) (*Client, error) {
+	return c.asUser(ctx, userID, nil)
+}
+
+// asUser is AsUser, calling prepare with a client authenticated with each new token before it is used.
+func (c *Client) asUser(ctx context.Context, userID ID, prepare func(context.Context, *Client) error) (*Client, error) {
+	src := &userTokenSource{parent: c, userID: userID, prepare: prepare}
+	if _, err := src.mint(ctx); err != nil {
+		return nil, err
+	}
+
+	child := c.newChild()
+	child.client.Transport = &tokenTransport{source: src, base: child.client.Transport}
+	child.sudo = src
+	return child, nil
+}
+
+// SudoUserID returns the user a client returned by AsUser acts as, and an empty ID for other clients.
+func (c *Client) SudoUserID() ID {
+	if c.sudo == nil {
+		return ""
+	}
+	return c.sudo.userID
+}
+
+// Logout revokes the token of a client returned by AsUser. Its requests fail with ErrLoggedOut afterwards.
+func (c *Client) Logout(ctx context.Context) (*Response, error) {
+	if c.sudo == nil {
+		return nil, errors.New("lookergo: Logout needs a client returned by AsUser")
+	}
+	// No need to mint a token only to revoke it.
+	if !c.sudo.valid() {
+		c.sudo.close()
+		return nil, nil
+	}
+	defer c.sudo.close()
+
+	ctx = withRoute(ctx, "logout")
+	req, err := c.newAPIRequest(ctx, http.MethodDelete, "logout", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(sessionRequest(ctx), req, nil)
+}
+
+// userTokenSource is the token source of the clients returned by AsUser.
+type userTokenSource struct {
+	parent  *Client
+	userID  ID
+	prepare func(context.Context, *Client) error
+
+	// Serializes the mints, so that concurrent requests with an expired token mint a single new one.
+	mintMu sync.Mutex
+
+	mu        sync.Mutex
+	t         *oauth2.Token
+	loggedOut bool
+}
+
+// token returns the current token, or mints one with ctx, the context of the request needing it, when it expired.
+// A canceled request does not wait for the mint.
+func (s *userTokenSource) token(ctx context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	token, loggedOut := s.t, s.loggedOut
+	s.mu.Unlock()
+
+	if loggedOut {
+		return nil, ErrLoggedOut
+	}
+	if token.Valid() {
+		return token, nil
+	}
+
+	s.mintMu.Lock()
+	defer s.mintMu.Unlock()
+	if s.valid() {
+		return s.current(), nil
+	}
+	return s.mint(ctx)
+}
+
+// mint fetches a new token of the user.
+func (s *userTokenSource) mint(ctx context.Context) (*oauth2.Token, error) {
+	token, _, err := s.parent.Sessions.GetLoginUserToken(ctx, s.userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.prepare != nil {
+		c := s.parent.newChild()
+		c.setTokenSource(oauth2.StaticTokenSource(token))
+		if err := s.prepare(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loggedOut {
+		return nil, ErrLoggedOut
+	}
+	s.t = token
+	return token, nil
+}
+
+// minting returns send, minting a token before each attempt if needed.
+func (s *userTokenSource) minting(send sendFunc) sendFunc {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		// The tokens are minted through the parent, which shares the limiter: mint before send takes a slot, or a
+		// full limiter would deadlock.
+		if _, err := s.token(ctx); err != nil {
+			return nil, err
+		}
+		return send(ctx, req)
+	}
+}
+
+// reauthenticate sends req with send, and sends it again, once, if the API rejected the token with a 401 before its
+// expiry: the request was not processed, whatever its method.
+func (s *userTokenSource) reauthenticate(ctx context.Context, req *http.Request, send sendFunc) (*http.Response, error) {
+	resp, err := send(ctx, req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	s.expire(resp.Request)
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	return send(ctx, req)
+}
+
+func (s *userTokenSource) valid() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.loggedOut && s.t.Valid()
+}
+
+// expire drops the current token if req, as sent, was authenticated with it: the API rejected it before its expiry.
+func (s *userTokenSource) expire(req *http.Request) {
+	if req == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.t != nil && req.Header.Get("Authorization") == s.t.Type()+" "+s.t.AccessToken {
+		s.t = nil
+	}
+}
+
+func (s *userTokenSource) current() *oauth2.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.t
+}
+
+func (s *userTokenSource) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loggedOut = true
+	s.t = nil
+}
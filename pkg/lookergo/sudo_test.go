@@ -0,0 +1,177 @@
+package lookergo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setupSudo serves login/5 to the admin token, and user and logout to the tokens it minted.
+func setupSudo(t *testing.T, parentOpts ...ClientOpt) (parent *Client, mints *int32, logouts *int32) {
+	mints, logouts = new(int32), new(int32)
+
+	mux.HandleFunc("/api/4.0/login/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if got := r.Header.Get("Authorization"); got != "Bearer admin" {
+			t.Errorf("login Authorization = %q, expected the token of the parent", got)
+		}
+		n := atomic.AddInt32(mints, 1)
+		fmt.Fprintf(w, `{"access_token":"user-5-%d","token_type":"Bearer","expires_in":3600}`, n)
+	})
+	mux.HandleFunc("/api/4.0/login/6", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not found"}`)
+	})
+	mux.HandleFunc("/api/4.0/user", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != fmt.Sprintf("Bearer user-5-%d", atomic.LoadInt32(mints)) {
+			t.Errorf("Authorization = %q, expected the last token minted", got)
+		}
+		fmt.Fprint(w, `{"id":"5"}`)
+	})
+	mux.HandleFunc("/api/4.0/logout", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		if got := r.Header.Get("Authorization"); got != fmt.Sprintf("Bearer user-5-%d", atomic.LoadInt32(mints)) {
+			t.Errorf("logout Authorization = %q, expected the last token minted", got)
+		}
+		atomic.AddInt32(logouts, 1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	parent, err := New(append([]ClientOpt{WithBaseURL(server.URL + "/api/"), WithStaticToken("admin")}, parentOpts...)...)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return parent, mints, logouts
+}
+
+func TestClient_AsUser(t *testing.T) {
+	setup()
+	defer teardown()
+	parent, mints, logouts := setupSudo(t, WithLimiter(nil))
+
+	c, err := parent.AsUser(ctx, "5")
+	if err != nil {
+		t.Fatalf("AsUser returned error: %v", err)
+	}
+	if c.SudoUserID() != "5" || parent.SudoUserID() != "" {
+		t.Errorf("SudoUserID = %q, parent %q", c.SudoUserID(), parent.SudoUserID())
+	}
+	for i := 0; i < 2; i++ {
+		user, _, err := c.Sessions.GetCurrentUser(ctx)
+		if err != nil {
+			t.Fatalf("GetCurrentUser returned error: %v", err)
+		}
+		if user.Id != "5" {
+			t.Errorf("user = %v, expected 5", user.Id)
+		}
+	}
+	if atomic.LoadInt32(mints) != 1 {
+		t.Errorf("%d tokens minted, expected 1", atomic.LoadInt32(mints))
+	}
+
+	if _, err := c.Logout(ctx); err != nil {
+		t.Fatalf("Logout returned error: %v", err)
+	}
+	if atomic.LoadInt32(logouts) != 1 {
+		t.Errorf("%d logouts, expected 1", atomic.LoadInt32(logouts))
+	}
+	if _, _, err := c.Sessions.GetCurrentUser(ctx); !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("GetCurrentUser after Logout returned %v, expected ErrLoggedOut", err)
+	}
+	if atomic.LoadInt32(mints) != 1 {
+		t.Errorf("%d tokens minted after Logout, expected 1", atomic.LoadInt32(mints))
+	}
+	// The parent is not affected.
+	if _, err := parent.Logout(ctx); err == nil {
+		t.Error("Logout of a client not returned by AsUser returned no error")
+	}
+}
+
+func TestClient_AsUser_refresh(t *testing.T) {
+	setup()
+	defer teardown()
+	// A single slot: minting must not wait for the slot held by the request needing the token.
+	parent, mints, _ := setupSudo(t, WithLimiter(NewLimiter(0, 0, 1)))
+
+	c, err := parent.AsUser(ctx, "5")
+	if err != nil {
+		t.Fatalf("AsUser returned error: %v", err)
+	}
+	c.sudo.t.Expiry = time.Now().Add(-time.Minute)
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := c.Sessions.GetCurrentUser(ctx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("GetCurrentUser returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetCurrentUser is stuck renewing the token")
+	}
+	if atomic.LoadInt32(mints) != 2 {
+		t.Errorf("%d tokens minted, expected 2", atomic.LoadInt32(mints))
+	}
+}
+
+func TestClient_AsUser_refreshCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+	parent, _, _ := setupSudo(t, WithLimiter(nil))
+	unblock := make(chan struct{})
+	defer close(unblock)
+	var mints int32
+	mux.HandleFunc("/api/4.0/login/7", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&mints, 1) == 1 {
+			fmt.Fprint(w, `{"access_token":"user-7","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		// The renewal hangs until the test ends.
+		select {
+		case <-r.Context().Done():
+		case <-unblock:
+		}
+	})
+
+	c, err := parent.AsUser(ctx, "7")
+	if err != nil {
+		t.Fatalf("AsUser returned error: %v", err)
+	}
+	c.sudo.t.Expiry = time.Now().Add(-time.Minute)
+
+	reqCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := c.Sessions.GetCurrentUser(reqCtx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GetCurrentUser returned %v, expected the deadline of its context", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetCurrentUser ignores its context while renewing the token")
+	}
+}
+
+func TestClient_AsUser_unknownUser(t *testing.T) {
+	setup()
+	defer teardown()
+	parent, _, _ := setupSudo(t, WithLimiter(nil))
+
+	if _, err := parent.AsUser(ctx, "6"); !IsNotFound(err) {
+		t.Errorf("AsUser returned %v, expected a 404", err)
+	}
+	if _, err := parent.AsUser(ctx, ""); err == nil {
+		t.Error("AsUser returned no error for an empty id")
+	}
+}
@@ -0,0 +1,100 @@
+package lookergo
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer of the spans of the clients, see WithTracerProvider.
+const TracerName = "github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo"
+
+// Attributes of the spans of Do, on top of the HTTP semantic conventions.
+const (
+	// Number of attempts after the first one.
+	RetriesKey = attribute.Key("looker.retries")
+	// Milliseconds spent waiting for the limiter, over all attempts.
+	LimiterWaitKey = attribute.Key("looker.limiter_wait_ms")
+	// Workspace of the client, "production" or "dev".
+	WorkspaceKey = attribute.Key("looker.workspace")
+)
+
+type routeCtxKey struct{}
+
+// withRoute returns ctx, for a call of Do sending a request to route, the template of its path below the API version as
+// in the specification of the API, e.g. "groups/{group_id}/users". It names the span of the call, instead of the path,
+// whose number of values is unbounded. The services set it for every request they send.
+func withRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeCtxKey{}, route)
+}
+
+// startSpan starts the span of a call of Do sending req, named after the route set by withRoute, or after the method
+// only for the requests of other callers. The attempts of the call record their status, retries and waits in it, see
+// traceAttempts.
+func (c *Client) startSpan(ctx context.Context, req *http.Request) (context.Context, trace.Span) {
+	name := req.Method
+	attrs := []attribute.KeyValue{
+		semconv.HTTPMethodKey.String(req.Method),
+		semconv.NetPeerNameKey.String(req.URL.Hostname()),
+		WorkspaceKey.String(c.workspace),
+	}
+	if route, _ := ctx.Value(routeCtxKey{}).(string); route != "" {
+		name += " " + route
+		attrs = append(attrs, semconv.HTTPRouteKey.String(route))
+	}
+	// The requests made on behalf of this one, e.g. the mint of a token, have routes of their own.
+	ctx = withRoute(ctx, "")
+	return c.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+type attemptStatsCtxKey struct{}
+
+// attemptStats are the statistics of the attempts of a call of Do.
+type attemptStats struct {
+	limiterWait time.Duration
+}
+
+// traceAttempts returns send, recording its attempts in the span of the context of the call: their number, the
+// status of the last one, the time spent waiting for the limiter, and an event for each retry.
+func traceAttempts(send sendFunc) sendFunc {
+	stats := &attemptStats{}
+	attempts := 0
+	var lastEnd time.Time
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		span := trace.SpanFromContext(ctx)
+		if attempts > 0 {
+			span.AddEvent("retry", trace.WithAttributes(
+				attribute.Int("attempt", attempts), attribute.String("wait", time.Since(lastEnd).String())))
+		}
+		attempts++
+
+		resp, err := send(context.WithValue(ctx, attemptStatsCtxKey{}, stats), req)
+		lastEnd = time.Now()
+		span.SetAttributes(RetriesKey.Int(attempts-1), LimiterWaitKey.Int64(stats.limiterWait.Milliseconds()))
+		if err == nil {
+			span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+		}
+		return resp, err
+	}
+}
+
+// addLimiterWait records, for traceAttempts, that an attempt waited d for the limiter.
+func addLimiterWait(ctx context.Context, d time.Duration) {
+	if stats, ok := ctx.Value(attemptStatsCtxKey{}).(*attemptStats); ok {
+		stats.limiterWait += d
+	}
+}
+
+// endSpan ends span, failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,155 @@
+package lookergo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+// spanAttr returns the value of the attribute key of span.
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestClient_tracing(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret), WithLimiter(nil),
+		WithTracerProvider(tp))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	applyCtx, apply := tp.Tracer("test").Start(ctx, "apply")
+	group, _, err := c.Groups.Create(applyCtx, &Group{Name: "Analysts"})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	if _, _, err := c.Groups.Get(applyCtx, group.Id, nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+	if _, err := c.Groups.Delete(applyCtx, "404"); err == nil {
+		t.Fatal("Groups.Delete of an unknown group succeeded")
+	}
+	apply.End()
+
+	spans := recorder.Ended()
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	// The token is fetched by the first request, within its span.
+	expected := []string{"looker token", "POST groups", "GET groups/{group_id}", "DELETE groups/{group_id}", "apply"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Fatalf("spans %q, expected %q", names, expected)
+	}
+	token, create, get, del := spans[0], spans[1], spans[2], spans[3]
+	if token.Parent().SpanID() != create.SpanContext().SpanID() {
+		t.Error("the token span is not a child of the span of the request fetching it")
+	}
+	for _, span := range spans[1:4] {
+		if span.Parent().SpanID() != apply.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the span of its context", span.Name())
+		}
+	}
+
+	if route := spanAttr(get, "http.route").AsString(); route != "groups/{group_id}" {
+		t.Errorf("http.route = %q, expected groups/{group_id}", route)
+	}
+	if status := spanAttr(create, "http.status_code").AsInt64(); status != 200 {
+		t.Errorf("http.status_code = %d, expected 200", status)
+	}
+	if retries := spanAttr(create, RetriesKey); retries.Type() != attribute.INT64 || retries.AsInt64() != 0 {
+		t.Errorf("%s = %v, expected 0", RetriesKey, retries.Emit())
+	}
+	if wait := spanAttr(create, LimiterWaitKey); wait.Type() != attribute.INT64 {
+		t.Errorf("%s is missing", LimiterWaitKey)
+	}
+	if workspace := spanAttr(create, WorkspaceKey).AsString(); workspace != "production" {
+		t.Errorf("%s = %q, expected production", WorkspaceKey, workspace)
+	}
+	if create.Status().Code != codes.Unset {
+		t.Errorf("status of the creation is %v", create.Status())
+	}
+	if del.Status().Code != codes.Error || spanAttr(del, "http.status_code").AsInt64() != 404 {
+		t.Errorf("status of the failed deletion is %v, %d", del.Status(), spanAttr(del, "http.status_code").AsInt64())
+	}
+}
+
+func TestClient_tracingRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/api/4.0/groups/5", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"5"}`)
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	c, _ := New(WithBaseURL(server.URL+"/api/"), WithLimiter(nil), WithTracerProvider(tp),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, MinWait: time.Millisecond, MaxWait: time.Millisecond}))
+	if _, _, err := c.Groups.Get(ctx, "5", nil); err != nil {
+		t.Fatalf("Groups.Get returned error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans, expected 1", len(spans))
+	}
+	span := spans[0]
+	if retries := spanAttr(span, RetriesKey).AsInt64(); retries != 1 {
+		t.Errorf("%s = %d, expected 1", RetriesKey, retries)
+	}
+	if status := spanAttr(span, "http.status_code").AsInt64(); status != 200 {
+		t.Errorf("http.status_code = %d, expected the 200 of the last attempt", status)
+	}
+	if events := span.Events(); len(events) != 1 || events[0].Name != "retry" {
+		t.Errorf("events %v, expected a retry", events)
+	}
+}
+
+func TestClient_tracingNoRoute(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/api/4.0/groups/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"5"}`)
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	c, _ := New(WithBaseURL(server.URL+"/api/"), WithLimiter(nil), WithTracerProvider(tp))
+	req, _ := c.NewRequest(ctx, http.MethodGet, "4.0/groups/5", nil)
+	if _, err := c.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	// The path of a request sent without a route does not name its span.
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "GET" {
+		t.Fatalf("spans %v, expected a single GET", spans)
+	}
+	if route := spanAttr(spans[0], "http.route"); route.Type() != attribute.INVALID {
+		t.Errorf("http.route = %q, expected none", route.Emit())
+	}
+}
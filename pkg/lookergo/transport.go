@@ -0,0 +1,78 @@
+package lookergo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportOptions configures how NewTransport connects to the API server, e.g. for a self-hosted instance behind a
+// private certificate authority or an egress proxy.
+type TransportOptions struct {
+	// PEM file of certificate authorities trusted on top of the ones of the system.
+	CACertFile string
+
+	// PEM files of the certificate and its key presented to the server, for mutual TLS. Both or neither are set.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// URL of the proxy to send the requests through, e.g. http://proxy.example.com:3128. When empty, the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+
+	// Accept any certificate presented by the server. Only meant for labs and tests.
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns a transport configured according to opts, otherwise like http.DefaultTransport. Pass it to
+// WithTransport: it is then shared by the client, the token fetches and the clients derived from it.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, NewArgError("CA certificate file", err.Error())
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, NewArgError("CA certificate file", fmt.Sprintf("%s has no PEM certificate", opts.CACertFile))
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (opts.ClientCertFile == "") != (opts.ClientKeyFile == "") {
+		return nil, NewArgError("client certificate", "both the certificate and the key files must be set")
+	}
+	if opts.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, NewArgError("client certificate", err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.ProxyURL != "" {
+		u, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, NewArgError("proxy URL", err.Error())
+		}
+		switch {
+		case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5":
+			return nil, NewArgError("proxy URL", fmt.Sprintf("scheme of %q is not http, https or socks5", opts.ProxyURL))
+		case u.Host == "":
+			return nil, NewArgError("proxy URL", fmt.Sprintf("%q has no host", opts.ProxyURL))
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
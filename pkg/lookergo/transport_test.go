@@ -0,0 +1,163 @@
+package lookergo
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+// writeFile writes content to a file of a temporary directory, and returns its path.
+func writeFile(t *testing.T, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeClientCert writes a self-signed client certificate and its key, and returns their paths and the certificate.
+func writeClientCert(t *testing.T) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ = x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = writeFile(t, "client.crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyFile = writeFile(t, "client.key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certFile, keyFile, cert
+}
+
+func TestNewTransport_tls(t *testing.T) {
+	srv := lookertest.NewTLSServer()
+	defer srv.Close()
+	caFile := writeFile(t, "ca.pem", srv.CertificatePEM())
+
+	tests := map[string]struct {
+		opts      TransportOptions
+		expectErr bool
+	}{
+		"system roots":  {TransportOptions{}, true},
+		"CA file":       {TransportOptions{CACertFile: caFile}, false},
+		"skip verifies": {TransportOptions{InsecureSkipVerify: true}, false},
+	}
+	for name, tt := range tests {
+		transport, err := NewTransport(tt.opts)
+		if err != nil {
+			t.Fatalf("%s: NewTransport returned error: %v", name, err)
+		}
+		c, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret),
+			WithTransport(transport), WithLimiter(nil))
+		if err != nil {
+			t.Fatalf("%s: New returned error: %v", name, err)
+		}
+		// Logs in first: the token fetch goes through the transport too.
+		_, _, err = c.Sessions.GetCurrentUser(ctx)
+		if tt.expectErr && err == nil {
+			t.Errorf("%s: GetCurrentUser succeeded, expected a certificate error", name)
+		} else if !tt.expectErr && err != nil {
+			t.Errorf("%s: GetCurrentUser returned error: %v", name, err)
+		}
+	}
+}
+
+func TestNewTransport_clientCert(t *testing.T) {
+	certFile, keyFile, cert := writeClientCert(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":%q}`, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+
+	transport, err := NewTransport(TransportOptions{InsecureSkipVerify: true, ClientCertFile: certFile, ClientKeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("NewTransport returned error: %v", err)
+	}
+	c, _ := New(WithBaseURL(srv.URL+"/api/"), WithTransport(transport), WithLimiter(nil))
+	user, _, err := c.Sessions.GetCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentUser returned error: %v", err)
+	}
+	if user.Id != "terraform" {
+		t.Errorf("server saw the certificate of %q, expected terraform", user.Id)
+	}
+}
+
+func TestNewTransport_proxy(t *testing.T) {
+	var host string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		fmt.Fprint(w, `{"id":"5"}`)
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewTransport returned error: %v", err)
+	}
+	c, _ := New(WithBaseURL("http://looker.example.invalid/api/"), WithStaticToken("tok"), WithTransport(transport), WithLimiter(nil))
+	if _, _, err := c.Sessions.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("GetCurrentUser returned error: %v", err)
+	}
+	if host != "looker.example.invalid" {
+		t.Errorf("proxy received a request for %q, expected looker.example.invalid", host)
+	}
+}
+
+func TestNewTransport_errors(t *testing.T) {
+	certFile, keyFile, _ := writeClientCert(t)
+	notPEM := writeFile(t, "ca.pem", []byte("not a certificate"))
+
+	tests := map[string]TransportOptions{
+		"missing CA file":    {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"CA file not PEM":    {CACertFile: notPEM},
+		"cert without key":   {ClientCertFile: certFile},
+		"key without cert":   {ClientKeyFile: keyFile},
+		"mismatched pair":    {ClientCertFile: certFile, ClientKeyFile: notPEM},
+		"proxy scheme":       {ProxyURL: "ftp://proxy.example.com"},
+		"proxy without host": {ProxyURL: "http://"},
+	}
+	for name, opts := range tests {
+		if _, err := NewTransport(opts); err == nil {
+			t.Errorf("%s: NewTransport returned no error", name)
+		}
+	}
+}
@@ -2,13 +2,12 @@ package lookergo
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
+	"strings"
 )
 
-const userBasePath = "4.0/users"
+const userBasePath = "users"
 
 // CredentialsEmail -
 type CredentialsEmail struct {
@@ -24,6 +23,12 @@ type CredentialsEmail struct {
 	UserUrl                        string          `json:"user_url,omitempty"`                            // Link to get this user
 }
 
+// WriteCredentialsEmail is the body of an email credentials update. Fields left nil are not changed.
+type WriteCredentialsEmail struct {
+	Email                          *Nullable[string] `json:"email,omitempty"`                               // EMail address used for user login
+	ForcedPasswordResetAtNextLogin *Nullable[bool]   `json:"forced_password_reset_at_next_login,omitempty"` // Force the user to change their password upon their next login
+}
+
 type CredentialsEmbed struct {
 	Can             map[string]bool `json:"can,omitempty"`               // Operations the current user is able to perform on this object
 	CreatedAt       string          `json:"created_at,omitempty"`        // Timestamp for the creation of this credential
@@ -47,128 +52,76 @@ type CredentialsSaml struct {
 	Url        string          `json:"url,omitempty"`          // Link to get this item
 }
 
-// SliceStringInts is an intermediate/shim type to make the json result return stringified integers,
-// while being able to use correct integer types internally.
-type SliceStringInts []int
-
 // User defines a user in the database
 // Ref: https://github.com/looker-open-source/sdk-codegen/blob/main/go/sdk/v4/models.go#L3508
 type User struct {
-	Can                    *map[string]bool    `json:"can,omitempty"`                       // Operations the current user is able to perform on this object
-	AvatarUrl              string              `json:"avatar_url,omitempty"`                // URL for the avatar image (may be generic)
-	AvatarUrlWithoutSizing string              `json:"avatar_url_without_sizing,omitempty"` // URL for the avatar image (may be generic), does not specify size
-	CredentialsEmail       *CredentialsEmail   `json:"credentials_email,omitempty"`
-	CredentialsEmbed       *[]CredentialsEmbed `json:"credentials_embed,omitempty"` // Embed credentials
-	//CredentialsApi3            *[]CredentialsApi3       `json:"credentials_api3,omitempty"`                // API 3 credentials
-	//CredentialsGoogle          *CredentialsGoogle       `json:"credentials_google,omitempty"`
-	//CredentialsLdap            *CredentialsLDAP         `json:"credentials_ldap,omitempty"`
-	//CredentialsLookerOpenid    *CredentialsLookerOpenid `json:"credentials_looker_openid,omitempty"`
-	//CredentialsOidc            *CredentialsOIDC         `json:"credentials_oidc,omitempty"`
-	//CredentialsTotp            *CredentialsTotp         `json:"credentials_totp,omitempty"`
-	CredentialsSaml            *CredentialsSaml       `json:"credentials_saml,omitempty"`
-	DisplayName                string                 `json:"display_name,omitempty"`                   // Full name for display (available only if both first_name and last_name are set)
-	Email                      string                 `json:"email,omitempty"`                          // EMail address
-	EmbedGroupSpaceId          string                 `json:"embed_group_space_id,omitempty"`           // (DEPRECATED) (Embed only) ID of user's group space based on the external_group_id optionally specified during embed user login
-	FirstName                  string                 `json:"first_name,omitempty"`                     // First name
-	GroupIds                   []string               `json:"group_ids,omitempty"`                      // Array of ids of the groups for this user
-	HomeFolderId               string                 `json:"home_folder_id,omitempty"`                 // ID string for user's home folder
-	Id                         string                 `json:"id,omitempty"`                             // Unique Id
-	IsDisabled                 bool                   `json:"is_disabled,omitempty"`                    // Account has been disabled
-	LastName                   string                 `json:"last_name,omitempty"`                      // Last name
-	Locale                     string                 `json:"locale,omitempty"`                         // User's preferred locale. User locale takes precedence over Looker's system-wide default locale. Locale determines language of display strings and date and numeric formatting in API responses. Locale string must be a 2 letter language code or a combination of language code and region code: 'en' or 'en-US', for example.
-	LookerVersions             []string               `json:"looker_versions,omitempty"`                // Array of strings representing the Looker versions that this user has used (this only goes back as far as '3.54.0')
-	ModelsDirValidated         bool                   `json:"models_dir_validated,omitempty"`           // User's dev workspace has been checked for presence of applicable production projects
-	PersonalFolderId           string                 `json:"personal_folder_id,omitempty"`             // ID of user's personal folder
-	PresumedLookerEmployee     bool                   `json:"presumed_looker_employee,omitempty"`       // User is identified as an employee of Looker
-	RoleIds                    SliceStringInts        `json:"role_ids,omitempty"`                       // Array of ids of the roles for this user
-	UiState                    map[string]interface{} `json:"ui_state,omitempty"`                       // Per user dictionary of undocumented state information owned by the Looker UI.
-	VerifiedLookerEmployee     bool                   `json:"verified_looker_employee,omitempty"`       // User is identified as an employee of Looker who has been verified via Looker corporate authentication
-	RolesExternallyManaged     bool                   `json:"roles_externally_managed,omitempty"`       // User's roles are managed by an external directory like SAML or LDAP and can not be changed directly.
-	AllowDirectRoles           bool                   `json:"allow_direct_roles,omitempty"`             // User can be directly assigned a role.
-	AllowNormalGroupMembership bool                   `json:"allow_normal_group_membership,omitempty"`  // User can be a direct member of a normal Looker group.
-	AllowRolesFromNormalGroups bool                   `json:"allow_roles_from_normal_groups,omitempty"` // User can inherit roles from a normal Looker group.
-	EmbedGroupFolderId         string                 `json:"embed_group_folder_id,omitempty"`          // (Embed only) ID of user's group folder based on the external_group_id optionally specified during embed user login
-	Url                        string                 `json:"url,omitempty"`                            // Link to get this item
-}
-
-// JSON parsing
-
-func (c *SliceStringInts) MarshalJSON() ([]byte, error) {
-	var r []string
-	for _, item := range *c {
-		r = append(r, fmt.Sprintf("%v", item))
-	}
-	return json.Marshal(r)
-}
-
-func (c *SliceStringInts) UnmarshalJSON(b []byte) error {
-	var n SliceStringInts
-
-	var nums []interface{}
-
-	err := json.Unmarshal(b, &nums)
-	if err != nil {
-		return err
-	}
-
-	for _, item := range nums {
-		switch value := item.(type) {
-		case int:
-			n = append(n, item.(int))
-		case float64:
-			n = append(n, int(value))
-		case string:
-			num, err := strconv.Atoi(value)
-			if err != nil {
-				return err
-			}
-			n = append(n, num)
-		}
-	}
-
-	*c = n
-	return nil
+	Can                        *map[string]bool         `json:"can,omitempty"`                       // Operations the current user is able to perform on this object
+	AvatarUrl                  string                   `json:"avatar_url,omitempty"`                // URL for the avatar image (may be generic)
+	AvatarUrlWithoutSizing     string                   `json:"avatar_url_without_sizing,omitempty"` // URL for the avatar image (may be generic), does not specify size
+	CredentialsEmail           *CredentialsEmail        `json:"credentials_email,omitempty"`
+	CredentialsEmbed           *[]CredentialsEmbed      `json:"credentials_embed,omitempty"` // Embed credentials
+	CredentialsApi3            []CredentialsApi3        `json:"credentials_api3,omitempty"`  // API 3 credentials
+	CredentialsGoogle          *CredentialsGoogle       `json:"credentials_google,omitempty"`
+	CredentialsLdap            *CredentialsLDAP         `json:"credentials_ldap,omitempty"`
+	CredentialsLookerOpenid    *CredentialsLookerOpenid `json:"credentials_looker_openid,omitempty"`
+	CredentialsOidc            *CredentialsOIDC         `json:"credentials_oidc,omitempty"`
+	CredentialsTotp            *CredentialsTotp         `json:"credentials_totp,omitempty"`
+	CredentialsSaml            *CredentialsSaml         `json:"credentials_saml,omitempty"`
+	DisplayName                string                   `json:"display_name,omitempty"`                   // Full name for display (available only if both first_name and last_name are set)
+	Email                      string                   `json:"email,omitempty"`                          // EMail address
+	EmbedGroupSpaceId          string                   `json:"embed_group_space_id,omitempty"`           // (DEPRECATED) (Embed only) ID of user's group space based on the external_group_id optionally specified during embed user login
+	FirstName                  string                   `json:"first_name,omitempty"`                     // First name
+	GroupIds                   IDs                      `json:"group_ids,omitempty"`                      // Array of ids of the groups for this user
+	HomeFolderId               string                   `json:"home_folder_id,omitempty"`                 // ID string for user's home folder
+	Id                         ID                       `json:"id,omitempty"`                             // Unique Id
+	IsDisabled                 bool                     `json:"is_disabled,omitempty"`                    // Account has been disabled
+	LastName                   string                   `json:"last_name,omitempty"`                      // Last name
+	Locale                     string                   `json:"locale,omitempty"`                         // User's preferred locale. User locale takes precedence over Looker's system-wide default locale. Locale determines language of display strings and date and numeric formatting in API responses. Locale string must be a 2 letter language code or a combination of language code and region code: 'en' or 'en-US', for example.
+	LookerVersions             []string                 `json:"looker_versions,omitempty"`                // Array of strings representing the Looker versions that this user has used (this only goes back as far as '3.54.0')
+	ModelsDirValidated         bool                     `json:"models_dir_validated,omitempty"`           // User's dev workspace has been checked for presence of applicable production projects
+	PersonalFolderId           string                   `json:"personal_folder_id,omitempty"`             // ID of user's personal folder
+	PresumedLookerEmployee     bool                     `json:"presumed_looker_employee,omitempty"`       // User is identified as an employee of Looker
+	RoleIds                    IDs                      `json:"role_ids,omitempty"`                       // Array of ids of the roles for this user
+	UiState                    map[string]interface{}   `json:"ui_state,omitempty"`                       // Per user dictionary of undocumented state information owned by the Looker UI.
+	VerifiedLookerEmployee     bool                     `json:"verified_looker_employee,omitempty"`       // User is identified as an employee of Looker who has been verified via Looker corporate authentication
+	RolesExternallyManaged     bool                     `json:"roles_externally_managed,omitempty"`       // User's roles are managed by an external directory like SAML or LDAP and can not be changed directly.
+	AllowDirectRoles           bool                     `json:"allow_direct_roles,omitempty"`             // User can be directly assigned a role.
+	AllowNormalGroupMembership bool                     `json:"allow_normal_group_membership,omitempty"`  // User can be a direct member of a normal Looker group.
+	AllowRolesFromNormalGroups bool                     `json:"allow_roles_from_normal_groups,omitempty"` // User can inherit roles from a normal Looker group.
+	EmbedGroupFolderId         string                   `json:"embed_group_folder_id,omitempty"`          // (Embed only) ID of user's group folder based on the external_group_id optionally specified during embed user login
+	Url                        string                   `json:"url,omitempty"`                            // Link to get this item
 }
 
-func (c *SliceStringInts) ToSliceOfStrings() []string {
-	var ret []string
-
-	for _, item := range *c {
-		ret = append(ret, strconv.Itoa(item))
-	}
-
-	return ret
-}
-
-func FromSliceOfStrings(s []string) SliceStringInts {
-	var ret SliceStringInts
-
-	for _, item := range s {
-		itemInt, _ := strconv.Atoi(item)
-		ret = append(ret, itemInt)
-	}
-
-	return ret
+// WriteUser is the body of a user update. Fields left nil are not changed.
+// Ref: https://developers.looker.com/api/explorer/4.0/types/User/WriteUser
+type WriteUser struct {
+	FirstName          *Nullable[string]                 `json:"first_name,omitempty"`           // First name
+	HomeFolderId       *Nullable[string]                 `json:"home_folder_id,omitempty"`       // ID string for user's home folder
+	IsDisabled         *Nullable[bool]                   `json:"is_disabled,omitempty"`          // Account has been disabled
+	LastName           *Nullable[string]                 `json:"last_name,omitempty"`            // Last name
+	Locale             *Nullable[string]                 `json:"locale,omitempty"`               // User's preferred locale
+	ModelsDirValidated *Nullable[bool]                   `json:"models_dir_validated,omitempty"` // User's dev workspace has been checked for presence of applicable production projects
+	UiState            *Nullable[map[string]interface{}] `json:"ui_state,omitempty"`             // Per user dictionary of undocumented state information owned by the Looker UI.
 }
 
 // UsersResource is an interface for interfacing with the User resource endpoints of the API.
 // Ref: https://developers.looker.com/api/explorer/4.0/methods/User
 type UsersResource interface {
 	List(context.Context, *ListOptions) ([]User, *Response, error)
-	ListById(context.Context, []string, *ListOptions) ([]User, *Response, error)
+	ListById(context.Context, []ID, *ListOptions) ([]User, *Response, error)
 	ListByEmail(context.Context, string, *ListOptions) ([]User, *Response, error)
-	Get(context.Context, string) (*User, *Response, error)
+	Get(context.Context, ID, *GetOptions) (*User, *Response, error)
 	Create(context.Context, *User) (*User, *Response, error)
-	Update(context.Context, string, *User) (*User, *Response, error)
-	Delete(context.Context, string) (*Response, error)
-	CreateEmail(context.Context, string, *CredentialsEmail) (*CredentialsEmail, *Response, error)
-	GetEmail(context.Context, string) (*CredentialsEmail, *Response, error)
-	UpdateEmail(context.Context, string, *CredentialsEmail) (*CredentialsEmail, *Response, error)
-	DeleteEmail(context.Context, string) (*Response, error)
-	CreatePasswordReset(context.Context, string) (*CredentialsEmail, *Response, error)
-	SendPasswordReset(context.Context, string) (*CredentialsEmail, *Response, error)
-	GetRoles(context.Context, string) ([]Role, *Response, error)
-	SetRoles(context.Context, string, []string) ([]Role, *Response, error)
+	Update(context.Context, ID, *WriteUser) (*User, *Response, error)
+	Delete(context.Context, ID) (*Response, error)
+	CreateEmail(context.Context, ID, *CredentialsEmail) (*CredentialsEmail, *Response, error)
+	GetEmail(context.Context, ID) (*CredentialsEmail, *Response, error)
+	UpdateEmail(context.Context, ID, *WriteCredentialsEmail) (*CredentialsEmail, *Response, error)
+	DeleteEmail(context.Context, ID) (*Response, error)
+	CreatePasswordReset(context.Context, ID) (*CredentialsEmail, *Response, error)
+	SendPasswordReset(context.Context, ID) (*CredentialsEmail, *Response, error)
+	GetRoles(context.Context, ID) ([]Role, *Response, error)
+	SetRoles(context.Context, ID, []ID) ([]Role, *Response, error)
 }
 
 // UsersResourceOp handles operations between User related methods of the API.
@@ -178,21 +131,17 @@ type UsersResourceOp struct {
 
 // List all users
 func (s *UsersResourceOp) List(ctx context.Context, opt *ListOptions) ([]User, *Response, error) {
+	ctx = withRoute(ctx, "users")
 	return doList(ctx, s.client, userBasePath, opt, new([]User))
 }
 
-func (s *UsersResourceOp) ListById(ctx context.Context, ids []string, opt *ListOptions) ([]User, *Response, error) {
+func (s *UsersResourceOp) ListById(ctx context.Context, ids []ID, opt *ListOptions) ([]User, *Response, error) {
+	ctx = withRoute(ctx, "users/search")
 	if len(ids) == 0 {
 		return nil, nil, NewArgError("id", "specify one or more id(s)")
 	}
 
-	var idsQString string
-	for i, id := range ids {
-		idsQString += id
-		if i+1 < len(ids) {
-			idsQString += ","
-		}
-	}
+	idsQString := strings.Join(IDs(ids).Strings(), ",")
 
 	qs := url.Values{}
 	// qs.Add("fields", "id,name,user_count,role_ids")
@@ -203,6 +152,7 @@ func (s *UsersResourceOp) ListById(ctx context.Context, ids []string, opt *ListO
 	return doListByX(ctx, s.client, path, opt, new([]User), qs)
 }
 func (s *UsersResourceOp) ListByEmail(ctx context.Context, email string, opt *ListOptions) ([]User, *Response, error) {
+	ctx = withRoute(ctx, "users/search")
 	if email == "" {
 		return nil, nil, NewArgError("email", "has to be non-empty")
 	}
@@ -217,63 +167,103 @@ func (s *UsersResourceOp) ListByEmail(ctx context.Context, email string, opt *Li
 }
 
 // Get -
-func (s *UsersResourceOp) Get(ctx context.Context, id string) (*User, *Response, error) {
-	return doGetById(ctx, s.client, userBasePath, id, new(User))
+func (s *UsersResourceOp) Get(ctx context.Context, id ID, opt *GetOptions) (*User, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}")
+	return doGetById(ctx, s.client, userBasePath, id, opt, new(User))
 }
 
 // Create -
 func (s *UsersResourceOp) Create(ctx context.Context, createReq *User) (*User, *Response, error) {
+	ctx = withRoute(ctx, "users")
 	return doCreate(ctx, s.client, userBasePath, createReq, new(User))
 }
 
 // Update -
-func (s *UsersResourceOp) Update(ctx context.Context, id string, updateReq *User) (*User, *Response, error) {
+func (s *UsersResourceOp) Update(ctx context.Context, id ID, updateReq *WriteUser) (*User, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}")
 	return doUpdate(ctx, s.client, userBasePath, id, updateReq, new(User))
 }
 
 // Delete -
-func (s *UsersResourceOp) Delete(ctx context.Context, id string) (*Response, error) {
+func (s *UsersResourceOp) Delete(ctx context.Context, id ID) (*Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}")
 	return doDelete(ctx, s.client, userBasePath, id)
 }
 
 // CreateEmail -
-func (s *UsersResourceOp) CreateEmail(ctx context.Context, id string, createReq *CredentialsEmail) (*CredentialsEmail, *Response, error) {
-	return doCreate(ctx, s.client, userBasePath, createReq, new(CredentialsEmail), id, "credentials_email")
+func (s *UsersResourceOp) CreateEmail(ctx context.Context, id ID, createReq *CredentialsEmail) (*CredentialsEmail, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/credentials_email")
+	path, err := idPath(userBasePath, id, "credentials_email")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doCreate(ctx, s.client, path, createReq, new(CredentialsEmail))
 }
 
 // GetEmail -
-func (s *UsersResourceOp) GetEmail(ctx context.Context, id string) (*CredentialsEmail, *Response, error) {
-	return doGet(ctx, s.client, userBasePath, new(CredentialsEmail), id, "credentials_email")
+func (s *UsersResourceOp) GetEmail(ctx context.Context, id ID) (*CredentialsEmail, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/credentials_email")
+	path, err := idPath(userBasePath, id, "credentials_email")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doGet(ctx, s.client, path, nil, new(CredentialsEmail))
 }
 
 // UpdateEmail -
-func (s *UsersResourceOp) UpdateEmail(ctx context.Context, id string, updateReq *CredentialsEmail) (*CredentialsEmail, *Response, error) {
+func (s *UsersResourceOp) UpdateEmail(ctx context.Context, id ID, updateReq *WriteCredentialsEmail) (*CredentialsEmail, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/credentials_email")
 	return doUpdate(ctx, s.client, userBasePath, id, updateReq, new(CredentialsEmail), "credentials_email")
 }
 
 // DeleteEmail -
-func (s *UsersResourceOp) DeleteEmail(ctx context.Context, id string) (*Response, error) {
+func (s *UsersResourceOp) DeleteEmail(ctx context.Context, id ID) (*Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/credentials_email")
 	return doDelete(ctx, s.client, userBasePath, id, "credentials_email")
 }
 
 // CreatePasswordReset -
-func (s *UsersResourceOp) CreatePasswordReset(ctx context.Context, id string) (*CredentialsEmail, *Response, error) {
-	return doEmptyPost(ctx, s.client, userBasePath, new(CredentialsEmail),
-		id, "credentials_email", "password_reset")
+func (s *UsersResourceOp) CreatePasswordReset(ctx context.Context, id ID) (*CredentialsEmail, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/credentials_email/password_reset")
+	path, err := idPath(userBasePath, id, "credentials_email", "password_reset")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doEmptyPost(ctx, s.client, path, new(CredentialsEmail))
 }
 
 // SendPasswordReset -
-func (s *UsersResourceOp) SendPasswordReset(ctx context.Context, id string) (*CredentialsEmail, *Response, error) {
-	return doEmptyPost(ctx, s.client, userBasePath, new(CredentialsEmail),
-		id, "credentials_email", "send_password_reset")
+func (s *UsersResourceOp) SendPasswordReset(ctx context.Context, id ID) (*CredentialsEmail, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/credentials_email/send_password_reset")
+	path, err := idPath(userBasePath, id, "credentials_email", "send_password_reset")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doEmptyPost(ctx, s.client, path, new(CredentialsEmail))
 }
 
 // GetRoles -
-func (s *UsersResourceOp) GetRoles(ctx context.Context, id string) ([]Role, *Response, error) {
-	return doList(ctx, s.client, userBasePath, nil, new([]Role), id, "roles")
+func (s *UsersResourceOp) GetRoles(ctx context.Context, id ID) ([]Role, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/roles")
+	path, err := idPath(userBasePath, id, "roles")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doList(ctx, s.client, path, nil, new([]Role))
 }
 
 // SetRoles -
-func (s *UsersResourceOp) SetRoles(ctx context.Context, id string, roleIds []string) ([]Role, *Response, error) {
-	return doSet(ctx, s.client, userBasePath, roleIds, new([]Role), id, "roles")
+func (s *UsersResourceOp) SetRoles(ctx context.Context, id ID, roleIds []ID) ([]Role, *Response, error) {
+	ctx = withRoute(ctx, "users/{user_id}/roles")
+	path, err := idPath(userBasePath, id, "roles")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return doSet(ctx, s.client, path, roleIds, new([]Role))
 }
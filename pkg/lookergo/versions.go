@@ -0,0 +1,162 @@
+package lookergo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The versions endpoint is not versioned itself, it tells which API versions the instance serves.
+const versionsBasePath = "versions"
+
+// VersionsResource tells the release of the Looker instance and the API versions it supports.
+// Ref: https://developers.looker.com/api/explorer/4.0/methods/ApiAuth/versions
+type VersionsResource interface {
+	Get(context.Context) (*ApiVersion, *Response, error)
+}
+
+type VersionsResourceOp struct {
+	client *Client
+}
+
+var _ VersionsResource = &VersionsResourceOp{}
+
+// Get -
+func (s *VersionsResourceOp) Get(ctx context.Context) (*ApiVersion, *Response, error) {
+	ctx = withRoute(ctx, "versions")
+	// Not below the API version of the client, which may not be served.
+	req, err := s.client.NewRequest(ctx, http.MethodGet, versionsBasePath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versions := new(ApiVersion)
+	resp, err := s.client.Do(ctx, req, versions)
+	if err != nil {
+		return nil, resp, err
+	}
+	return versions, resp, nil
+}
+
+// Release returns the parsed LookerReleaseVersion.
+func (v *ApiVersion) Release() (Version, error) {
+	return ParseVersion(v.LookerReleaseVersion)
+}
+
+// Supports reports whether the instance serves the API version apiVersion, e.g. "4.0".
+func (v *ApiVersion) Supports(apiVersion string) bool {
+	for _, e := range v.SupportedVersions {
+		if e.Version == apiVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedVersionNames returns the API versions the instance serves, e.g. []string{"3.1", "4.0"}.
+func (v *ApiVersion) SupportedVersionNames() []string {
+	names := make([]string, 0, len(v.SupportedVersions))
+	for _, e := range v.SupportedVersions {
+		names = append(names, e.Version)
+	}
+	return names
+}
+
+// Version is a Looker release, like 21.0 or 22.14.18. The zero Version is unknown.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a release as found in ApiVersion.LookerReleaseVersion or in the documentation of the
+// API: two or three numbers separated by dots. Anything after the numbers, like "-beta", is ignored.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	core := strings.TrimSpace(s)
+	if i := strings.IndexFunc(core, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return v, fmt.Errorf("invalid Looker version %q", s)
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid Looker version %q", s)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// MustParseVersion is like ParseVersion but panics on invalid input. It is meant for constants.
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// IsZero reports whether the version is unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is older than, the same as or newer than o.
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is min or newer.
+func (v Version) AtLeast(min Version) bool {
+	return v.Compare(min) >= 0
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// VersionError reports a feature the Looker instance is too old for.
+type VersionError struct {
+	// What needs the version, e.g. an attribute name.
+	Feature string
+	// First release supporting the feature.
+	Required Version
+	// Release of the instance.
+	Actual Version
+}
+
+var _ error = &VersionError{}
+
+func (e *VersionError) Error() string {
+	required := e.Required.String()
+	if e.Required.Patch == 0 {
+		required = fmt.Sprintf("%d.%d", e.Required.Major, e.Required.Minor)
+	}
+	return fmt.Sprintf("%s is supported only in Looker %s and higher, the instance runs Looker %s", e.Feature, required, e.Actual)
+}
+
+// RequireVersion returns a *VersionError if actual is older than required, nil otherwise. An unknown (zero)
+// actual version passes: the API then decides.
+func RequireVersion(actual Version, required string, feature string) error {
+	min, err := ParseVersion(required)
+	if err != nil {
+		return err
+	}
+	if actual.IsZero() || actual.AtLeast(min) {
+		return nil
+	}
+	return &VersionError{Feature: feature, Required: min, Actual: actual}
+}
@@ -0,0 +1,118 @@
+package lookergo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestVersionsResourceOp_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `
+{
+  "looker_release_version": "22.14.18",
+  "current_version": {"version": "4.0", "full_version": "4.0.22.14", "status": "current", "swagger_url": "https://localhost:19999/api/4.0/swagger.json"},
+  "supported_versions": [
+    {"version": "3.1", "full_version": "3.1.0", "status": "legacy", "swagger_url": "https://localhost:19999/api/3.1/swagger.json"},
+    {"version": "4.0", "full_version": "4.0.22.14", "status": "current", "swagger_url": "https://localhost:19999/api/4.0/swagger.json"}
+  ],
+  "api_server_url": "https://localhost:19999",
+  "web_server_url": "https://localhost:9999"
+}`)
+	})
+
+	versions, _, err := client.Versions.Get(ctx)
+	if err != nil {
+		t.Fatalf("Versions.Get returned error: %v", err)
+	}
+
+	release, err := versions.Release()
+	if err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if expected := (Version{22, 14, 18}); release != expected {
+		t.Errorf("Release = %v, expected %v", release, expected)
+	}
+	if expected := []string{"3.1", "4.0"}; !reflect.DeepEqual(versions.SupportedVersionNames(), expected) {
+		t.Errorf("SupportedVersionNames = %v, expected %v", versions.SupportedVersionNames(), expected)
+	}
+	if !versions.Supports("4.0") || versions.Supports("5.0") {
+		t.Errorf("Supports is wrong for %v", versions.SupportedVersionNames())
+	}
+	if versions.CurrentVersion == nil || versions.CurrentVersion.FullVersion != "4.0.22.14" {
+		t.Errorf("CurrentVersion = %+v", versions.CurrentVersion)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]Version{
+		"21.0":         {21, 0, 0},
+		"7.20.3":       {7, 20, 3},
+		" 22.14.18 ":   {22, 14, 18},
+		"23.0.1-beta":  {23, 0, 1},
+		"22.20.5.1234": {},
+		"22":           {},
+		"":             {},
+		"a.b":          {},
+		"22.x":         {},
+	}
+	for in, expected := range tests {
+		v, err := ParseVersion(in)
+		if expected.IsZero() {
+			if err == nil {
+				t.Errorf("ParseVersion(%q) = %v, expected an error", in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseVersion(%q) returned error: %v", in, err)
+		} else if v != expected {
+			t.Errorf("ParseVersion(%q) = %v, expected %v", in, v, expected)
+		}
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"21.0", "21.0.0", 0},
+		{"7.20.3", "21.0", -1},
+		{"21.0.1", "21.0", 1},
+		{"22.14", "22.2", 1},
+	}
+	for _, tt := range tests {
+		if got := MustParseVersion(tt.a).Compare(MustParseVersion(tt.b)); got != tt.expected {
+			t.Errorf("%s.Compare(%s) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestRequireVersion(t *testing.T) {
+	if err := RequireVersion(MustParseVersion("21.0.0"), "21.0", "git_production_branch_name"); err != nil {
+		t.Errorf("RequireVersion returned error for the required release: %v", err)
+	}
+	if err := RequireVersion(Version{}, "21.0", "git_production_branch_name"); err != nil {
+		t.Errorf("RequireVersion returned error for an unknown release: %v", err)
+	}
+	if err := RequireVersion(Version{}, "21", "x"); err == nil {
+		t.Error("RequireVersion accepted an invalid required release")
+	}
+
+	err := RequireVersion(MustParseVersion("7.20.3"), "21.0", "git_production_branch_name")
+	var versionErr *VersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("RequireVersion returned %v, expected a *VersionError", err)
+	}
+	expected := "git_production_branch_name is supported only in Looker 21.0 and higher, the instance runs Looker 7.20.3"
+	if err.Error() != expected {
+		t.Errorf("Error = %q, expected %q", err.Error(), expected)
+	}
+}
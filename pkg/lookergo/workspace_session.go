@@ -0,0 +1,79 @@
+package lookergo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WorkspaceSession provides a client working in a workspace of the API, e.g. the dev workspace in which LookML
+// projects are changed, without changing the workspace of the client it derives from.
+//
+// The client is created on first use and acts as the user of the parent client, see AsUser: each of its tokens is
+// switched to the workspace before it is used, and renewed when it expires or when the API rejects it. A
+// WorkspaceSession is safe for concurrent use. Close logs it out.
+type WorkspaceSession struct {
+	parent    *Client
+	workspace string
+
+	mu     sync.Mutex
+	client *Client
+	closed bool
+}
+
+// NewWorkspaceSession returns a session of the workspace workspaceID ("dev" or "production") for the user of parent.
+// No request is made until Client is called.
+func NewWorkspaceSession(parent *Client, workspaceID string) *WorkspaceSession {
+	return &WorkspaceSession{parent: parent, workspace: workspaceID}
+}
+
+// Client returns the client of the session, logging in on the first call. After Close, it returns ErrLoggedOut.
+func (s *WorkspaceSession) Client(ctx context.Context) (*Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrLoggedOut
+	}
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	user, _, err := s.parent.Sessions.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.parent.asUser(ctx, user.Id, s.enter)
+	if err != nil {
+		return nil, err
+	}
+	c = c.WithWorkspace(s.workspace)
+	s.client = c
+	return c, nil
+}
+
+// enter switches the session of a new token to the workspace.
+func (s *WorkspaceSession) enter(ctx context.Context, c *Client) error {
+	session, _, err := c.Sessions.SetWorkspaceId(ctx, s.workspace)
+	if err != nil {
+		return err
+	}
+	if session.WorkspaceId != s.workspace {
+		return fmt.Errorf("session is in the %q workspace, expected %q", session.WorkspaceId, s.workspace)
+	}
+	return nil
+}
+
+// Close logs the session out, if it was used. The session cannot be used afterwards.
+func (s *WorkspaceSession) Close(ctx context.Context) error {
+	s.mu.Lock()
+	c := s.client
+	s.client, s.closed = nil, true
+	s.mu.Unlock()
+
+	if c == nil {
+		return nil
+	}
+	_, err := c.Logout(ctx)
+	return err
+}
@@ -0,0 +1,80 @@
+package lookergo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/devoteamgcloud/terraform-provider-looker/pkg/lookergo/lookertest"
+)
+
+func TestWorkspaceSession(t *testing.T) {
+	srv := lookertest.NewServer()
+	defer srv.Close()
+
+	parent, err := New(WithBaseURL(srv.BaseURL()), WithOAuthCredentials(srv.ClientID, srv.ClientSecret), WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	session := NewWorkspaceSession(parent, "dev")
+	if srv.Tokens() != 0 {
+		t.Fatalf("%d tokens before the first use, expected none", srv.Tokens())
+	}
+
+	// Concurrent resource operations share the session.
+	clients := make([]*Client, 8)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := session.Client(ctx)
+			if err != nil {
+				t.Errorf("Client returned error: %v", err)
+				return
+			}
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+	dev := clients[0]
+	for _, c := range clients {
+		if c != dev {
+			t.Fatal("Client returned different clients")
+		}
+	}
+	if dev.Workspace() != "dev" || parent.Workspace() != "production" {
+		t.Errorf("Workspace() = %q, parent %q", dev.Workspace(), parent.Workspace())
+	}
+
+	// Projects can only be created in the dev workspace.
+	if _, _, err := dev.Projects.Create(ctx, &Project{Name: "marketing"}); err != nil {
+		t.Fatalf("Projects.Create returned error: %v", err)
+	}
+	parentSession, _, err := parent.Sessions.Get(ctx)
+	if err != nil || parentSession.WorkspaceId != "production" {
+		t.Errorf("parent session = %+v, %v, expected production", parentSession, err)
+	}
+
+	// A token rejected by the API is renewed, and switched to the workspace again.
+	if !srv.RevokeToken(dev.sudo.current().AccessToken) {
+		t.Fatal("RevokeToken did not find the token of the session")
+	}
+	if _, _, err := dev.Projects.Update(ctx, "marketing", &WriteProject{PullRequestMode: Value("links")}); err != nil {
+		t.Fatalf("Projects.Update after the revocation returned error: %v", err)
+	}
+
+	before := srv.Tokens()
+	if err := session.Close(ctx); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if srv.Tokens() != before-1 {
+		t.Errorf("%d tokens after Close, expected %d", srv.Tokens(), before-1)
+	}
+	if _, err := session.Client(ctx); !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("Client after Close returned %v, expected ErrLoggedOut", err)
+	}
+	if _, _, err := dev.Projects.Get(ctx, "marketing", nil); !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("Projects.Get after Close returned %v, expected ErrLoggedOut", err)
+	}
+}